@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"github.com/kyverno/kyverno/pkg/auditlog"
 	"github.com/kyverno/kyverno/pkg/cosign"
 	"net/http"
 	_ "net/http/pprof"
@@ -28,14 +29,17 @@ import (
 	generatecleanup "github.com/kyverno/kyverno/pkg/generate/cleanup"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/kyverno/kyverno/pkg/metrics/workqueuemetrics"
 	"github.com/kyverno/kyverno/pkg/openapi"
 	"github.com/kyverno/kyverno/pkg/policy"
 	"github.com/kyverno/kyverno/pkg/policycache"
 	"github.com/kyverno/kyverno/pkg/policyreport"
 	"github.com/kyverno/kyverno/pkg/policystatus"
 	"github.com/kyverno/kyverno/pkg/resourcecache"
+	"github.com/kyverno/kyverno/pkg/resultsink"
 	"github.com/kyverno/kyverno/pkg/signal"
 	ktls "github.com/kyverno/kyverno/pkg/tls"
+	"github.com/kyverno/kyverno/pkg/tracing"
 	"github.com/kyverno/kyverno/pkg/utils"
 	"github.com/kyverno/kyverno/pkg/version"
 	"github.com/kyverno/kyverno/pkg/webhookconfig"
@@ -61,6 +65,18 @@ var (
 	disableMetricsExport         bool
 	policyControllerResyncPeriod time.Duration
 	imagePullSecrets             string
+	batchReports                 bool
+	reportFlushInterval          time.Duration
+	auditLogPath                 string
+	auditLogMaxSize              int
+	auditLogMaxAge               time.Duration
+	auditLogMaxBackups           int
+	auditLogSampleRate           = auditlog.SampleRate(1)
+	tracingEndpoint              string
+	tracingInsecure              bool
+	tracingSampleRatio           float64
+	eventRateLimitQPS            float64
+	eventRateLimitBurst          int
 	setupLog                     = log.Log.WithName("setup")
 )
 
@@ -80,6 +96,18 @@ func main() {
 	flag.StringVar(&metricsPort, "metrics-port", "8000", "Expose prometheus metrics at the given port, default to 8000.")
 	flag.DurationVar(&policyControllerResyncPeriod, "background-scan", time.Hour, "Perform background scan every given interval, e.g., 30s, 15m, 1h.")
 	flag.StringVar(&imagePullSecrets, "imagePullSecrets", "", "Secret resource names for image registry access credentials")
+	flag.BoolVar(&batchReports, "batch-reports", false, "Set this flag to 'true', to aggregate the policy report results in memory and write the reports directly instead of creating report change requests. The reports are written by the leader, the other replicas create report change requests.")
+	flag.DurationVar(&reportFlushInterval, "report-flush-interval", 10*time.Second, "Interval to write the changed policy reports when batch-reports is enabled, e.g., 10s, 1m.")
+	flag.StringVar(&auditLogPath, "audit-log-path", "", "Path of the file the admission decisions of the resource webhooks and the results of the validate audit and generate policies are logged to. The audit log is disabled if empty.")
+	flag.IntVar(&auditLogMaxSize, "audit-log-max-size", 100, "Maximum size in megabytes of the audit log before it is rotated, 0 disables size based rotation.")
+	flag.DurationVar(&auditLogMaxAge, "audit-log-max-age", 24*time.Hour, "Maximum age of the audit log before it is rotated, 0 disables age based rotation.")
+	flag.IntVar(&auditLogMaxBackups, "audit-log-max-backups", 10, "Maximum number of rotated audit log files to keep, 0 keeps all of them.")
+	flag.Var(&auditLogSampleRate, "audit-log-sample-rate", "Fraction of the successful admission requests written to the audit log, between 0 and 1. Denied requests and requests with failed rules are always logged.")
+	flag.StringVar(&tracingEndpoint, "tracing-endpoint", "", "Address of the OTLP gRPC collector the traces are exported to, e.g. otel-collector:4317. Tracing is disabled if empty.")
+	flag.BoolVar(&tracingInsecure, "tracing-insecure", false, "Set this flag to 'true', to export the traces without TLS.")
+	flag.Float64Var(&tracingSampleRatio, "tracing-sample-ratio", 1, "Fraction of the traces started by Kyverno that are sampled, between 0 and 1. The sampling decision of a propagated trace context is kept.")
+	flag.Float64Var(&eventRateLimitQPS, "event-rate-limit-qps", 10, "Maximum number of events per second emitted by each Kyverno controller.")
+	flag.IntVar(&eventRateLimitBurst, "event-rate-limit-burst", 100, "Maximum burst of events emitted by each Kyverno controller.")
 
 	if err := flag.Set("v", "2"); err != nil {
 		setupLog.Error(err, "failed to set log level")
@@ -97,6 +125,23 @@ func main() {
 		os.Exit(1)
 	}
 
+	if tracingEndpoint != "" {
+		tracerProvider, err := tracing.NewOTLPProvider(context.Background(), tracingEndpoint, tracingInsecure, tracingSampleRatio)
+		if err != nil {
+			setupLog.Error(err, "Failed to create the trace exporter")
+			os.Exit(1)
+		}
+
+		setupLog.Info("Enable tracing", "endpoint", tracingEndpoint, "sampleRatio", tracingSampleRatio)
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := tracerProvider.Shutdown(ctx); err != nil {
+				setupLog.Error(err, "Failed to flush the traces")
+			}
+		}()
+	}
+
 	var profilingServerMux *http.ServeMux
 	var metricsServerMux *http.ServeMux
 	var promConfig *metrics.PromConfig
@@ -113,20 +158,6 @@ func main() {
 		}()
 	}
 
-	if !disableMetricsExport {
-		promConfig = metrics.NewPromConfig()
-		metricsServerMux = http.NewServeMux()
-		metricsServerMux.Handle("/metrics", promhttp.HandlerFor(promConfig.MetricsRegistry, promhttp.HandlerOpts{Timeout: 10 * time.Second}))
-		metricsAddr := ":" + metricsPort
-		go func() {
-			setupLog.Info("enabling metrics service", "address", metricsAddr)
-			if err := http.ListenAndServe(metricsAddr, metricsServerMux); err != nil {
-				setupLog.Error(err, "failed to enable metrics service", "address", metricsAddr)
-				os.Exit(1)
-			}
-		}()
-	}
-
 	// KYVERNO CRD CLIENT
 	// access CRD resources
 	//		- ClusterPolicy, Policy
@@ -159,6 +190,28 @@ func main() {
 		os.Exit(1)
 	}
 
+	if !disableMetricsExport {
+		metricsConfig, err := config.LoadMetricsConfig(kubeClient, log.Log.WithName("MetricsConfig"))
+		if err != nil {
+			setupLog.Error(err, "Failed to load the metrics configuration")
+			os.Exit(1)
+		}
+
+		promConfig = metrics.NewPromConfig(metricsConfig)
+		// the workqueue metrics are only exposed for the queues created after the registration
+		workqueuemetrics.ParsePromMetrics(*promConfig.Metrics).Register()
+		metricsServerMux = http.NewServeMux()
+		metricsServerMux.Handle("/metrics", promhttp.HandlerFor(promConfig.MetricsRegistry, promhttp.HandlerOpts{Timeout: 10 * time.Second}))
+		metricsAddr := ":" + metricsPort
+		go func() {
+			setupLog.Info("enabling metrics service", "address", metricsAddr)
+			if err := http.ListenAndServe(metricsAddr, metricsServerMux); err != nil {
+				setupLog.Error(err, "failed to enable metrics service", "address", metricsAddr)
+				os.Exit(1)
+			}
+		}()
+	}
+
 	kubeInformer := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod)
 	kubedynamicInformer := client.NewDynamicSharedInformerFactory(resyncPeriod)
 
@@ -188,11 +241,15 @@ func main() {
 
 	// EVENT GENERATOR
 	// - generate event with retry mechanism
+	// - rate limit the events per source, repeated events are aggregated into series by the broadcaster
 	eventGenerator := event.NewEventGenerator(
-		client,
+		kubeClient,
 		pInformer.Kyverno().V1().ClusterPolicies(),
 		pInformer.Kyverno().V1().Policies(),
 		rCache,
+		float32(eventRateLimitQPS),
+		eventRateLimitBurst,
+		promConfig,
 		log.Log.WithName("EventGenerator"))
 
 	// Policy Status Handler - deals with all logic related to policy status
@@ -202,6 +259,9 @@ func main() {
 		pInformer.Kyverno().V1().Policies().Lister())
 
 	// POLICY Report GENERATOR
+	// - batched: the leader aggregates the results in memory and writes the policy reports,
+	//   the other replicas create report change requests
+	// - otherwise: creates report change requests which are merged into the policy reports
 	reportReqGen := policyreport.NewReportChangeRequestGenerator(pclient,
 		client,
 		pInformer.Kyverno().V1alpha1().ReportChangeRequests(),
@@ -212,12 +272,34 @@ func main() {
 		log.Log.WithName("ReportChangeRequestGenerator"),
 	)
 
+	var reportWriter *policyreport.ReportWriter
+	var prGenerator policyreport.GeneratorInterface = reportReqGen
+	if batchReports {
+		reportWriter = policyreport.NewReportWriter(
+			client,
+			pInformer.Wgpolicyk8s().V1alpha2().ClusterPolicyReports(),
+			pInformer.Wgpolicyk8s().V1alpha2().PolicyReports(),
+			kubeInformer.Core().V1().Namespaces(),
+			pInformer.Kyverno().V1().ClusterPolicies(),
+			pInformer.Kyverno().V1().Policies(),
+			reportReqGen,
+			reportFlushInterval,
+			log.Log.WithName("PolicyReportWriter"),
+		)
+		prGenerator = reportWriter
+	}
+
+	// RESULT SINKS
+	// - publishes the policy results to the external sinks configured in the Kyverno ConfigMap
+	resultSinkManager := resultsink.NewManager(promConfig, log.Log.WithName("ResultSinks"))
+	backgroundPrGenerator := resultsink.NewReportGenerator(prGenerator, resultSinkManager)
+
 	prgen, err := policyreport.NewReportGenerator(
 		kubeClient,
 		pclient,
 		client,
-		pInformer.Wgpolicyk8s().V1alpha1().ClusterPolicyReports(),
-		pInformer.Wgpolicyk8s().V1alpha1().PolicyReports(),
+		pInformer.Wgpolicyk8s().V1alpha2().ClusterPolicyReports(),
+		pInformer.Wgpolicyk8s().V1alpha2().PolicyReports(),
 		pInformer.Kyverno().V1alpha1().ReportChangeRequests(),
 		pInformer.Kyverno().V1alpha1().ClusterReportChangeRequests(),
 		kubeInformer.Core().V1().Namespaces(),
@@ -234,6 +316,9 @@ func main() {
 		clientConfig,
 		client,
 		rCache,
+		pInformer.Kyverno().V1().ClusterPolicies(),
+		pInformer.Kyverno().V1().Policies(),
+		statusSync.Listener,
 		serverIP,
 		int32(webhookTimeout),
 		debug,
@@ -257,6 +342,7 @@ func main() {
 		excludeUsername,
 		prgen.ReconcileCh,
 		webhookCfg.UpdateWebhookChan,
+		resultSinkManager.UpdateSinksChan,
 		log.Log.WithName("ConfigData"),
 	)
 
@@ -273,13 +359,14 @@ func main() {
 		pInformer.Kyverno().V1().GenerateRequests(),
 		configData,
 		eventGenerator,
-		reportReqGen,
+		backgroundPrGenerator,
 		prgen,
 		kubeInformer.Core().V1().Namespaces(),
 		log.Log.WithName("PolicyController"),
 		rCache,
 		policyControllerResyncPeriod,
 		promConfig,
+		statusSync.Listener,
 	)
 
 	if err != nil {
@@ -299,6 +386,7 @@ func main() {
 		pInformer.Kyverno().V1().ClusterPolicies(),
 		pInformer.Kyverno().V1().GenerateRequests(),
 		eventGenerator,
+		backgroundPrGenerator,
 		kubedynamicInformer,
 		statusSync.Listener,
 		log.Log.WithName("GenerateController"),
@@ -329,14 +417,26 @@ func main() {
 	pCacheController := policycache.NewPolicyCacheController(
 		pInformer.Kyverno().V1().ClusterPolicies(),
 		pInformer.Kyverno().V1().Policies(),
+		promConfig,
+		statusSync.Listener,
 		log.Log.WithName("PolicyCacheController"),
 	)
 
+	// AUDIT LOG
+	// - records the admission decisions of the resource webhooks, the validate audit and the generate policies on local disk
+	var auditLog *auditlog.Logger
+	if auditLogPath != "" {
+		auditLog = auditlog.NewLogger(auditLogPath, auditLogMaxSize, auditLogMaxAge, auditLogMaxBackups, float64(auditLogSampleRate), log.Log.WithName("AuditLog"))
+		go auditLog.Run(stopCh)
+	}
+
 	auditHandler := webhooks.NewValidateAuditHandler(
 		pCacheController.Cache,
 		eventGenerator,
 		statusSync.Listener,
-		reportReqGen,
+		prGenerator,
+		resultSinkManager,
+		auditLog,
 		kubeInformer.Rbac().V1().RoleBindings(),
 		kubeInformer.Rbac().V1().ClusterRoleBindings(),
 		kubeInformer.Core().V1().Namespaces(),
@@ -431,7 +531,9 @@ func main() {
 		webhookMonitor,
 		statusSync.Listener,
 		configData,
-		reportReqGen,
+		prGenerator,
+		resultSinkManager,
+		auditLog,
 		grgen,
 		auditHandler,
 		cleanUp,
@@ -455,6 +557,9 @@ func main() {
 		go prgen.Run(1, stopCh)
 		go grc.Run(genWorkers, stopCh)
 		go grcc.Run(1, stopCh)
+		if batchReports {
+			go reportWriter.Run(stopCh)
+		}
 	}
 
 	kubeClientLeaderElection, err := utils.NewKubeClient(clientConfig)
@@ -484,9 +589,11 @@ func main() {
 
 	go reportReqGen.Run(2, stopCh)
 	go configData.Run(stopCh)
-	go eventGenerator.Run(3, stopCh)
+	go resultSinkManager.Run(configData, stopCh)
+	go eventGenerator.Run(3, configData, stopCh)
 	go grgen.Run(10, stopCh)
 	go statusSync.Run(1, stopCh)
+	go webhookCfg.UpdatePolicyConditions(stopCh)
 	go pCacheController.Run(1, stopCh)
 	go auditHandler.Run(10, stopCh)
 	if !debug {
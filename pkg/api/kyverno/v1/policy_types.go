@@ -48,6 +48,12 @@ type Spec struct {
 	// +optional
 	ValidationFailureAction string `json:"validationFailureAction,omitempty" yaml:"validationFailureAction,omitempty"`
 
+	// ValidationFailureActionOverrides is a list of ValidationFailureActionOverride instances
+	// which override the ValidationFailureAction for the namespaces they select.
+	// The first override that selects the namespace of a resource is applied.
+	// +optional
+	ValidationFailureActionOverrides []ValidationFailureActionOverride `json:"validationFailureActionOverrides,omitempty" yaml:"validationFailureActionOverrides,omitempty"`
+
 	// Background controls if rules are applied to existing resources during a background scan.
 	// Optional. Default value is "true". The value must be set to "false" if the policy rule
 	// uses variables that are only available in the admission review request (e.g. user name).
@@ -55,6 +61,24 @@ type Spec struct {
 	Background *bool `json:"background,omitempty" yaml:"background,omitempty"`
 }
 
+// ValidationFailureActionOverride overrides the ValidationFailureAction of a policy
+// for the namespaces selected by name or by labels.
+type ValidationFailureActionOverride struct {
+	// Action is the validation failure action applied in the selected namespaces,
+	// either "enforce" or "audit".
+	Action string `json:"action,omitempty" yaml:"action,omitempty"`
+
+	// Namespaces is a list of namespace names. The names support wildcard characters
+	// "*" (matches zero or many characters) and "?" (at least one character).
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+
+	// NamespaceSelector is a label selector for the namespaces. When both namespaces and
+	// namespaceSelector are specified, a namespace must satisfy both.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty" yaml:"namespaceSelector,omitempty"`
+}
+
 // Rule defines a validation, mutation, or generation control for matching resources.
 // Each rules contains a match declaration to select resources, and an optional exclude
 // declaration to specify which resources to exclude.
@@ -219,6 +243,16 @@ const (
 // MatchResources is used to specify resource and admission review request data for
 // which a policy rule is applicable.
 type MatchResources struct {
+	// Any allows specifying resources which will be ORed.
+	// Cannot be used together with the resources and user info fields.
+	// +optional
+	Any ResourceFilters `json:"any,omitempty" yaml:"any,omitempty"`
+
+	// All allows specifying resources which will be ANDed.
+	// Cannot be used together with the resources and user info fields.
+	// +optional
+	All ResourceFilters `json:"all,omitempty" yaml:"all,omitempty"`
+
 	// UserInfo contains information about the user performing the operation.
 	// +optional
 	UserInfo `json:",omitempty" yaml:",omitempty"`
@@ -231,6 +265,30 @@ type MatchResources struct {
 // ExcludeResources specifies resource and admission review request data for
 // which a policy rule is not applicable.
 type ExcludeResources struct {
+	// Any allows specifying resources which will be ORed.
+	// Cannot be used together with the resources and user info fields.
+	// +optional
+	Any ResourceFilters `json:"any,omitempty" yaml:"any,omitempty"`
+
+	// All allows specifying resources which will be ANDed.
+	// Cannot be used together with the resources and user info fields.
+	// +optional
+	All ResourceFilters `json:"all,omitempty" yaml:"all,omitempty"`
+
+	// UserInfo contains information about the user performing the operation.
+	// +optional
+	UserInfo `json:",omitempty" yaml:",omitempty"`
+
+	// ResourceDescription contains information about the resource being created or modified.
+	// +optional
+	ResourceDescription `json:"resources,omitempty" yaml:"resources,omitempty"`
+}
+
+// ResourceFilters is a slice of ResourceFilter
+type ResourceFilters []ResourceFilter
+
+// ResourceFilter allow users to "AND" or "OR" between resources
+type ResourceFilter struct {
 	// UserInfo contains information about the user performing the operation.
 	// +optional
 	UserInfo `json:",omitempty" yaml:",omitempty"`
@@ -258,6 +316,8 @@ type UserInfo struct {
 // ResourceDescription contains criteria used to match resources.
 type ResourceDescription struct {
 	// Kinds is a list of resource kinds.
+	// Kinds can be qualified as version/Kind, group/version/Kind or group/*/Kind, and "*" matches all kinds.
+	// Subresources can be matched using the form Kind/subresource, e.g. Pod/exec.
 	// +optional
 	Kinds []string `json:"kinds,omitempty" yaml:"kinds,omitempty"`
 
@@ -369,6 +429,12 @@ type Validation struct {
 	// Deny defines conditions used to pass or fail a validation rule.
 	// +optional
 	Deny *Deny `json:"deny,omitempty" yaml:"deny,omitempty"`
+
+	// ValidationFailureAction overrides the policy ValidationFailureAction for this rule.
+	// Allowed values are "enforce" and "audit". Optional, by default the action of the
+	// policy applies.
+	// +optional
+	ValidationFailureAction string `json:"validationFailureAction,omitempty" yaml:"validationFailureAction,omitempty"`
 }
 
 // Deny specifies a list of conditions used to pass or fail a validation rule.
@@ -466,6 +532,43 @@ type PolicyStatus struct {
 	// Rules provides per rule statistics
 	// +optional
 	Rules []RuleStats `json:"ruleStatus,omitempty" yaml:"ruleStatus,omitempty"`
+
+	// Conditions are the latest observations of the policy readiness: Ready, WebhookConfigured,
+	// PolicyCached and BackgroundScanCompleted. The Ready condition is true once the policy is enforced by the
+	// admission webhooks, e.g. "kubectl wait --for=condition=Ready clusterpolicy/<name>".
+	// The background scan does not count towards Ready.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+
+	// Autogen contains the details of the rules auto-generated for the pod controllers.
+	// +optional
+	Autogen *AutogenStatus `json:"autogen,omitempty" yaml:"autogen,omitempty"`
+}
+
+// Types of the conditions of a policy status.
+const (
+	// PolicyConditionReady indicates the policy is enforced by the admission webhooks.
+	PolicyConditionReady = "Ready"
+
+	// PolicyConditionWebhookConfigured indicates the webhook configurations cover the kinds of the policy rules.
+	PolicyConditionWebhookConfigured = "WebhookConfigured"
+
+	// PolicyConditionPolicyCached indicates the policy cache of the admission webhooks serves the policy.
+	PolicyConditionPolicyCached = "PolicyCached"
+
+	// PolicyConditionBackgroundScanCompleted indicates the policy has been applied to the existing resources.
+	PolicyConditionBackgroundScanCompleted = "BackgroundScanCompleted"
+)
+
+// AutogenStatus contains the details of the rules auto-generated for the pod controllers.
+type AutogenStatus struct {
+	// Controllers are the pod controllers the rules are auto-generated for.
+	// +optional
+	Controllers []string `json:"controllers,omitempty" yaml:"controllers,omitempty"`
+
+	// Rules are the names of the auto-generated rules.
+	// +optional
+	Rules []string `json:"rules,omitempty" yaml:"rules,omitempty"`
 }
 
 // RuleStats provides statistics for an individual rule within a policy.
@@ -78,6 +78,18 @@ func (r Rule) HasGenerate() bool {
 	return !reflect.DeepEqual(r.Generation, Generation{})
 }
 
+// GetValidationFailureAction returns the validation failure action of the rule,
+// defaults to the given policy action if the rule does not override it.
+// The action of the rule takes precedence over the policy's validationFailureActionOverrides, which only
+// change the default of the rules without an action: pass the policy action resolved for the namespace.
+func (r Rule) GetValidationFailureAction(policyAction string) string {
+	if r.Validation.ValidationFailureAction != "" {
+		return r.Validation.ValidationFailureAction
+	}
+
+	return policyAction
+}
+
 // DeserializeAnyPattern deserialize apiextensions.JSON to []interface{}
 func (in *Validation) DeserializeAnyPattern() ([]interface{}, error) {
 	if in.AnyPattern == nil {
@@ -168,6 +180,40 @@ func (in *Rule) DeepCopyInto(out *Rule) {
 	}
 }
 
+// GetKinds returns the unique kinds of the resource description and of all the any/all resource filters
+func (m MatchResources) GetKinds() []string {
+	var kinds []string
+	seen := make(map[string]bool)
+	add := func(rd ResourceDescription) {
+		for _, kind := range rd.Kinds {
+			if !seen[kind] {
+				seen[kind] = true
+				kinds = append(kinds, kind)
+			}
+		}
+	}
+
+	add(m.ResourceDescription)
+	for _, rf := range m.Any {
+		add(rf.ResourceDescription)
+	}
+	for _, rf := range m.All {
+		add(rf.ResourceDescription)
+	}
+
+	return kinds
+}
+
+// HasAnyAll checks if the resource filters are specified using any/all
+func (m MatchResources) HasAnyAll() bool {
+	return len(m.Any) > 0 || len(m.All) > 0
+}
+
+// HasAnyAll checks if the resource filters are specified using any/all
+func (e ExcludeResources) HasAnyAll() bool {
+	return len(e.Any) > 0 || len(e.All) > 0
+}
+
 //ToKey generates the key string used for adding label to polivy violation
 func (rs ResourceSpec) ToKey() string {
 	return rs.Kind + "." + rs.Name
@@ -187,4 +233,8 @@ type ViolatedRule struct {
 
 	// +optional
 	Check string `json:"check" yaml:"check"`
+
+	// Specifies the validation failure action of the rule.
+	// +optional
+	ValidationFailureAction string `json:"validationFailureAction,omitempty" yaml:"validationFailureAction,omitempty"`
 }
@@ -0,0 +1,226 @@
+/*
+Copyright 2020 The Kubernetes authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// the keys of the result data that keep the v1alpha2 fields v1alpha1 cannot represent,
+// so that converting a result to v1alpha1 and back does not lose them
+const (
+	DataKeySource    = "v1alpha2.source"
+	DataKeyType      = "v1alpha2.type"
+	DataKeyTimestamp = "v1alpha2.timestamp"
+	DataKeySeverity  = "v1alpha2.severity"
+)
+
+// ConvertTo converts this PolicyReport to the v1alpha2 hub version
+func (src *PolicyReport) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha2.PolicyReport)
+	if !ok {
+		return fmt.Errorf("unsupported conversion hub %T", dstRaw)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Scope = src.Scope
+	dst.ScopeSelector = src.ScopeSelector
+	dst.Summary = v1alpha2.PolicyReportSummary(src.Summary)
+	dst.Results = ConvertResultsTo(src.Results)
+	return nil
+}
+
+// ConvertFrom converts a v1alpha2 PolicyReport to this version
+func (dst *PolicyReport) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha2.PolicyReport)
+	if !ok {
+		return fmt.Errorf("unsupported conversion hub %T", srcRaw)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Scope = src.Scope
+	dst.ScopeSelector = src.ScopeSelector
+	dst.Summary = PolicyReportSummary(src.Summary)
+	dst.Results = ConvertResultsFrom(src.Results)
+	return nil
+}
+
+// ConvertTo converts this ClusterPolicyReport to the v1alpha2 hub version
+func (src *ClusterPolicyReport) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha2.ClusterPolicyReport)
+	if !ok {
+		return fmt.Errorf("unsupported conversion hub %T", dstRaw)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Scope = src.Scope
+	dst.ScopeSelector = src.ScopeSelector
+	dst.Summary = v1alpha2.PolicyReportSummary(src.Summary)
+	dst.Results = ConvertResultsTo(src.Results)
+	return nil
+}
+
+// ConvertFrom converts a v1alpha2 ClusterPolicyReport to this version
+func (dst *ClusterPolicyReport) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha2.ClusterPolicyReport)
+	if !ok {
+		return fmt.Errorf("unsupported conversion hub %T", srcRaw)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Scope = src.Scope
+	dst.ScopeSelector = src.ScopeSelector
+	dst.Summary = PolicyReportSummary(src.Summary)
+	dst.Results = ConvertResultsFrom(src.Results)
+	return nil
+}
+
+// ConvertResultsTo converts the results to v1alpha2, the v1alpha2 fields kept in the data are restored
+func ConvertResultsTo(results []*PolicyReportResult) []*v1alpha2.PolicyReportResult {
+	if results == nil {
+		return nil
+	}
+
+	converted := make([]*v1alpha2.PolicyReportResult, 0, len(results))
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+
+		severity := v1alpha2.PolicySeverity(result.Severity)
+		var properties map[string]string
+		var source, ruleType string
+		var timestamp metav1.Timestamp
+		for key, value := range result.Data {
+			switch key {
+			case DataKeySource:
+				source = value
+			case DataKeyType:
+				ruleType = value
+			case DataKeyTimestamp:
+				timestamp = parseTimestamp(value)
+			case DataKeySeverity:
+				severity = v1alpha2.PolicySeverity(value)
+			default:
+				if properties == nil {
+					properties = make(map[string]string)
+				}
+				properties[key] = value
+			}
+		}
+
+		converted = append(converted, &v1alpha2.PolicyReportResult{
+			Source:           source,
+			Policy:           result.Policy,
+			Rule:             result.Rule,
+			Type:             v1alpha2.PolicyRuleType(ruleType),
+			Category:         result.Category,
+			Severity:         severity,
+			Timestamp:        timestamp,
+			Result:           v1alpha2.PolicyResult(result.Status),
+			Scored:           result.Scored,
+			Resources:        result.Resources,
+			ResourceSelector: result.ResourceSelector,
+			Message:          result.Message,
+			Properties:       properties,
+		})
+	}
+
+	return converted
+}
+
+// ConvertResultsFrom converts v1alpha2 results to this version, the source, type and timestamp of the results
+// and the severities v1alpha1 does not define are kept in the data
+func ConvertResultsFrom(results []*v1alpha2.PolicyReportResult) []*PolicyReportResult {
+	if results == nil {
+		return nil
+	}
+
+	converted := make([]*PolicyReportResult, 0, len(results))
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+
+		var data map[string]string
+		setData := func(key, value string) {
+			if data == nil {
+				data = make(map[string]string, len(result.Properties)+4)
+			}
+			data[key] = value
+		}
+
+		for key, value := range result.Properties {
+			setData(key, value)
+		}
+
+		severity := PolicySeverity(result.Severity)
+		switch result.Severity {
+		case v1alpha2.SeverityCritical:
+			severity = SeverityHigh
+			setData(DataKeySeverity, string(result.Severity))
+		case v1alpha2.SeverityInfo:
+			severity = SeverityLow
+			setData(DataKeySeverity, string(result.Severity))
+		}
+
+		if result.Source != "" {
+			setData(DataKeySource, result.Source)
+		}
+
+		if result.Type != "" {
+			setData(DataKeyType, string(result.Type))
+		}
+
+		if result.Timestamp != (metav1.Timestamp{}) {
+			setData(DataKeyTimestamp, formatTimestamp(result.Timestamp))
+		}
+
+		converted = append(converted, &PolicyReportResult{
+			Policy:           result.Policy,
+			Rule:             result.Rule,
+			Category:         result.Category,
+			Severity:         severity,
+			Status:           PolicyStatus(result.Result),
+			Scored:           result.Scored,
+			Resources:        result.Resources,
+			ResourceSelector: result.ResourceSelector,
+			Message:          result.Message,
+			Data:             data,
+		})
+	}
+
+	return converted
+}
+
+// formatTimestamp formats the timestamp as <seconds>.<nanos>
+func formatTimestamp(timestamp metav1.Timestamp) string {
+	return fmt.Sprintf("%d.%09d", timestamp.Seconds, timestamp.Nanos)
+}
+
+func parseTimestamp(value string) metav1.Timestamp {
+	parts := strings.SplitN(value, ".", 2)
+	seconds, _ := strconv.ParseInt(parts[0], 10, 64)
+	var nanos int64
+	if len(parts) == 2 {
+		nanos, _ = strconv.ParseInt(parts[1], 10, 32)
+	}
+	return metav1.Timestamp{Seconds: seconds, Nanos: int32(nanos)}
+}
@@ -0,0 +1,129 @@
+package auditlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1beta1 "k8s.io/api/admission/v1beta1"
+)
+
+const bufferSize = 1000
+
+// Logger writes the admission decisions as newline-delimited JSON to a local file
+type Logger struct {
+	file       *rotatingFile
+	sampleRate float64
+	entries    chan *Entry
+	log        logr.Logger
+}
+
+// NewLogger returns a new audit logger, the file is rotated when it exceeds maxSizeMB or maxAge
+// and at most maxBackups rotated files are kept; sampleRate is the fraction of the successful
+// requests that are logged, denied requests and requests with failed rules are always logged
+func NewLogger(path string, maxSizeMB int, maxAge time.Duration, maxBackups int, sampleRate float64, log logr.Logger) *Logger {
+	return &Logger{
+		file:       newRotatingFile(path, int64(maxSizeMB)*1024*1024, maxAge, maxBackups),
+		sampleRate: sampleRate,
+		entries:    make(chan *Entry, bufferSize),
+		log:        log,
+	}
+}
+
+// NewEntry returns a new entry for the request, or nil if the audit log is disabled
+func (l *Logger) NewEntry(webhook string, request *v1beta1.AdmissionRequest) *Entry {
+	if l == nil {
+		return nil
+	}
+
+	return NewEntry(webhook, request)
+}
+
+// Add queues the entry to be written, entries without policies are only written when the request is denied
+func (l *Logger) Add(entry *Entry) {
+	if l == nil || entry == nil {
+		return
+	}
+
+	if len(entry.Policies) == 0 && entry.Allowed {
+		return
+	}
+
+	if entry.successful() && rand.Float64() >= l.sampleRate {
+		return
+	}
+
+	select {
+	case l.entries <- entry:
+	default:
+		l.log.Info("audit log buffer is full, dropping entry", "uid", entry.UID, "webhook", entry.Webhook)
+	}
+}
+
+// Run writes the queued entries until stopCh is closed, the entries queued before are written before the file is closed
+func (l *Logger) Run(stopCh <-chan struct{}) {
+	defer func() {
+		if err := l.file.Close(); err != nil {
+			l.log.Error(err, "failed to close audit log")
+		}
+	}()
+
+	for {
+		select {
+		case entry := <-l.entries:
+			l.write(entry)
+		case <-stopCh:
+			l.drain()
+			return
+		}
+	}
+}
+
+func (l *Logger) drain() {
+	for {
+		select {
+		case entry := <-l.entries:
+			l.write(entry)
+		default:
+			return
+		}
+	}
+}
+
+func (l *Logger) write(entry *Entry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		l.log.Error(err, "failed to marshal audit log entry", "uid", entry.UID)
+		return
+	}
+
+	if _, err := l.file.Write(append(data, '\n')); err != nil {
+		l.log.Error(err, "failed to write audit log entry", "uid", entry.UID)
+	}
+}
+
+// SampleRate is the flag value of the fraction of the successful requests written to the audit log,
+// it must be between 0 and 1
+type SampleRate float64
+
+func (r *SampleRate) String() string {
+	return strconv.FormatFloat(float64(*r), 'g', -1, 64)
+}
+
+// Set parses the sample rate and rejects values outside of [0, 1]
+func (r *SampleRate) Set(value string) error {
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return err
+	}
+
+	if rate < 0 || rate > 1 {
+		return fmt.Errorf("sample rate %v is not between 0 and 1", rate)
+	}
+
+	*r = SampleRate(rate)
+	return nil
+}
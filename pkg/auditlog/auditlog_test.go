@@ -0,0 +1,146 @@
+package auditlog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"gotest.tools/assert"
+	v1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_Entry(t *testing.T) {
+	request := &v1beta1.AdmissionRequest{
+		UID:       "1234",
+		Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Pod"},
+		Namespace: "test",
+		Name:      "nginx",
+		Operation: v1beta1.Create,
+		UserInfo:  authenticationv1.UserInfo{Username: "alice"},
+	}
+
+	entry := NewEntry(WebhookMutate, request)
+	entry.AddEngineResponses(&response.EngineResponse{
+		PolicyResponse: response.PolicyResponse{
+			Policy: response.PolicySpec{Name: "add-labels"},
+			Rules: []response.RuleResponse{
+				{Name: "add-team", Type: "Mutation", Success: true},
+				{Name: "add-owner", Type: "Mutation", Status: response.RuleStatusError},
+			},
+		},
+	})
+	entry.SetResponse(&v1beta1.AdmissionResponse{Allowed: true, Patch: []byte(`[{"op":"add","path":"/metadata/labels/team","value":"a"}]`)})
+
+	assert.Equal(t, entry.User.Username, "alice")
+	assert.Equal(t, len(entry.Policies), 1)
+	assert.Equal(t, entry.Policies[0].Rules[0].Result, "pass")
+	assert.Equal(t, entry.Policies[0].Rules[1].Result, "error")
+	assert.Equal(t, string(entry.Patch), `[{"op":"add","path":"/metadata/labels/team","value":"a"}]`)
+	assert.Assert(t, !entry.successful())
+
+	var disabled *Entry
+	disabled.AddEngineResponses(&response.EngineResponse{})
+	disabled.SetResponse(&v1beta1.AdmissionResponse{})
+}
+
+func Test_Logger_sampling(t *testing.T) {
+	logger := NewLogger("", 0, 0, 0, 0, log.Log)
+
+	allowed := &Entry{Allowed: true, Policies: []Policy{{Name: "pol", Rules: []Rule{{Name: "rule", Result: "pass"}}}}}
+	denied := &Entry{Allowed: false}
+	unmatched := &Entry{Allowed: true}
+
+	logger.Add(allowed)
+	logger.Add(unmatched)
+	logger.Add(denied)
+
+	assert.Equal(t, len(logger.entries), 1)
+	assert.Equal(t, <-logger.entries, denied)
+
+	var disabled *Logger
+	assert.Assert(t, disabled.NewEntry(WebhookValidate, &v1beta1.AdmissionRequest{}) == nil)
+	disabled.Add(denied)
+}
+
+func Test_Logger_Run_drainsEntries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auditlog")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "audit.log")
+	logger := NewLogger(path, 0, 0, 0, 1, log.Log)
+	for i := 0; i < 3; i++ {
+		logger.Add(&Entry{UID: fmt.Sprintf("%d", i), Allowed: false})
+	}
+
+	stopCh := make(chan struct{})
+	close(stopCh)
+	logger.Run(stopCh)
+
+	data, err := ioutil.ReadFile(path)
+	assert.NilError(t, err)
+	assert.Equal(t, strings.Count(string(data), "\n"), 3)
+}
+
+func Test_SampleRate(t *testing.T) {
+	var rate SampleRate
+	assert.NilError(t, rate.Set("0.25"))
+	assert.Equal(t, rate.String(), "0.25")
+	assert.NilError(t, rate.Set("1"))
+	assert.NilError(t, rate.Set("0"))
+	assert.ErrorContains(t, rate.Set("1.5"), "not between 0 and 1")
+	assert.ErrorContains(t, rate.Set("-0.1"), "not between 0 and 1")
+	assert.Assert(t, rate.Set("all") != nil)
+	assert.Equal(t, float64(rate), float64(0))
+}
+
+func Test_rotatingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auditlog")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)
+	f := newRotatingFile(filepath.Join(dir, "audit.log"), 10, time.Hour, 2)
+	f.now = func() time.Time { return now }
+
+	write := func(data string) {
+		now = now.Add(time.Second)
+		_, err := f.Write([]byte(data))
+		assert.NilError(t, err)
+	}
+
+	// rotated by size
+	write("12345")
+	write("12345")
+	write("1")
+	write("1")
+	write("1234567890")
+	// rotated by age
+	now = now.Add(time.Hour)
+	write("1")
+	assert.NilError(t, f.Close())
+
+	backups, err := f.backups()
+	assert.NilError(t, err)
+	assert.Equal(t, len(backups), 2)
+
+	data, err := ioutil.ReadFile(backups[0].path)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "1234567890")
+
+	data, err = ioutil.ReadFile(backups[1].path)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "11")
+
+	data, err = ioutil.ReadFile(filepath.Join(dir, "audit.log"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "1")
+}
@@ -0,0 +1,135 @@
+package auditlog
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	v1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// WebhookMutate is set for the entries of the resource mutating webhook
+	WebhookMutate = "mutate"
+	// WebhookValidate is set for the entries of the resource validating webhook
+	WebhookValidate = "validate"
+	// WebhookAudit is set for the entries of the validate audit policies applied in the background to allowed requests
+	WebhookAudit = "audit"
+	// WebhookGenerate is set for the entries of the generate policies applied in the background to allowed requests
+	WebhookGenerate = "generate"
+)
+
+// Entry records the decision of a webhook on an admission request
+type Entry struct {
+	Timestamp time.Time                 `json:"timestamp"`
+	UID       string                    `json:"uid"`
+	Webhook   string                    `json:"webhook"`
+	Operation string                    `json:"operation"`
+	Kind      metav1.GroupVersionKind   `json:"kind"`
+	Namespace string                    `json:"namespace,omitempty"`
+	Name      string                    `json:"name,omitempty"`
+	User      authenticationv1.UserInfo `json:"user"`
+	Allowed   bool                      `json:"allowed"`
+	Message   string                    `json:"message,omitempty"`
+	Policies  []Policy                  `json:"policies,omitempty"`
+	Patch     json.RawMessage           `json:"patch,omitempty"`
+	LatencyMs float64                   `json:"latencyMs"`
+	startTime time.Time
+}
+
+// Policy records the rules of a policy applied to the request
+type Policy struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Rules     []Rule `json:"rules"`
+}
+
+// Rule records the result of a rule applied to the request
+type Rule struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Result  string `json:"result"`
+	Message string `json:"message,omitempty"`
+}
+
+// NewEntry returns the entry of an admission request received by the webhook
+func NewEntry(webhook string, request *v1beta1.AdmissionRequest) *Entry {
+	now := time.Now()
+	return &Entry{
+		Timestamp: now,
+		UID:       string(request.UID),
+		Webhook:   webhook,
+		Operation: string(request.Operation),
+		Kind:      request.Kind,
+		Namespace: request.Namespace,
+		Name:      request.Name,
+		User:      request.UserInfo,
+		startTime: now,
+	}
+}
+
+// AddEngineResponses records the rules applied by the engine, it is a no-op on a nil entry
+func (e *Entry) AddEngineResponses(engineResponses ...*response.EngineResponse) {
+	if e == nil {
+		return
+	}
+
+	for _, er := range engineResponses {
+		if er == nil || len(er.PolicyResponse.Rules) == 0 {
+			continue
+		}
+
+		policy := Policy{
+			Name:      er.PolicyResponse.Policy.Name,
+			Namespace: er.PolicyResponse.Policy.Namespace,
+		}
+
+		for _, rule := range er.PolicyResponse.Rules {
+			policy.Rules = append(policy.Rules, Rule{
+				Name:    rule.Name,
+				Type:    rule.Type,
+				Result:  string(rule.GetStatus()),
+				Message: rule.Message,
+			})
+		}
+
+		e.Policies = append(e.Policies, policy)
+	}
+}
+
+// SetResponse records the admission response and the latency of the webhook, it is a no-op on a nil entry
+func (e *Entry) SetResponse(admissionResponse *v1beta1.AdmissionResponse) {
+	if e == nil || admissionResponse == nil {
+		return
+	}
+
+	e.Allowed = admissionResponse.Allowed
+	if admissionResponse.Result != nil {
+		e.Message = admissionResponse.Result.Message
+	}
+
+	if len(admissionResponse.Patch) > 0 {
+		e.Patch = json.RawMessage(admissionResponse.Patch)
+	}
+
+	e.LatencyMs = float64(time.Since(e.startTime)) / float64(time.Millisecond)
+}
+
+// successful returns true if the request was allowed and no rule failed
+func (e *Entry) successful() bool {
+	if !e.Allowed {
+		return false
+	}
+
+	for _, policy := range e.Policies {
+		for _, rule := range policy.Rules {
+			if rule.Result == string(response.RuleStatusFail) || rule.Result == string(response.RuleStatusError) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
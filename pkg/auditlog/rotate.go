@@ -0,0 +1,174 @@
+package auditlog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// rotatingFile is a file that is rotated when it exceeds its maximum size or age,
+// the rotated files are kept as <name>-<timestamp><ext> next to the file
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+
+	file     *os.File
+	size     int64
+	openedAt time.Time
+
+	// now is replaced in tests
+	now func() time.Time
+}
+
+func newRotatingFile(path string, maxSize int64, maxAge time.Duration, maxBackups int) *rotatingFile {
+	return &rotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxAge:     maxAge,
+		maxBackups: maxBackups,
+		now:        time.Now,
+	}
+}
+
+// Write appends data to the file, the file is rotated first if data does not fit or the file is too old
+func (f *rotatingFile) Write(data []byte) (int, error) {
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if f.size > 0 && f.exceeded(int64(len(data))) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(data)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the file
+func (f *rotatingFile) Close() error {
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *rotatingFile) exceeded(size int64) bool {
+	if f.maxSize > 0 && f.size+size > f.maxSize {
+		return true
+	}
+
+	return f.maxAge > 0 && f.now().Sub(f.openedAt) >= f.maxAge
+}
+
+func (f *rotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0700); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log %s: %v", f.path, err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = f.now()
+	return nil
+}
+
+func (f *rotatingFile) rotate() error {
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	ext := filepath.Ext(f.path)
+	backup := strings.TrimSuffix(f.path, ext) + "-" + f.now().UTC().Format(backupTimeFormat) + ext
+	if err := os.Rename(f.path, backup); err != nil {
+		return err
+	}
+
+	if err := f.removeBackups(); err != nil {
+		return err
+	}
+
+	return f.open()
+}
+
+// removeBackups removes the oldest rotated files exceeding maxBackups
+func (f *rotatingFile) removeBackups() error {
+	if f.maxBackups <= 0 {
+		return nil
+	}
+
+	backups, err := f.backups()
+	if err != nil {
+		return err
+	}
+
+	for i := f.maxBackups; i < len(backups); i++ {
+		if err := os.Remove(backups[i].path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type backup struct {
+	path      string
+	timestamp time.Time
+}
+
+// backups returns the rotated files, newest first
+func (f *rotatingFile) backups() ([]backup, error) {
+	ext := filepath.Ext(f.path)
+	prefix := filepath.Base(strings.TrimSuffix(f.path, ext)) + "-"
+
+	files, err := os.ReadDir(filepath.Dir(f.path))
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []backup
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+
+		timestamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)
+		rotatedAt, err := time.Parse(backupTimeFormat, timestamp)
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, backup{path: filepath.Join(filepath.Dir(f.path), name), timestamp: rotatedAt})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].timestamp.After(backups[j].timestamp)
+	})
+
+	return backups, nil
+}
@@ -78,6 +78,9 @@ var (
 	//VerifyMutatingWebhookServicePath is the path for verify webhook(used to veryfing if admission control is enabled and active)
 	VerifyMutatingWebhookServicePath = "/verifymutate"
 
+	//ConversionWebhookServicePath is the path for the conversion webhook(used to convert policy report versions)
+	ConversionWebhookServicePath = "/convert"
+
 	// LivenessServicePath is the path for check liveness health
 	LivenessServicePath = "/health/liveness"
 
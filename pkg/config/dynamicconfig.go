@@ -28,6 +28,33 @@ type WebhookConfig struct {
 	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty" protobuf:"bytes,5,opt,name=namespaceSelector"`
 }
 
+// ResultSinkConfig configures an external sink the policy results are published to
+type ResultSinkConfig struct {
+	// Name identifies the sink in logs and metrics
+	Name string `json:"name"`
+	// Type of the sink: file, http or syslog
+	Type string `json:"type"`
+	// Path of the file the results are appended to, for the file sink
+	Path string `json:"path,omitempty"`
+	// URL the results are posted to, for the http sink
+	URL string `json:"url,omitempty"`
+	// Headers added to the requests of the http sink
+	Headers map[string]string `json:"headers,omitempty"`
+	// Network and Address of the syslog server, the local syslog daemon is used if empty
+	Network string `json:"network,omitempty"`
+	Address string `json:"address,omitempty"`
+	// Tag of the syslog messages
+	Tag string `json:"tag,omitempty"`
+	// BufferSize is the number of results buffered before new results are dropped
+	BufferSize int `json:"bufferSize,omitempty"`
+	// BatchSize is the maximum number of results delivered at once
+	BatchSize int `json:"batchSize,omitempty"`
+	// FlushInterval is the maximum time results are buffered before they are delivered, e.g. 5s
+	FlushInterval string `json:"flushInterval,omitempty"`
+	// MaxRetries is the number of times a failed delivery is retried before the results are dropped
+	MaxRetries int `json:"maxRetries,omitempty"`
+}
+
 // ConfigData stores the configuration
 type ConfigData struct {
 	client                      kubernetes.Interface
@@ -39,9 +66,13 @@ type ConfigData struct {
 	restrictDevelopmentUsername []string
 	webhooks                    []WebhookConfig
 	generateSuccessEvents       bool
+	generateFailEvents          bool
+	generateErrorEvents         bool
+	resultSinks                 []ResultSinkConfig
 	cmSycned                    cache.InformerSynced
 	reconcilePolicyReport       chan<- bool
 	updateWebhookConfigurations chan<- bool
+	updateResultSinks           chan<- bool
 	log                         logr.Logger
 }
 
@@ -92,6 +123,20 @@ func (cd *ConfigData) GetGenerateSuccessEvents() bool {
 	return cd.generateSuccessEvents
 }
 
+// GetGenerateFailEvents return if should generate events for failed rules
+func (cd *ConfigData) GetGenerateFailEvents() bool {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.generateFailEvents
+}
+
+// GetGenerateErrorEvents return if should generate events for policies failing to apply
+func (cd *ConfigData) GetGenerateErrorEvents() bool {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.generateErrorEvents
+}
+
 // FilterNamespaces filters exclude namespace
 func (cd *ConfigData) FilterNamespaces(namespaces []string) []string {
 	var results []string
@@ -110,6 +155,13 @@ func (cd *ConfigData) GetWebhooks() []WebhookConfig {
 	return cd.webhooks
 }
 
+// GetResultSinks returns the configured result sinks
+func (cd *ConfigData) GetResultSinks() []ResultSinkConfig {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.resultSinks
+}
+
 func (cd *ConfigData) GetInitConfigMapName() string {
 	return cd.cmName
 }
@@ -120,14 +172,17 @@ type Interface interface {
 	GetExcludeGroupRole() []string
 	GetExcludeUsername() []string
 	GetGenerateSuccessEvents() bool
+	GetGenerateFailEvents() bool
+	GetGenerateErrorEvents() bool
 	RestrictDevelopmentUsername() []string
 	FilterNamespaces(namespaces []string) []string
 	GetWebhooks() []WebhookConfig
+	GetResultSinks() []ResultSinkConfig
 	GetInitConfigMapName() string
 }
 
 // NewConfigData ...
-func NewConfigData(rclient kubernetes.Interface, cmInformer informers.ConfigMapInformer, filterK8sResources, excludeGroupRole, excludeUsername string, reconcilePolicyReport, updateWebhookConfigurations chan<- bool, log logr.Logger) *ConfigData {
+func NewConfigData(rclient kubernetes.Interface, cmInformer informers.ConfigMapInformer, filterK8sResources, excludeGroupRole, excludeUsername string, reconcilePolicyReport, updateWebhookConfigurations, updateResultSinks chan<- bool, log logr.Logger) *ConfigData {
 	// environment var is read at start only
 	if cmNameEnv == "" {
 		log.Info("ConfigMap name not defined in env:INIT_CONFIG: loading no default configuration")
@@ -139,6 +194,9 @@ func NewConfigData(rclient kubernetes.Interface, cmInformer informers.ConfigMapI
 		cmSycned:                    cmInformer.Informer().HasSynced,
 		reconcilePolicyReport:       reconcilePolicyReport,
 		updateWebhookConfigurations: updateWebhookConfigurations,
+		updateResultSinks:           updateResultSinks,
+		generateFailEvents:          true,
+		generateErrorEvents:         true,
 		log:                         log,
 	}
 
@@ -186,7 +244,12 @@ func (cd *ConfigData) addCM(obj interface{}) {
 	if cm.Name != cd.cmName {
 		return
 	}
-	cd.load(*cm)
+
+	_, _, updateResultSinks := cd.load(*cm)
+	if updateResultSinks {
+		cd.log.Info("result sinks configured, updating result sinks")
+		cd.notifyResultSinks()
+	}
 }
 
 func (cd *ConfigData) updateCM(old, cur interface{}) {
@@ -195,7 +258,7 @@ func (cd *ConfigData) updateCM(old, cur interface{}) {
 		return
 	}
 	// if data has not changed then dont load configmap
-	reconcilePolicyReport, updateWebook := cd.load(*cm)
+	reconcilePolicyReport, updateWebook, updateResultSinks := cd.load(*cm)
 	if reconcilePolicyReport {
 		cd.log.Info("resource filters changed, sending reconcile signal to the policy controller")
 		cd.reconcilePolicyReport <- true
@@ -205,6 +268,11 @@ func (cd *ConfigData) updateCM(old, cur interface{}) {
 		cd.log.Info("webhook configurations changed, updating webhook configurations")
 		cd.updateWebhookConfigurations <- true
 	}
+
+	if updateResultSinks {
+		cd.log.Info("result sinks changed, updating result sinks")
+		cd.notifyResultSinks()
+	}
 }
 
 func (cd *ConfigData) deleteCM(obj interface{}) {
@@ -227,10 +295,22 @@ func (cd *ConfigData) deleteCM(obj interface{}) {
 		return
 	}
 	// remove the configuration parameters
-	cd.unload(*cm)
+	if cd.unload(*cm) {
+		cd.log.Info("result sinks removed, updating result sinks")
+		cd.notifyResultSinks()
+	}
+}
+
+// notifyResultSinks signals the result sink manager without blocking the informer,
+// a signal which is not yet consumed covers the later changes
+func (cd *ConfigData) notifyResultSinks() {
+	select {
+	case cd.updateResultSinks <- true:
+	default:
+	}
 }
 
-func (cd *ConfigData) load(cm v1.ConfigMap) (reconcilePolicyReport, updateWebhook bool) {
+func (cd *ConfigData) load(cm v1.ConfigMap) (reconcilePolicyReport, updateWebhook, updateResultSinks bool) {
 	logger := cd.log.WithValues("name", cm.Name, "namespace", cm.Namespace)
 	if cm.Data == nil {
 		logger.V(4).Info("configuration: No data defined in ConfigMap")
@@ -317,9 +397,49 @@ func (cd *ConfigData) load(cm v1.ConfigMap) (reconcilePolicyReport, updateWebhoo
 		}
 	}
 
+	cd.generateFailEvents = cd.loadEventsFlag(logger, cm, "generateFailEvents", cd.generateFailEvents)
+	cd.generateErrorEvents = cd.loadEventsFlag(logger, cm, "generateErrorEvents", cd.generateErrorEvents)
+
+	resultSinks, ok := cm.Data["resultSinks"]
+	if !ok {
+		logger.V(4).Info("configuration: No resultSinks defined in ConfigMap")
+	} else {
+		sinks, err := parseResultSinks(resultSinks)
+		if err != nil {
+			logger.Error(err, "unable to parse result sinks configurations")
+		} else if reflect.DeepEqual(sinks, cd.resultSinks) {
+			logger.V(4).Info("resultSinks did not change")
+		} else {
+			logger.V(2).Info("Updated resultSinks", "oldResultSinks", cd.resultSinks, "newResultSinks", sinks)
+			cd.resultSinks = sinks
+			updateResultSinks = true
+		}
+	}
+
 	return
 }
 
+// loadEventsFlag parses the boolean key of the ConfigMap enabling the events for a result type,
+// the current value is kept if the key is not defined or invalid
+func (cd *ConfigData) loadEventsFlag(logger logr.Logger, cm v1.ConfigMap, key string, current bool) bool {
+	value, ok := cm.Data[key]
+	if !ok {
+		logger.V(4).Info("configuration: No " + key + " defined in ConfigMap")
+		return current
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		logger.V(4).Info("configuration: " + key + " must be either true/false")
+		return current
+	}
+
+	if enabled != current {
+		logger.V(2).Info("Updated "+key, "old", current, "new", enabled)
+	}
+	return enabled
+}
+
 //TODO: this has been added to backward support command line arguments
 // will be removed in future and the configuration will be set only via configmaps
 func (cd *ConfigData) initFilters(filters string) {
@@ -351,7 +471,7 @@ func (cd *ConfigData) initRbac(action, exclude string) {
 
 }
 
-func (cd *ConfigData) unload(cm v1.ConfigMap) {
+func (cd *ConfigData) unload(cm v1.ConfigMap) (updateResultSinks bool) {
 	logger := cd.log
 	logger.Info("ConfigMap deleted, removing configuration filters", "name", cm.Name, "namespace", cm.Namespace)
 	cd.mux.Lock()
@@ -361,6 +481,11 @@ func (cd *ConfigData) unload(cm v1.ConfigMap) {
 	cd.excludeGroupRole = append(cd.excludeGroupRole, defaultExcludeGroupRole...)
 	cd.excludeUsername = []string{}
 	cd.generateSuccessEvents = false
+	cd.generateFailEvents = true
+	cd.generateErrorEvents = true
+	updateResultSinks = len(cd.resultSinks) > 0
+	cd.resultSinks = nil
+	return
 }
 
 type k8Resource struct {
@@ -410,3 +535,12 @@ func parseWebhooks(webhooks string) ([]WebhookConfig, error) {
 
 	return webhookCfgs, nil
 }
+
+func parseResultSinks(resultSinks string) ([]ResultSinkConfig, error) {
+	var sinks []ResultSinkConfig
+	if err := json.Unmarshal([]byte(resultSinks), &sinks); err != nil {
+		return nil, err
+	}
+
+	return sinks, nil
+}
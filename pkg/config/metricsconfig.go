@@ -0,0 +1,53 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// metricsConfigKey is the key of the metrics configuration in the Kyverno ConfigMap
+const metricsConfigKey = "metricsConfig"
+
+// LoadMetricsConfig reads the metrics configuration from the Kyverno ConfigMap, it is only
+// read at startup as the metrics are created once, changing it requires a restart
+func LoadMetricsConfig(client kubernetes.Interface, log logr.Logger) (metrics.MetricsConfig, error) {
+	cmName := os.Getenv(cmNameEnv)
+	if cmName == "" {
+		log.Info("ConfigMap name not defined in env:INIT_CONFIG: using the default metrics configuration")
+		return metrics.MetricsConfig{}, nil
+	}
+
+	cm, err := client.CoreV1().ConfigMaps(KyvernoNamespace).Get(context.TODO(), cmName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("ConfigMap not found: using the default metrics configuration", "name", cmName)
+			return metrics.MetricsConfig{}, nil
+		}
+
+		return metrics.MetricsConfig{}, err
+	}
+
+	data, ok := cm.Data[metricsConfigKey]
+	if !ok {
+		log.V(4).Info("configuration: No metricsConfig defined in ConfigMap")
+		return metrics.MetricsConfig{}, nil
+	}
+
+	return parseMetricsConfig(data)
+}
+
+func parseMetricsConfig(data string) (metrics.MetricsConfig, error) {
+	var metricsConfig metrics.MetricsConfig
+	if err := json.Unmarshal([]byte(data), &metricsConfig); err != nil {
+		return metrics.MetricsConfig{}, err
+	}
+
+	return metricsConfig, nil
+}
@@ -309,7 +309,7 @@ func (c ServerPreferredResources) FindResource(apiVersion string, kind string) (
 func (c ServerPreferredResources) findResource(apiVersion string, kind string) (*meta.APIResource, schema.GroupVersionResource, error) {
 	var serverResources []*meta.APIResourceList
 	var err error
-	if apiVersion == "" {
+	if apiVersion == "" || strings.HasSuffix(apiVersion, "/*") {
 		serverResources, err = c.cachedClient.ServerPreferredResources()
 	} else {
 		_, serverResources, err = c.cachedClient.ServerGroupsAndResources()
@@ -327,7 +327,7 @@ func (c ServerPreferredResources) findResource(apiVersion string, kind string) (
 	}
 
 	for _, serverResource := range serverResources {
-		if apiVersion != "" && serverResource.GroupVersion != apiVersion {
+		if apiVersion != "" && !matchesGroupVersion(apiVersion, serverResource.GroupVersion) {
 			continue
 		}
 
@@ -354,6 +354,16 @@ func (c ServerPreferredResources) findResource(apiVersion string, kind string) (
 	return nil, schema.GroupVersionResource{}, fmt.Errorf("kind '%s' not found in apiVersion '%s'", kind, apiVersion)
 }
 
+// matchesGroupVersion checks if the groupVersion matches the apiVersion,
+// an apiVersion of the form group/* matches all versions of the group
+func matchesGroupVersion(apiVersion, groupVersion string) bool {
+	if group := strings.TrimSuffix(apiVersion, "/*"); group != apiVersion {
+		return strings.HasPrefix(groupVersion, group+"/")
+	}
+
+	return groupVersion == apiVersion
+}
+
 func logDiscoveryErrors(err error, c ServerPreferredResources) {
 	discoveryError := err.(*discovery.ErrGroupDiscoveryFailed)
 	for gv, e := range discoveryError.Groups {
@@ -1,10 +1,12 @@
 package engine
 
 import (
+	"fmt"
 	"time"
 
 	kyverno "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/engine/response"
+	"github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -15,6 +17,10 @@ import (
 // 2. returns the list of rules that are applicable on this policy and resource, if 1 succeed
 func Generate(policyContext *PolicyContext) (resp *response.EngineResponse) {
 	policyStartTime := time.Now()
+
+	_, endSpan := policyContext.startPolicySpan("generate")
+	defer endSpan()
+
 	return filterRules(policyContext, policyStartTime)
 }
 
@@ -47,7 +53,16 @@ func filterRules(policyContext *PolicyContext, startTime time.Time) *response.En
 	}
 
 	for _, rule := range policyContext.Policy.Spec.Rules {
-		if ruleResp := filterRule(rule, policyContext); ruleResp != nil {
+		if !rule.HasGenerate() {
+			continue
+		}
+
+		span, endSpan := policyContext.startRuleSpan(rule, utils.Generation)
+		ruleResp := filterRule(rule, policyContext)
+		setRuleResult(span, ruleResp)
+		endSpan()
+
+		if ruleResp != nil {
 			resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *ruleResp)
 		}
 	}
@@ -74,14 +89,15 @@ func filterRule(rule kyverno.Rule, policyContext *PolicyContext) *response.RuleR
 	logger := log.Log.WithName("Generate").WithValues("policy", policy.Name,
 		"kind", newResource.GetKind(), "namespace", newResource.GetNamespace(), "name", newResource.GetName())
 
-	if err := MatchesResourceDescription(newResource, rule, admissionInfo, excludeGroupRole, namespaceLabels); err != nil {
+	if err := MatchesResourceDescription(newResource, rule, admissionInfo, excludeGroupRole, namespaceLabels, policyContext.Subresource); err != nil {
 
 		// if the oldResource matched, return "false" to delete GR for it
-		if err := MatchesResourceDescription(oldResource, rule, admissionInfo, excludeGroupRole, namespaceLabels); err == nil {
+		if err := MatchesResourceDescription(oldResource, rule, admissionInfo, excludeGroupRole, namespaceLabels, policyContext.Subresource); err == nil {
 			return &response.RuleResponse{
 				Name:    rule.Name,
 				Type:    "Generation",
 				Success: false,
+				Status:  response.RuleStatusSkip,
 				RuleStats: response.RuleStats{
 					ProcessingTime:         time.Since(startTime),
 					RuleExecutionTimestamp: startTime.Unix(),
@@ -97,20 +113,20 @@ func filterRule(rule kyverno.Rule, policyContext *PolicyContext) *response.RuleR
 
 	if err := LoadContext(logger, rule.Context, resCache, policyContext, rule.Name); err != nil {
 		logger.V(4).Info("cannot add external data to the context", "reason", err.Error())
-		return nil
+		return generateRuleResponse(rule.Name, fmt.Sprintf("failed to load context: %v", err), response.RuleStatusError, startTime)
 	}
 
 	// operate on the copy of the conditions, as we perform variable substitution
 	copyConditions, err := copyConditions(rule.AnyAllConditions)
 	if err != nil {
 		logger.V(4).Info("cannot copy AnyAllConditions", "reason", err.Error())
-		return nil
+		return generateRuleResponse(rule.Name, fmt.Sprintf("failed to copy preconditions: %v", err), response.RuleStatusError, startTime)
 	}
 
 	// evaluate pre-conditions
 	if !variables.EvaluateConditions(logger, ctx, copyConditions, true) {
 		logger.V(4).Info("preconditions not satisfied, skipping rule", "rule", rule.Name)
-		return nil
+		return generateRuleResponse(rule.Name, "preconditions not met", response.RuleStatusSkip, startTime)
 	}
 
 	// build rule Response
@@ -124,3 +140,13 @@ func filterRule(rule kyverno.Rule, policyContext *PolicyContext) *response.RuleR
 		},
 	}
 }
+
+// generateRuleResponse builds the response of a generate rule which is not applied to the resource
+func generateRuleResponse(name, message string, status response.RuleStatus, startTime time.Time) *response.RuleResponse {
+	resp := ruleResponse(name, utils.Generation, message, status)
+	resp.RuleStats = response.RuleStats{
+		ProcessingTime:         time.Since(startTime),
+		RuleExecutionTimestamp: startTime.Unix(),
+	}
+	return resp
+}
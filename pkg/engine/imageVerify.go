@@ -9,6 +9,9 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/engine/utils"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/kyverno/kyverno/pkg/metrics/imageverificationlatency"
+	"github.com/kyverno/kyverno/pkg/tracing"
 	"github.com/minio/minio/pkg/wildcard"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"time"
@@ -32,6 +35,9 @@ func VerifyAndPatchImages(policyContext *PolicyContext) (resp *response.EngineRe
 		return
 	}
 
+	_, endSpan := policyContext.startPolicySpan("verify-images")
+	defer endSpan()
+
 	startTime := time.Now()
 	defer func() {
 		buildResponse(logger, policyContext, resp, startTime)
@@ -52,16 +58,18 @@ func VerifyAndPatchImages(policyContext *PolicyContext) (resp *response.EngineRe
 		}
 
 		policyContext.JSONContext.Restore()
+		_, endRuleSpan := policyContext.startRuleSpan(rule, utils.Validation)
 		for _, imageVerify := range rule.VerifyImages {
-			verifyAndPatchImages(logger, &rule, imageVerify, images.Containers, resp)
-			verifyAndPatchImages(logger, &rule, imageVerify, images.InitContainers, resp)
+			verifyAndPatchImages(logger, policyContext, &rule, imageVerify, images.Containers, resp)
+			verifyAndPatchImages(logger, policyContext, &rule, imageVerify, images.InitContainers, resp)
 		}
+		endRuleSpan()
 	}
 
 	return
 }
 
-func verifyAndPatchImages(logger logr.Logger, rule *v1.Rule, imageVerify *v1.ImageVerification, images map[string]*context.ImageInfo, resp *response.EngineResponse) {
+func verifyAndPatchImages(logger logr.Logger, policyContext *PolicyContext, rule *v1.Rule, imageVerify *v1.ImageVerification, images map[string]*context.ImageInfo, resp *response.EngineResponse) {
 	imagePattern := imageVerify.Image
 	key := imageVerify.Key
 
@@ -81,7 +89,11 @@ func verifyAndPatchImages(logger logr.Logger, rule *v1.Rule, imageVerify *v1.Ima
 		}
 
 		start := time.Now()
+		span, endSpan := policyContext.startSpan("verify-image", tracing.ImageKey.String(image))
 		digest, err := cosign.Verify(image, []byte(key), logger)
+		tracing.SetError(span, err)
+		endSpan()
+		registerImageVerification(logger, policyContext.PromConfig, err, time.Since(start))
 		if err != nil {
 			logger.Info("failed to verify image", "image", image, "key", key, "error", err, "duration", time.Since(start).Seconds())
 			ruleResp.Success = false
@@ -107,6 +119,21 @@ func verifyAndPatchImages(logger logr.Logger, rule *v1.Rule, imageVerify *v1.Ima
 	}
 }
 
+func registerImageVerification(logger logr.Logger, promConfig *metrics.PromConfig, err error, duration time.Duration) {
+	if promConfig == nil {
+		return
+	}
+
+	result := imageverificationlatency.Verified
+	if err != nil {
+		result = imageverificationlatency.Failed
+	}
+
+	if err := imageverificationlatency.ParsePromMetrics(*promConfig.Metrics).RegisterVerification(result, duration); err != nil {
+		logger.Error(err, "error occurred while registering kyverno_image_verification_duration_seconds metrics")
+	}
+}
+
 func makeAddDigestPatch(imageInfo *context.ImageInfo, digest string) ([]byte, error) {
 	var patch = make(map[string]interface{})
 	patch["op"] = "replace"
@@ -301,3 +301,13 @@ func Test_labelMatch(t *testing.T) {
 	}
 
 }
+
+func TestJMESPathFunctions_Signature(t *testing.T) {
+	signatures := make(map[string]string)
+	for _, function := range GetFunctions() {
+		signatures[function.Name] = FunctionSignature(function)
+	}
+
+	assert.Equal(t, signatures[compare], "compare(string, string)")
+	assert.Equal(t, signatures[regexMatch], "regex_match(string, string|number)")
+}
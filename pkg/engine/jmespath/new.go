@@ -1,6 +1,9 @@
 package jmespath
 
 import (
+	"fmt"
+	"strings"
+
 	gojmespath "github.com/jmespath/go-jmespath"
 )
 
@@ -16,3 +19,24 @@ func New(query string) (*gojmespath.JMESPath, error) {
 
 	return jp, nil
 }
+
+// FunctionEntry is a custom function registered with the JMESPath queries
+type FunctionEntry = gojmespath.FunctionEntry
+
+// GetFunctions returns the custom functions registered with the JMESPath queries
+func GetFunctions() []*FunctionEntry {
+	return getFunctions()
+}
+
+// FunctionSignature returns the signature of the function, e.g. compare(string, string)
+func FunctionSignature(function *FunctionEntry) string {
+	args := make([]string, 0, len(function.Arguments))
+	for _, arg := range function.Arguments {
+		types := make([]string, 0, len(arg.Types))
+		for _, t := range arg.Types {
+			types = append(types, string(t))
+		}
+		args = append(args, strings.Join(types, "|"))
+	}
+	return fmt.Sprintf("%s(%s)", function.Name, strings.Join(args, ", "))
+}
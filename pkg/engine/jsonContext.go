@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	kyverno "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
@@ -14,8 +15,11 @@ import (
 	jmespath "github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/kyverno/store"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/kyverno/kyverno/pkg/metrics/contextloadlatency"
 	"github.com/kyverno/kyverno/pkg/resourcecache"
-	"k8s.io/client-go/dynamic/dynamiclister"
+	"github.com/kyverno/kyverno/pkg/tracing"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // LoadContext - Fetches and adds external data to the Context.
@@ -27,14 +31,28 @@ func LoadContext(logger logr.Logger, contextEntries []kyverno.ContextEntry, resC
 	policyName := ctx.Policy.Name
 	if store.GetMock() {
 		rule := store.GetPolicyRuleFromContext(policyName, ruleName)
-		if len(rule.Values) == 0 {
+		if rule != nil && len(rule.Values) > 0 {
+			for key, value := range rule.Values {
+				jsonData := pkgcommon.VariableToJSON(key, value)
+				if err := ctx.JSONContext.AddJSON(jsonData); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+
+		// without mocked values, the context entries are loaded from the cluster fixtures served by the client
+		if ctx.Client == nil {
 			return fmt.Errorf("No values found for policy %s rule %s", policyName, ruleName)
 		}
-		variables := rule.Values
 
-		for key, value := range variables {
-			jsonData := pkgcommon.VariableToJSON(key, value)
-			if err := ctx.JSONContext.AddJSON(jsonData); err != nil {
+		getConfigMap := func(namespace, name string) (*unstructured.Unstructured, error) {
+			return ctx.Client.GetResource("v1", "ConfigMap", namespace, name)
+		}
+
+		for _, entry := range contextEntries {
+			if err := loadContextEntry(logger, entry, getConfigMap, ctx); err != nil {
 				return err
 			}
 		}
@@ -48,22 +66,51 @@ func LoadContext(logger logr.Logger, contextEntries []kyverno.ContextEntry, resC
 		}
 
 		lister := gvrC.Lister()
+		getConfigMap := func(namespace, name string) (*unstructured.Unstructured, error) {
+			return lister.Get(fmt.Sprintf("%s/%s", namespace, name))
+		}
 
 		for _, entry := range contextEntries {
-			if entry.ConfigMap != nil {
-				if err := loadConfigMap(logger, entry, lister, ctx.JSONContext); err != nil {
-					return err
-				}
-			} else if entry.APICall != nil {
-				if err := loadAPIData(logger, entry, ctx); err != nil {
-					return err
-				}
+			if err := loadContextEntry(logger, entry, getConfigMap, ctx); err != nil {
+				return err
 			}
 		}
 	}
 	return nil
 }
 
+// configMapGetter returns the ConfigMap with the given namespace and name
+type configMapGetter func(namespace, name string) (*unstructured.Unstructured, error)
+
+func loadContextEntry(logger logr.Logger, entry kyverno.ContextEntry, getConfigMap configMapGetter, ctx *PolicyContext) (err error) {
+	span, endSpan := ctx.startSpan("load-context", tracing.ContextEntryKey.String(entry.Name))
+	defer func() {
+		tracing.SetError(span, err)
+		endSpan()
+	}()
+
+	startTime := time.Now()
+	if entry.ConfigMap != nil {
+		err = loadConfigMap(logger, entry, getConfigMap, ctx.JSONContext)
+		registerContextEntryLoad(logger, ctx.PromConfig, contextloadlatency.ConfigMap, err, time.Since(startTime))
+	} else if entry.APICall != nil {
+		err = loadAPIData(logger, entry, ctx)
+		registerContextEntryLoad(logger, ctx.PromConfig, contextloadlatency.APICall, err, time.Since(startTime))
+	}
+
+	return err
+}
+
+func registerContextEntryLoad(logger logr.Logger, promConfig *metrics.PromConfig, entryType contextloadlatency.EntryType, err error, duration time.Duration) {
+	if promConfig == nil {
+		return
+	}
+
+	if err := contextloadlatency.ParsePromMetrics(*promConfig.Metrics).RegisterLoad(entryType, metrics.ParseOutcome(err), duration); err != nil {
+		logger.Error(err, "error occurred while registering kyverno_context_entry_load_duration_seconds metrics")
+	}
+}
+
 func loadAPIData(logger logr.Logger, entry kyverno.ContextEntry, ctx *PolicyContext) error {
 	jsonData, err := fetchAPIData(logger, entry, ctx)
 	if err != nil {
@@ -154,6 +201,10 @@ func fetchAPIData(log logr.Logger, entry kyverno.ContextEntry, ctx *PolicyContex
 }
 
 func loadResourceList(ctx *PolicyContext, p *APIPath) ([]byte, error) {
+	if ctx.Client == nil {
+		return nil, fmt.Errorf("API client is not available")
+	}
+
 	l, err := ctx.Client.ListResource(p.Version, p.ResourceType, p.Namespace, nil)
 	if err != nil {
 		return nil, err
@@ -175,8 +226,8 @@ func loadResource(ctx *PolicyContext, p *APIPath) ([]byte, error) {
 	return r.MarshalJSON()
 }
 
-func loadConfigMap(logger logr.Logger, entry kyverno.ContextEntry, lister dynamiclister.Lister, ctx *context.Context) error {
-	data, err := fetchConfigMap(logger, entry, lister, ctx)
+func loadConfigMap(logger logr.Logger, entry kyverno.ContextEntry, getConfigMap configMapGetter, ctx *context.Context) error {
+	data, err := fetchConfigMap(logger, entry, getConfigMap, ctx)
 	if err != nil {
 		return fmt.Errorf("failed to retrieve config map for context entry %s: %v", entry.Name, err)
 	}
@@ -189,7 +240,7 @@ func loadConfigMap(logger logr.Logger, entry kyverno.ContextEntry, lister dynami
 	return nil
 }
 
-func fetchConfigMap(logger logr.Logger, entry kyverno.ContextEntry, lister dynamiclister.Lister, jsonContext *context.Context) ([]byte, error) {
+func fetchConfigMap(logger logr.Logger, entry kyverno.ContextEntry, getConfigMap configMapGetter, jsonContext *context.Context) ([]byte, error) {
 	contextData := make(map[string]interface{})
 
 	name, err := variables.SubstituteAll(logger, jsonContext, entry.ConfigMap.Name)
@@ -206,10 +257,9 @@ func fetchConfigMap(logger logr.Logger, entry kyverno.ContextEntry, lister dynam
 		namespace = "default"
 	}
 
-	key := fmt.Sprintf("%s/%s", namespace, name)
-	obj, err := lister.Get(key)
+	obj, err := getConfigMap(fmt.Sprint(namespace), fmt.Sprint(name))
 	if err != nil {
-		return nil, fmt.Errorf("failed to read configmap %s/%s from cache: %v", namespace, name, err)
+		return nil, fmt.Errorf("failed to read configmap %s/%s: %v", namespace, name, err)
 	}
 
 	unstructuredObj := obj.DeepCopy().Object
@@ -3,8 +3,17 @@ package engine
 import (
 	"bytes"
 	"encoding/json"
-	"gotest.tools/assert"
 	"testing"
+
+	kyverno "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	dclient "github.com/kyverno/kyverno/pkg/dclient"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/kyverno/store"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 func Test_parseMultilineBlockBody(t *testing.T) {
@@ -69,3 +78,56 @@ func Test_parseMultilineBlockBody(t *testing.T) {
 		}
 	}
 }
+
+func Test_LoadContext_mock(t *testing.T) {
+	store.SetMock(true)
+	defer func() {
+		store.SetMock(false)
+		store.SetContext(store.Context{})
+	}()
+
+	contextEntries := []kyverno.ContextEntry{{
+		Name:      "quota",
+		ConfigMap: &kyverno.ConfigMapReference{Name: "pod-quota", Namespace: "default"},
+	}}
+
+	newPolicyContext := func(client *dclient.Client) *PolicyContext {
+		policy := kyverno.ClusterPolicy{}
+		policy.SetName("limit-pods")
+		return &PolicyContext{Policy: policy, JSONContext: context.NewContext(), Client: client}
+	}
+
+	// the values of the rule take precedence over the context entries
+	store.SetContext(store.Context{Policies: []store.Policy{{
+		Name:  "limit-pods",
+		Rules: []store.Rule{{Name: "mocked", Values: map[string]string{"quota.data.maxPods": "5"}}},
+	}}})
+
+	ctx := newPolicyContext(nil)
+	assert.NilError(t, LoadContext(log.Log, contextEntries, nil, ctx, "mocked"))
+	maxPods, err := ctx.JSONContext.Query("quota.data.maxPods")
+	assert.NilError(t, err)
+	assert.Equal(t, maxPods, "5")
+
+	// without values and cluster resources the context cannot be loaded
+	err = LoadContext(log.Log, contextEntries, nil, newPolicyContext(nil), "fixtures")
+	assert.Error(t, err, "No values found for policy limit-pods rule fixtures")
+
+	// without values the context entries are loaded from the cluster resources served by the client
+	configMap := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "pod-quota", "namespace": "default"},
+		"data":       map[string]interface{}{"maxPods": "2"},
+	}}
+
+	client, err := dclient.NewMockClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{{Version: "v1", Resource: "configmaps"}: "ConfigMapList"}, configMap)
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+
+	ctx = newPolicyContext(client)
+	assert.NilError(t, LoadContext(log.Log, contextEntries, nil, ctx, "fixtures"))
+	maxPods, err = ctx.JSONContext.Query("quota.data.maxPods")
+	assert.NilError(t, err)
+	assert.Equal(t, maxPods, "2")
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/tracing"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -29,14 +30,15 @@ func Mutate(policyContext *PolicyContext) (resp *response.EngineResponse) {
 	startTime := time.Now()
 	policy := policyContext.Policy
 	patchedResource := policyContext.NewResource
-	ctx := policyContext.JSONContext
 
-	resCache := policyContext.ResourceCache
 	logger := log.Log.WithName("EngineMutate").WithValues("policy", policy.Name, "kind", patchedResource.GetKind(),
 		"namespace", patchedResource.GetNamespace(), "name", patchedResource.GetName())
 
 	logger.V(4).Info("start policy processing", "startTime", startTime)
 
+	_, endSpan := policyContext.startPolicySpan("mutate")
+	defer endSpan()
+
 	startMutateResultResponse(resp, policy, patchedResource)
 	defer endMutateResultResponse(logger, resp, startTime)
 
@@ -54,7 +56,6 @@ func Mutate(policyContext *PolicyContext) (resp *response.EngineResponse) {
 			continue
 		}
 
-		var ruleResponse response.RuleResponse
 		logger := logger.WithValues("rule", rule.Name)
 
 		excludeResource := []string{}
@@ -62,7 +63,7 @@ func Mutate(policyContext *PolicyContext) (resp *response.EngineResponse) {
 			excludeResource = policyContext.ExcludeGroupRole
 		}
 
-		if err := MatchesResourceDescription(patchedResource, rule, policyContext.AdmissionInfo, excludeResource, policyContext.NamespaceLabels); err != nil {
+		if err := MatchesResourceDescription(patchedResource, rule, policyContext.AdmissionInfo, excludeResource, policyContext.NamespaceLabels, policyContext.Subresource); err != nil {
 			logger.V(4).Info("rule not matched", "reason", err.Error())
 			continue
 		}
@@ -70,57 +71,81 @@ func Mutate(policyContext *PolicyContext) (resp *response.EngineResponse) {
 		logger.V(3).Info("matched mutate rule")
 
 		policyContext.JSONContext.Restore()
-		if err := LoadContext(logger, rule.Context, resCache, policyContext, rule.Name); err != nil {
-			logger.Error(err, "failed to load context")
-			continue
+		var ruleResponse *response.RuleResponse
+		ruleResponse, patchedResource = mutateRule(logger, policyContext, rule, patchedResource)
+		if ruleResponse != nil {
+			resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *ruleResponse)
+			if ruleResponse.Status != response.RuleStatusSkip {
+				incrementAppliedRuleCount(resp)
+			}
 		}
+	}
 
-		// operate on the copy of the conditions, as we perform variable substitution
-		copyConditions, err := copyConditions(rule.AnyAllConditions)
-		if err != nil {
-			logger.V(2).Info("failed to load context", "reason", err.Error())
-			continue
-		}
-		// evaluate pre-conditions
-		// - handle variable substitutions
-		if !variables.EvaluateConditions(logger, ctx, copyConditions, true) {
-			logger.V(3).Info("resource fails the preconditions")
-			continue
-		}
+	resp.PatchedResource = patchedResource
+	return resp
+}
 
-		if rule, err = variables.SubstituteAllInRule(logger, policyContext.JSONContext, rule); err != nil {
-			ruleResp := response.RuleResponse{
-				Name:    rule.Name,
-				Type:    utils.Validation.String(),
-				Message: fmt.Sprintf("variable substitution failed for rule %s: %s", rule.Name, err.Error()),
-				Success: true,
-			}
+// mutateRule applies the mutate rule matching the resource and returns the patched resource,
+// the returned rule response is nil if the rule does not change the resource
+func mutateRule(logger logr.Logger, policyContext *PolicyContext, rule kyverno.Rule, resource unstructured.Unstructured) (ruleResp *response.RuleResponse, patchedResource unstructured.Unstructured) {
+	span, endSpan := policyContext.startRuleSpan(rule, utils.Mutation)
+	defer func() {
+		setRuleResult(span, ruleResp)
+		endSpan()
+	}()
 
-			incrementAppliedCount(resp)
-			resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, ruleResp)
+	ctx := policyContext.JSONContext
+	if err := LoadContext(logger, rule.Context, policyContext.ResourceCache, policyContext, rule.Name); err != nil {
+		logger.Error(err, "failed to load context")
+		tracing.SetError(span, err)
+		return ruleResponse(rule.Name, utils.Mutation, fmt.Sprintf("failed to load context: %v", err), response.RuleStatusError), resource
+	}
 
-			logger.Error(err, "failed to substitute variables, skip current rule", "rule name", rule.Name)
-			continue
-		}
+	// operate on the copy of the conditions, as we perform variable substitution
+	copyConditions, err := copyConditions(rule.AnyAllConditions)
+	if err != nil {
+		logger.V(2).Info("failed to copy preconditions", "reason", err.Error())
+		return ruleResponse(rule.Name, utils.Mutation, fmt.Sprintf("failed to copy preconditions: %v", err), response.RuleStatusError), resource
+	}
+	// evaluate pre-conditions
+	// - handle variable substitutions
+	if !variables.EvaluateConditions(logger, ctx, copyConditions, true) {
+		logger.V(3).Info("resource fails the preconditions")
+		return ruleResponse(rule.Name, utils.Mutation, "preconditions not met", response.RuleStatusSkip), resource
+	}
 
-		mutation := rule.Mutation.DeepCopy()
-		mutateHandler := mutate.CreateMutateHandler(rule.Name, mutation, patchedResource, ctx, logger)
-		ruleResponse, patchedResource = mutateHandler.Handle()
-		if ruleResponse.Success {
-			// - overlay pattern does not match the resource conditions
-			if ruleResponse.Patches == nil {
-				continue
-			}
+	if rule, err = variables.SubstituteAllInRule(logger, ctx, rule); err != nil {
+		logger.Error(err, "failed to substitute variables", "rule name", rule.Name)
+		return ruleResponse(rule.Name, utils.Mutation, fmt.Sprintf("variable substitution failed for rule %s: %s", rule.Name, err.Error()), response.RuleStatusError), resource
+	}
 
-			logger.V(4).Info("mutate rule applied successfully", "ruleName", rule.Name)
+	mutation := rule.Mutation.DeepCopy()
+	mutateHandler := mutate.CreateMutateHandler(rule.Name, mutation, resource, ctx, logger)
+	ruleResponse, patchedResource := mutateHandler.Handle()
+	if ruleResponse.Success {
+		// - overlay pattern does not match the resource conditions
+		if ruleResponse.Patches == nil {
+			return nil, patchedResource
 		}
 
-		resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, ruleResponse)
-		incrementAppliedRuleCount(resp)
+		logger.V(4).Info("mutate rule applied successfully", "ruleName", rule.Name)
+	} else {
+		ruleResponse.Status = response.RuleStatusError
 	}
 
-	resp.PatchedResource = patchedResource
-	return resp
+	return &ruleResponse, patchedResource
+}
+
+// ruleResponse builds the response of a rule which is not applied to the resource,
+// skipped rules are successful as the resource is not affected by them
+func ruleResponse(name string, ruleType utils.RuleType, message string, status response.RuleStatus) *response.RuleResponse {
+	return &response.RuleResponse{
+		Name:    name,
+		Type:    ruleType.String(),
+		Message: message,
+		Success: status == response.RuleStatusSkip,
+		Status:  status,
+	}
 }
 
 func incrementAppliedRuleCount(resp *response.EngineResponse) {
@@ -7,6 +7,7 @@ import (
 
 	kyverno "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/kyverno/store"
 	"gotest.tools/assert"
@@ -163,6 +164,86 @@ func Test_variableSubstitutionPathNotExist(t *testing.T) {
 	er := Mutate(policyContext)
 	expectedErrorStr := "variable substitution failed for rule test-path-not-exist: Unknown key \"name1\" in path"
 	assert.Equal(t, er.PolicyResponse.Rules[0].Message, expectedErrorStr)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, response.RuleStatusError)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Success, false)
+}
+
+func Test_mutatePreconditionsNotMet(t *testing.T) {
+	resourceRaw := []byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+			"name": "check-root-user"
+		},
+		"spec": {
+			"containers": [
+				{
+					"name": "check-root-user",
+					"image": "nginxinc/nginx-unprivileged"
+				}
+			]
+		}
+	}`)
+
+	policyraw := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+		  "name": "add-label"
+		},
+		"spec": {
+		  "rules": [
+			{
+			  "name": "add-label",
+			  "match": {
+				"resources": {
+				  "kinds": [
+					"Pod"
+				  ]
+				}
+			  },
+			  "preconditions": [
+				{
+				  "key": "{{request.object.metadata.name}}",
+				  "operator": "Equals",
+				  "value": "other"
+				}
+			  ],
+			  "mutate": {
+				"patchStrategicMerge": {
+				  "metadata": {
+					"labels": {
+					  "app": "nginx"
+					}
+				  }
+				}
+			  }
+			}
+		  ]
+		}
+	  }`)
+
+	var policy kyverno.ClusterPolicy
+	err := json.Unmarshal(policyraw, &policy)
+	assert.NilError(t, err)
+	resourceUnstructured, err := utils.ConvertToUnstructured(resourceRaw)
+	assert.NilError(t, err)
+
+	ctx := context.NewContext()
+	err = ctx.AddResource(resourceRaw)
+	assert.NilError(t, err)
+
+	policyContext := &PolicyContext{
+		Policy:      policy,
+		JSONContext: ctx,
+		NewResource: *resourceUnstructured}
+	er := Mutate(policyContext)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, response.RuleStatusSkip)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Success, true)
+	assert.Equal(t, er.PolicyResponse.RulesAppliedCount, 0)
+	assert.Equal(t, len(er.GetPatches()), 0)
+	assert.Equal(t, len(er.GetSuccessRules()), 0)
 }
 
 func Test_variableSubstitutionCLI(t *testing.T) {
@@ -1,11 +1,15 @@
 package engine
 
 import (
+	contextdefault "context"
+
 	kyverno "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
 	client "github.com/kyverno/kyverno/pkg/dclient"
 	"github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/resourcecache"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // PolicyContext contains the contexts for engine to process
@@ -39,4 +43,22 @@ type PolicyContext struct {
 
 	// NamespaceLabels stores the label of namespace to be processed by namespace selector
 	NamespaceLabels map[string]string
+
+	// Subresource is set when the admission request targets a subresource, e.g. pods/exec
+	Subresource Subresource
+
+	// PromConfig records the latencies of the context loads and image verifications, it is nil if metrics are disabled
+	PromConfig *metrics.PromConfig
+
+	// TraceContext carries the span of the caller, the spans of the policy evaluation are its children
+	TraceContext contextdefault.Context
+}
+
+// Subresource identifies the subresource of an admission request
+type Subresource struct {
+	// Name is the name of the subresource, e.g. exec or scale
+	Name string
+
+	// ParentKind is the group/version/kind of the resource that owns the subresource
+	ParentKind schema.GroupVersionKind
 }
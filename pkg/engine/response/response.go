@@ -74,10 +74,41 @@ type RuleResponse struct {
 	Patches [][]byte `json:"patches,omitempty"`
 	// success/fail
 	Success bool `json:"success"`
+	// Status of the rule application: pass, fail, error or skip; derived from Success when empty
+	Status RuleStatus `json:"status,omitempty"`
+	// ValidationFailureAction of the rule: audit (default) or enforce
+	ValidationFailureAction string `json:"validationFailureAction,omitempty"`
 	// statistics
 	RuleStats `json:",inline"`
 }
 
+//RuleStatus represents the status of a rule application
+type RuleStatus string
+
+const (
+	//RuleStatusPass the rule was applied successfully
+	RuleStatusPass RuleStatus = "pass"
+	//RuleStatusFail the resource does not satisfy the rule
+	RuleStatusFail RuleStatus = "fail"
+	//RuleStatusError the rule could not be applied
+	RuleStatusError RuleStatus = "error"
+	//RuleStatusSkip the rule was not applied
+	RuleStatusSkip RuleStatus = "skip"
+)
+
+//GetStatus returns the status of the rule application, derived from Success when Status is not set
+func (rr RuleResponse) GetStatus() RuleStatus {
+	if rr.Status != "" {
+		return rr.Status
+	}
+
+	if rr.Success {
+		return RuleStatusPass
+	}
+
+	return RuleStatusFail
+}
+
 //ToString ...
 func (rr RuleResponse) ToString() string {
 	return fmt.Sprintf("rule %s (%s): %v", rr.Name, rr.Type, rr.Message)
@@ -101,10 +132,10 @@ func (er EngineResponse) IsSuccessful() bool {
 	return true
 }
 
-//IsFailed checks if any rule has succeeded or not
+//IsFailed checks if any rule has succeeded or not, skipped rules are ignored
 func (er EngineResponse) IsFailed() bool {
 	for _, r := range er.PolicyResponse.Rules {
-		if r.Success {
+		if r.Success && r.Status != RuleStatusSkip {
 			return false
 		}
 	}
@@ -128,7 +159,7 @@ func (er EngineResponse) GetFailedRules() []string {
 	return er.getRules(false)
 }
 
-//GetSuccessRules returns success rules
+//GetSuccessRules returns success rules, skipped rules are not included
 func (er EngineResponse) GetSuccessRules() []string {
 	return er.getRules(true)
 }
@@ -147,7 +178,7 @@ func (er EngineResponse) GetResourceSpec() ResourceSpec {
 func (er EngineResponse) getRules(success bool) []string {
 	var rules []string
 	for _, r := range er.PolicyResponse.Rules {
-		if r.Success == success {
+		if r.Success == success && r.Status != RuleStatusSkip {
 			rules = append(rules, r.Name)
 		}
 	}
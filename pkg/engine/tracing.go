@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"reflect"
+
+	kyverno "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"github.com/kyverno/kyverno/pkg/engine/utils"
+	"github.com/kyverno/kyverno/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// startSpan starts a span as a child of the trace context of the policy context, the span
+// is the parent of the spans started with the policy context until end is called
+func (pc *PolicyContext) startSpan(name string, attrs ...attribute.KeyValue) (span trace.Span, end func()) {
+	parent := pc.TraceContext
+	spanCtx, span := tracing.StartSpan(parent, name, attrs...)
+	pc.TraceContext = spanCtx
+
+	return span, func() {
+		span.End()
+		pc.TraceContext = parent
+	}
+}
+
+// startPolicySpan starts the span of the policy evaluation on the resource
+func (pc *PolicyContext) startPolicySpan(name string) (trace.Span, func()) {
+	resource := pc.NewResource
+	if reflect.DeepEqual(resource, unstructured.Unstructured{}) {
+		resource = pc.OldResource
+	}
+
+	return pc.startSpan(name,
+		tracing.PolicyNameKey.String(pc.Policy.GetName()),
+		tracing.PolicyNamespaceKey.String(pc.Policy.GetNamespace()),
+		tracing.ResourceKindKey.String(resource.GetKind()),
+		tracing.ResourceNamespaceKey.String(resource.GetNamespace()),
+		tracing.ResourceNameKey.String(resource.GetName()),
+	)
+}
+
+// startRuleSpan starts the span of the rule evaluation on the resource
+func (pc *PolicyContext) startRuleSpan(rule kyverno.Rule, ruleType utils.RuleType) (trace.Span, func()) {
+	return pc.startSpan("rule",
+		tracing.RuleNameKey.String(rule.Name),
+		tracing.RuleTypeKey.String(ruleType.String()),
+	)
+}
+
+// setRuleResult records the result of the rule on its span, a nil response means the rule was skipped
+func setRuleResult(span trace.Span, ruleResp *response.RuleResponse) {
+	if ruleResp == nil {
+		span.SetAttributes(tracing.RuleResultKey.String(string(response.RuleStatusSkip)))
+		return
+	}
+
+	span.SetAttributes(tracing.RuleResultKey.String(string(ruleResp.GetStatus())))
+}
@@ -0,0 +1,83 @@
+package engine
+
+import (
+	contextdefault "context"
+	"encoding/json"
+	"testing"
+
+	kyverno "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/utils"
+	"github.com/kyverno/kyverno/pkg/tracing"
+	"github.com/kyverno/kyverno/pkg/tracing/tracingtest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gotest.tools/assert"
+)
+
+func Test_Validate_spans(t *testing.T) {
+	provider, exporter := tracingtest.NewInMemoryProvider()
+	defer provider.Shutdown(contextdefault.Background())
+
+	rawPolicy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "require-labels"},
+		"spec": {
+			"rules": [
+				{
+					"name": "check-app",
+					"match": {"resources": {"kinds": ["Pod"]}},
+					"validate": {"pattern": {"metadata": {"labels": {"app": "?*"}}}}
+				},
+				{
+					"name": "check-deployments",
+					"match": {"resources": {"kinds": ["Deployment"]}},
+					"validate": {"pattern": {"metadata": {"labels": {"app": "?*"}}}}
+				}
+			]
+		}
+	}`)
+	rawResource := []byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {"name": "nginx", "namespace": "default", "labels": {"app": "nginx"}},
+		"spec": {"containers": [{"name": "nginx", "image": "nginx"}]}
+	}`)
+
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+	resource, err := utils.ConvertToUnstructured(rawResource)
+	assert.NilError(t, err)
+	ctx := context.NewContext()
+	assert.NilError(t, ctx.AddResource(rawResource))
+
+	traceCtx, admissionSpan := tracing.StartSpan(contextdefault.Background(), "admission")
+	er := Validate(&PolicyContext{Policy: policy, NewResource: *resource, JSONContext: ctx, TraceContext: traceCtx})
+	admissionSpan.End()
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+
+	spans := exporter.GetSpans()
+	assert.Equal(t, len(spans), 3)
+	ruleSpan, policySpan := spans[0], spans[1]
+
+	assert.Equal(t, policySpan.Name, "validate")
+	assert.Equal(t, policySpan.Parent.SpanID(), admissionSpan.SpanContext().SpanID())
+	assert.Equal(t, spanAttribute(policySpan, tracing.PolicyNameKey), "require-labels")
+	assert.Equal(t, spanAttribute(policySpan, tracing.ResourceNameKey), "nginx")
+
+	assert.Equal(t, ruleSpan.Name, "rule")
+	assert.Equal(t, ruleSpan.Parent.SpanID(), policySpan.SpanContext.SpanID())
+	assert.Equal(t, spanAttribute(ruleSpan, tracing.RuleNameKey), "check-app")
+	assert.Equal(t, spanAttribute(ruleSpan, tracing.RuleResultKey), "pass")
+}
+
+func spanAttribute(span tracetest.SpanStub, key attribute.Key) string {
+	for _, attr := range span.Attributes {
+		if attr.Key == key {
+			return attr.Value.AsString()
+		}
+	}
+
+	return ""
+}
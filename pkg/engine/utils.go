@@ -28,21 +28,18 @@ type EngineStats struct {
 	RulesAppliedCount int
 }
 
-func checkKind(kinds []string, resource unstructured.Unstructured) bool {
-	for _, kind := range kinds {
-		SplitGVK := strings.Split(kind, "/")
-		if len(SplitGVK) == 1 {
-			if resource.GetKind() == kind {
-				return true
-			}
-		} else if len(SplitGVK) == 2 {
-			if resource.GroupVersionKind().Kind == SplitGVK[1] && resource.GroupVersionKind().Version == SplitGVK[0] {
-				return true
-			}
-		} else {
-			if resource.GroupVersionKind().Group == SplitGVK[0] && resource.GroupVersionKind().Kind == SplitGVK[2] && (resource.GroupVersionKind().Version == SplitGVK[1] || resource.GroupVersionKind().Version == "*") {
+func checkKind(kinds []string, resource unstructured.Unstructured, subresource Subresource) bool {
+	for _, k := range kinds {
+		kind, sub := utils.SplitSubresource(k)
+		if sub != "" {
+			if subresource.Name != "" && wildcard.Match(sub, subresource.Name) && utils.MatchesGVK(kind, subresource.ParentKind) {
 				return true
 			}
+			continue
+		}
+
+		if utils.MatchesGVK(kind, resource.GroupVersionKind()) {
+			return true
 		}
 	}
 
@@ -105,6 +102,34 @@ func checkSelector(labelSelector *metav1.LabelSelector, resourceLabels map[strin
 	return false, nil
 }
 
+// GetValidationFailureAction returns the validation failure action of the policy for a resource in the given namespace.
+// The first validationFailureActionOverrides entry that selects the namespace takes precedence over spec.validationFailureAction.
+func GetValidationFailureAction(policy kyverno.ClusterPolicy, namespace string, namespaceLabels map[string]string) string {
+	if namespace == "" {
+		return policy.Spec.ValidationFailureAction
+	}
+
+	for _, override := range policy.Spec.ValidationFailureActionOverrides {
+		if len(override.Namespaces) == 0 && override.NamespaceSelector == nil {
+			continue
+		}
+
+		if len(override.Namespaces) > 0 && !utils.ContainsNamepace(override.Namespaces, namespace) {
+			continue
+		}
+
+		if override.NamespaceSelector != nil {
+			if matched, err := checkSelector(override.NamespaceSelector.DeepCopy(), namespaceLabels); err != nil || !matched {
+				continue
+			}
+		}
+
+		return override.Action
+	}
+
+	return policy.Spec.ValidationFailureAction
+}
+
 // doesResourceMatchConditionBlock filters the resource with defined conditions
 // for a match / exclude block, it has the following attributes:
 // ResourceDescription:
@@ -120,11 +145,11 @@ func checkSelector(labelSelector *metav1.LabelSelector, resourceLabels map[strin
 // should be: AND across attributes but an OR inside attributes that of type list
 // To filter out the targeted resources with UserInfo, the check
 // should be: OR (across & inside) attributes
-func doesResourceMatchConditionBlock(conditionBlock kyverno.ResourceDescription, userInfo kyverno.UserInfo, admissionInfo kyverno.RequestInfo, resource unstructured.Unstructured, dynamicConfig []string, namespaceLabels map[string]string) []error {
+func doesResourceMatchConditionBlock(conditionBlock kyverno.ResourceDescription, userInfo kyverno.UserInfo, admissionInfo kyverno.RequestInfo, resource unstructured.Unstructured, dynamicConfig []string, namespaceLabels map[string]string, subresource Subresource) []error {
 	var errs []error
 
 	if len(conditionBlock.Kinds) > 0 {
-		if !checkKind(conditionBlock.Kinds, resource) {
+		if !checkKind(conditionBlock.Kinds, resource, subresource) {
 			errs = append(errs, fmt.Errorf("kind does not match %v", conditionBlock.Kinds))
 		}
 	}
@@ -256,7 +281,8 @@ func matchSubjects(ruleSubjects []rbacv1.Subject, userInfo authenticationv1.User
 }
 
 //MatchesResourceDescription checks if the resource matches resource description of the rule or not
-func MatchesResourceDescription(resourceRef unstructured.Unstructured, ruleRef kyverno.Rule, admissionInfoRef kyverno.RequestInfo, dynamicConfig []string, namespaceLabels map[string]string) error {
+// subresource is only set for admission requests on a subresource, e.g. pods/exec
+func MatchesResourceDescription(resourceRef unstructured.Unstructured, ruleRef kyverno.Rule, admissionInfoRef kyverno.RequestInfo, dynamicConfig []string, namespaceLabels map[string]string, subresource Subresource) error {
 
 	rule := *ruleRef.DeepCopy()
 	resource := *resourceRef.DeepCopy()
@@ -266,22 +292,69 @@ func MatchesResourceDescription(resourceRef unstructured.Unstructured, ruleRef k
 
 	if reflect.DeepEqual(admissionInfo, kyverno.RequestInfo{}) {
 		rule.MatchResources.UserInfo = kyverno.UserInfo{}
+		for i := range rule.MatchResources.Any {
+			rule.MatchResources.Any[i].UserInfo = kyverno.UserInfo{}
+		}
+		for i := range rule.MatchResources.All {
+			rule.MatchResources.All[i].UserInfo = kyverno.UserInfo{}
+		}
+	}
+
+	matchFilter := func(rf kyverno.ResourceFilter) []error {
+		return doesResourceMatchConditionBlock(rf.ResourceDescription, rf.UserInfo, admissionInfo, resource, dynamicConfig, namespaceLabels, subresource)
 	}
 
 	// checking if resource matches the rule
-	if !reflect.DeepEqual(rule.MatchResources.ResourceDescription, kyverno.ResourceDescription{}) ||
+	if len(rule.MatchResources.Any) > 0 {
+		// include the resource if any of the filters matches
+		matched := false
+		for _, rf := range rule.MatchResources.Any {
+			if len(matchFilter(rf)) == 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			reasonsForFailure = append(reasonsForFailure, fmt.Errorf("no resource filter in match.any matched"))
+		}
+	} else if len(rule.MatchResources.All) > 0 {
+		// include the resource only if all of the filters match
+		for _, rf := range rule.MatchResources.All {
+			reasonsForFailure = append(reasonsForFailure, matchFilter(rf)...)
+		}
+	} else if !reflect.DeepEqual(rule.MatchResources.ResourceDescription, kyverno.ResourceDescription{}) ||
 		!reflect.DeepEqual(rule.MatchResources.UserInfo, kyverno.UserInfo{}) {
-		matchErrs := doesResourceMatchConditionBlock(rule.MatchResources.ResourceDescription, rule.MatchResources.UserInfo, admissionInfo, resource, dynamicConfig, namespaceLabels)
-		reasonsForFailure = append(reasonsForFailure, matchErrs...)
+		rf := kyverno.ResourceFilter{UserInfo: rule.MatchResources.UserInfo, ResourceDescription: rule.MatchResources.ResourceDescription}
+		reasonsForFailure = append(reasonsForFailure, matchFilter(rf)...)
 	} else {
 		reasonsForFailure = append(reasonsForFailure, fmt.Errorf("match cannot be empty"))
 	}
 
 	// checking if resource has been excluded
-	if !reflect.DeepEqual(rule.ExcludeResources.ResourceDescription, kyverno.ResourceDescription{}) ||
+	if len(rule.ExcludeResources.Any) > 0 {
+		// exclude the resource if any of the filters matches
+		for _, rf := range rule.ExcludeResources.Any {
+			if len(matchFilter(rf)) == 0 {
+				reasonsForFailure = append(reasonsForFailure, fmt.Errorf("resource excluded"))
+				break
+			}
+		}
+	} else if len(rule.ExcludeResources.All) > 0 {
+		// exclude the resource only if all of the filters match
+		excluded := true
+		for _, rf := range rule.ExcludeResources.All {
+			if len(matchFilter(rf)) > 0 {
+				excluded = false
+				break
+			}
+		}
+		if excluded {
+			reasonsForFailure = append(reasonsForFailure, fmt.Errorf("resource excluded"))
+		}
+	} else if !reflect.DeepEqual(rule.ExcludeResources.ResourceDescription, kyverno.ResourceDescription{}) ||
 		!reflect.DeepEqual(rule.ExcludeResources.UserInfo, kyverno.UserInfo{}) {
-		excludeErrs := doesResourceMatchConditionBlock(rule.ExcludeResources.ResourceDescription, rule.ExcludeResources.UserInfo, admissionInfo, resource, dynamicConfig, namespaceLabels)
-		if excludeErrs == nil {
+		rf := kyverno.ResourceFilter{UserInfo: rule.ExcludeResources.UserInfo, ResourceDescription: rule.ExcludeResources.ResourceDescription}
+		if len(matchFilter(rf)) == 0 {
 			reasonsForFailure = append(reasonsForFailure, fmt.Errorf("resource excluded"))
 		}
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/utils"
 	"gotest.tools/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestMatchesResourceDescription(t *testing.T) {
@@ -140,7 +141,7 @@ func TestMatchesResourceDescription(t *testing.T) {
 		resource, _ := utils.ConvertToUnstructured(tc.Resource)
 
 		for _, rule := range policy.Spec.Rules {
-			err := MatchesResourceDescription(*resource, rule, tc.AdmissionInfo, []string{}, nil)
+			err := MatchesResourceDescription(*resource, rule, tc.AdmissionInfo, []string{}, nil, Subresource{})
 			if err != nil {
 				if !tc.areErrorsExpected {
 					t.Errorf("Testcase %d Unexpected error: %v", i+1, err)
@@ -208,7 +209,7 @@ func TestResourceDescriptionMatch_MultipleKind(t *testing.T) {
 	}
 	rule := kyverno.Rule{MatchResources: kyverno.MatchResources{ResourceDescription: resourceDescription}}
 
-	if err := MatchesResourceDescription(*resource, rule, kyverno.RequestInfo{}, []string{}, nil); err != nil {
+	if err := MatchesResourceDescription(*resource, rule, kyverno.RequestInfo{}, []string{}, nil, Subresource{}); err != nil {
 		t.Errorf("Testcase has failed due to the following:%v", err)
 	}
 
@@ -269,7 +270,7 @@ func TestResourceDescriptionMatch_Name(t *testing.T) {
 	}
 	rule := kyverno.Rule{MatchResources: kyverno.MatchResources{ResourceDescription: resourceDescription}}
 
-	if err := MatchesResourceDescription(*resource, rule, kyverno.RequestInfo{}, []string{}, nil); err != nil {
+	if err := MatchesResourceDescription(*resource, rule, kyverno.RequestInfo{}, []string{}, nil, Subresource{}); err != nil {
 		t.Errorf("Testcase has failed due to the following:%v", err)
 	}
 }
@@ -329,7 +330,7 @@ func TestResourceDescriptionMatch_Name_Regex(t *testing.T) {
 	}
 	rule := kyverno.Rule{MatchResources: kyverno.MatchResources{ResourceDescription: resourceDescription}}
 
-	if err := MatchesResourceDescription(*resource, rule, kyverno.RequestInfo{}, []string{}, nil); err != nil {
+	if err := MatchesResourceDescription(*resource, rule, kyverno.RequestInfo{}, []string{}, nil, Subresource{}); err != nil {
 		t.Errorf("Testcase has failed due to the following:%v", err)
 	}
 }
@@ -397,7 +398,7 @@ func TestResourceDescriptionMatch_Label_Expression_NotMatch(t *testing.T) {
 	}
 	rule := kyverno.Rule{MatchResources: kyverno.MatchResources{ResourceDescription: resourceDescription}}
 
-	if err := MatchesResourceDescription(*resource, rule, kyverno.RequestInfo{}, []string{}, nil); err != nil {
+	if err := MatchesResourceDescription(*resource, rule, kyverno.RequestInfo{}, []string{}, nil, Subresource{}); err != nil {
 		t.Errorf("Testcase has failed due to the following:%v", err)
 	}
 }
@@ -466,7 +467,7 @@ func TestResourceDescriptionMatch_Label_Expression_Match(t *testing.T) {
 	}
 	rule := kyverno.Rule{MatchResources: kyverno.MatchResources{ResourceDescription: resourceDescription}}
 
-	if err := MatchesResourceDescription(*resource, rule, kyverno.RequestInfo{}, []string{}, nil); err != nil {
+	if err := MatchesResourceDescription(*resource, rule, kyverno.RequestInfo{}, []string{}, nil, Subresource{}); err != nil {
 		t.Errorf("Testcase has failed due to the following:%v", err)
 	}
 }
@@ -546,7 +547,7 @@ func TestResourceDescriptionExclude_Label_Expression_Match(t *testing.T) {
 	rule := kyverno.Rule{MatchResources: kyverno.MatchResources{ResourceDescription: resourceDescription},
 		ExcludeResources: kyverno.ExcludeResources{ResourceDescription: resourceDescriptionExclude}}
 
-	if err := MatchesResourceDescription(*resource, rule, kyverno.RequestInfo{}, []string{}, nil); err == nil {
+	if err := MatchesResourceDescription(*resource, rule, kyverno.RequestInfo{}, []string{}, nil, Subresource{}); err == nil {
 		t.Errorf("Testcase has failed due to the following:\n Function has returned no error, even though it was supposed to fail")
 	}
 }
@@ -690,3 +691,100 @@ func TestManagedPodResource(t *testing.T) {
 		assert.Equal(t, res, tc.expectedResult, "test %d/%s failed, expect %v, got %v", i+1, tc.name, tc.expectedResult, res)
 	}
 }
+
+func TestResourceDescriptionMatch_Subresource(t *testing.T) {
+	rawResource := []byte(`{"kind":"PodExecOptions","apiVersion":"v1","stdin":true,"tty":true,"container":"nginx","command":["/bin/sh"]}`)
+	resource, err := utils.ConvertToUnstructured(rawResource)
+	assert.NilError(t, err)
+	resource.SetNamespace("prod")
+	resource.SetName("nginx")
+
+	podExec := Subresource{Name: "exec", ParentKind: schema.GroupVersionKind{Version: "v1", Kind: "Pod"}}
+	deploymentScale := Subresource{Name: "scale", ParentKind: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}}
+
+	testCases := []struct {
+		kinds       []string
+		subresource Subresource
+		match       bool
+	}{
+		{kinds: []string{"Pod/exec"}, subresource: podExec, match: true},
+		{kinds: []string{"v1/Pod/exec"}, subresource: podExec, match: true},
+		{kinds: []string{"Pod/*"}, subresource: podExec, match: true},
+		{kinds: []string{"Pod/attach"}, subresource: podExec, match: false},
+		{kinds: []string{"Pod"}, subresource: podExec, match: false},
+		{kinds: []string{"PodExecOptions"}, subresource: podExec, match: true},
+		{kinds: []string{"Pod/exec"}, subresource: Subresource{}, match: false},
+		{kinds: []string{"Deployment/scale"}, subresource: deploymentScale, match: true},
+		{kinds: []string{"apps/v1/Deployment/scale"}, subresource: deploymentScale, match: true},
+		{kinds: []string{"Deployment/scale"}, subresource: podExec, match: false},
+	}
+
+	for i, tc := range testCases {
+		rule := kyverno.Rule{MatchResources: kyverno.MatchResources{ResourceDescription: kyverno.ResourceDescription{Kinds: tc.kinds}}}
+		err := MatchesResourceDescription(*resource, rule, kyverno.RequestInfo{}, []string{}, nil, tc.subresource)
+		assert.Equal(t, err == nil, tc.match, "test %d failed for kinds %v: %v", i+1, tc.kinds, err)
+	}
+}
+
+func TestResourceDescriptionMatch_AnyAll(t *testing.T) {
+	rawResource := []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"nginx","namespace":"prod","labels":{"team":"x"}}}`)
+	resource, err := utils.ConvertToUnstructured(rawResource)
+	assert.NilError(t, err)
+
+	podsInA := kyverno.ResourceFilter{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Pod"}, Namespaces: []string{"a"}}}
+	teamX := kyverno.ResourceFilter{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Deployment"}, Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "x"}}}}
+	inProd := kyverno.ResourceFilter{ResourceDescription: kyverno.ResourceDescription{Namespaces: []string{"prod"}}}
+	named := kyverno.ResourceFilter{ResourceDescription: kyverno.ResourceDescription{Name: "other"}}
+
+	testCases := []struct {
+		name    string
+		match   kyverno.MatchResources
+		exclude kyverno.ExcludeResources
+		matched bool
+	}{
+		{name: "match-any", match: kyverno.MatchResources{Any: kyverno.ResourceFilters{podsInA, teamX}}, matched: true},
+		{name: "match-any-none", match: kyverno.MatchResources{Any: kyverno.ResourceFilters{podsInA, named}}, matched: false},
+		{name: "match-all", match: kyverno.MatchResources{All: kyverno.ResourceFilters{teamX, inProd}}, matched: true},
+		{name: "match-all-one-missing", match: kyverno.MatchResources{All: kyverno.ResourceFilters{teamX, named}}, matched: false},
+		{name: "exclude-any", match: kyverno.MatchResources{Any: kyverno.ResourceFilters{teamX}}, exclude: kyverno.ExcludeResources{Any: kyverno.ResourceFilters{named, inProd}}, matched: false},
+		{name: "exclude-all", match: kyverno.MatchResources{Any: kyverno.ResourceFilters{teamX}}, exclude: kyverno.ExcludeResources{All: kyverno.ResourceFilters{named, inProd}}, matched: true},
+		{name: "legacy", match: kyverno.MatchResources{ResourceDescription: teamX.ResourceDescription}, exclude: kyverno.ExcludeResources{ResourceDescription: inProd.ResourceDescription}, matched: false},
+	}
+
+	for _, tc := range testCases {
+		rule := kyverno.Rule{MatchResources: tc.match, ExcludeResources: tc.exclude}
+		err := MatchesResourceDescription(*resource, rule, kyverno.RequestInfo{}, []string{}, nil, Subresource{})
+		assert.Equal(t, err == nil, tc.matched, "test %s failed: %v", tc.name, err)
+	}
+}
+
+func TestGetValidationFailureAction(t *testing.T) {
+	policy := kyverno.ClusterPolicy{
+		Spec: kyverno.Spec{
+			ValidationFailureAction: "audit",
+			ValidationFailureActionOverrides: []kyverno.ValidationFailureActionOverride{
+				{Action: "enforce", Namespaces: []string{"prod-*"}},
+				{Action: "enforce", NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}},
+				{Action: "audit", Namespaces: []string{"test"}, NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}}},
+			},
+		},
+	}
+
+	testCases := []struct {
+		namespace string
+		labels    map[string]string
+		action    string
+	}{
+		{namespace: "", action: "audit"},
+		{namespace: "default", action: "audit"},
+		{namespace: "prod-apps", action: "enforce"},
+		{namespace: "apps", labels: map[string]string{"env": "prod"}, action: "enforce"},
+		{namespace: "test", labels: map[string]string{"env": "dev"}, action: "audit"},
+		{namespace: "other", labels: map[string]string{"env": "dev"}, action: "audit"},
+	}
+
+	for _, tc := range testCases {
+		action := GetValidationFailureAction(policy, tc.namespace, tc.labels)
+		assert.Equal(t, action, tc.action, "unexpected action for namespace %q", tc.namespace)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/engine/validate"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/tracing"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -24,6 +25,9 @@ func Validate(policyContext *PolicyContext) (resp *response.EngineResponse) {
 	resp = &response.EngineResponse{}
 	startTime := time.Now()
 
+	_, endSpan := policyContext.startPolicySpan("validate")
+	defer endSpan()
+
 	logger := buildLogger(policyContext)
 	logger.V(4).Info("start policy processing", "startTime", startTime)
 	defer func() {
@@ -67,11 +71,29 @@ func buildResponse(logger logr.Logger, ctx *PolicyContext, resp *response.Engine
 	resp.PolicyResponse.Resource.Namespace = resp.PatchedResource.GetNamespace()
 	resp.PolicyResponse.Resource.Kind = resp.PatchedResource.GetKind()
 	resp.PolicyResponse.Resource.APIVersion = resp.PatchedResource.GetAPIVersion()
-	resp.PolicyResponse.ValidationFailureAction = ctx.Policy.Spec.ValidationFailureAction
+	namespace, namespaceLabels := resp.PatchedResource.GetNamespace(), ctx.NamespaceLabels
+	if resp.PatchedResource.GetKind() == "Namespace" {
+		namespace, namespaceLabels = resp.PatchedResource.GetName(), resp.PatchedResource.GetLabels()
+	}
+	resp.PolicyResponse.ValidationFailureAction = GetValidationFailureAction(ctx.Policy, namespace, namespaceLabels)
+	setRuleValidationFailureActions(ctx.Policy, resp)
 	resp.PolicyResponse.ProcessingTime = time.Since(startTime)
 	resp.PolicyResponse.PolicyExecutionTimestamp = startTime.Unix()
 }
 
+// setRuleValidationFailureActions sets the validation failure action of each rule response,
+// rules without an action inherit the action of the policy response
+func setRuleValidationFailureActions(policy kyverno.ClusterPolicy, resp *response.EngineResponse) {
+	ruleActions := make(map[string]string, len(policy.Spec.Rules))
+	for _, rule := range policy.Spec.Rules {
+		ruleActions[rule.Name] = rule.GetValidationFailureAction(resp.PolicyResponse.ValidationFailureAction)
+	}
+
+	for i, rule := range resp.PolicyResponse.Rules {
+		resp.PolicyResponse.Rules[i].ValidationFailureAction = ruleActions[rule.Name]
+	}
+}
+
 func incrementAppliedCount(resp *response.EngineResponse) {
 	resp.PolicyResponse.RulesAppliedCount++
 }
@@ -87,8 +109,6 @@ func validateResource(log logr.Logger, ctx *PolicyContext) *response.EngineRespo
 	defer ctx.JSONContext.Restore()
 
 	for _, rule := range ctx.Policy.Spec.Rules {
-		var err error
-
 		if !rule.HasValidate() {
 			continue
 		}
@@ -100,74 +120,81 @@ func validateResource(log logr.Logger, ctx *PolicyContext) *response.EngineRespo
 		}
 
 		ctx.JSONContext.Restore()
-		if err := LoadContext(log, rule.Context, ctx.ResourceCache, ctx, rule.Name); err != nil {
-			log.Error(err, "failed to load context")
-			continue
+		if ruleResp := processValidationRule(log, ctx, rule); ruleResp != nil {
+			incrementAppliedCount(resp)
+			resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *ruleResp)
 		}
+	}
 
-		log.V(3).Info("matched validate rule")
+	return resp
+}
 
-		// operate on the copy of the conditions, as we perform variable substitution
-		preconditionsCopy, err := copyConditions(rule.AnyAllConditions)
-		if err != nil {
-			log.V(2).Info("wrongfully configured data", "reason", err.Error())
-			continue
-		}
+// processValidationRule applies the validate rule matching the resource, it returns nil if the rule is skipped
+func processValidationRule(log logr.Logger, ctx *PolicyContext, rule kyverno.Rule) (ruleResp *response.RuleResponse) {
+	span, endSpan := ctx.startRuleSpan(rule, utils.Validation)
+	defer func() {
+		setRuleResult(span, ruleResp)
+		endSpan()
+	}()
 
-		// evaluate pre-conditions
-		if !variables.EvaluateConditions(log, ctx.JSONContext, preconditionsCopy, true) {
-			log.V(4).Info("resource fails the preconditions")
-			continue
-		}
+	if err := LoadContext(log, rule.Context, ctx.ResourceCache, ctx, rule.Name); err != nil {
+		log.Error(err, "failed to load context")
+		tracing.SetError(span, err)
+		return nil
+	}
 
-		if rule, err = variables.SubstituteAllInRule(log, ctx.JSONContext, rule); err != nil {
-			ruleResp := response.RuleResponse{
-				Name:    rule.Name,
-				Type:    utils.Validation.String(),
-				Message: fmt.Sprintf("variable substitution failed for rule %s: %s", rule.Name, err.Error()),
-				Success: true,
-			}
+	log.V(3).Info("matched validate rule")
 
-			incrementAppliedCount(resp)
-			resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, ruleResp)
+	// operate on the copy of the conditions, as we perform variable substitution
+	preconditionsCopy, err := copyConditions(rule.AnyAllConditions)
+	if err != nil {
+		log.V(2).Info("wrongfully configured data", "reason", err.Error())
+		return nil
+	}
 
-			switch err.(type) {
-			case gojmespath.NotFoundError:
-				log.V(2).Info("failed to substitute variables, skip current rule", "info", err.Error(), "rule name", rule.Name)
-			default:
-				log.Error(err, "failed to substitute variables, skip current rule", "rule name", rule.Name)
-			}
-			continue
+	// evaluate pre-conditions
+	if !variables.EvaluateConditions(log, ctx.JSONContext, preconditionsCopy, true) {
+		log.V(4).Info("resource fails the preconditions")
+		return nil
+	}
+
+	if rule, err = variables.SubstituteAllInRule(log, ctx.JSONContext, rule); err != nil {
+		switch err.(type) {
+		case gojmespath.NotFoundError:
+			log.V(2).Info("failed to substitute variables, skip current rule", "info", err.Error(), "rule name", rule.Name)
+		default:
+			log.Error(err, "failed to substitute variables, skip current rule", "rule name", rule.Name)
 		}
 
-		if rule.Validation.Pattern != nil || rule.Validation.AnyPattern != nil {
-			ruleResponse := validateResourceWithRule(log, ctx, rule)
-			if ruleResponse != nil {
-				if !common.IsConditionalAnchorError(ruleResponse.Message) {
-					incrementAppliedCount(resp)
-					resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *ruleResponse)
-				}
-			}
-		} else if rule.Validation.Deny != nil {
-			denyConditionsCopy, err := copyConditions(rule.Validation.Deny.AnyAllConditions)
-			if err != nil {
-				log.V(2).Info("wrongfully configured data", "reason", err.Error())
-				continue
-			}
-			deny := variables.EvaluateConditions(log, ctx.JSONContext, denyConditionsCopy, false)
-			ruleResp := response.RuleResponse{
-				Name:    rule.Name,
-				Type:    utils.Validation.String(),
-				Message: rule.Validation.Message,
-				Success: !deny,
-			}
+		return &response.RuleResponse{
+			Name:    rule.Name,
+			Type:    utils.Validation.String(),
+			Message: fmt.Sprintf("variable substitution failed for rule %s: %s", rule.Name, err.Error()),
+			Success: true,
+		}
+	}
 
-			incrementAppliedCount(resp)
-			resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, ruleResp)
+	if rule.Validation.Pattern != nil || rule.Validation.AnyPattern != nil {
+		ruleResponse := validateResourceWithRule(log, ctx, rule)
+		if ruleResponse != nil && !common.IsConditionalAnchorError(ruleResponse.Message) {
+			return ruleResponse
+		}
+	} else if rule.Validation.Deny != nil {
+		denyConditionsCopy, err := copyConditions(rule.Validation.Deny.AnyAllConditions)
+		if err != nil {
+			log.V(2).Info("wrongfully configured data", "reason", err.Error())
+			return nil
+		}
+		deny := variables.EvaluateConditions(log, ctx.JSONContext, denyConditionsCopy, false)
+		return &response.RuleResponse{
+			Name:    rule.Name,
+			Type:    utils.Validation.String(),
+			Message: rule.Validation.Message,
+			Success: !deny,
 		}
 	}
 
-	return resp
+	return nil
 }
 
 func validateResourceWithRule(log logr.Logger, ctx *PolicyContext, rule kyverno.Rule) (resp *response.RuleResponse) {
@@ -193,13 +220,13 @@ func validateResourceWithRule(log logr.Logger, ctx *PolicyContext, rule kyverno.
 
 // matches checks if either the new or old resource satisfies the filter conditions defined in the rule
 func matches(logger logr.Logger, rule kyverno.Rule, ctx *PolicyContext) bool {
-	err := MatchesResourceDescription(ctx.NewResource, rule, ctx.AdmissionInfo, ctx.ExcludeGroupRole, ctx.NamespaceLabels)
+	err := MatchesResourceDescription(ctx.NewResource, rule, ctx.AdmissionInfo, ctx.ExcludeGroupRole, ctx.NamespaceLabels, ctx.Subresource)
 	if err == nil {
 		return true
 	}
 
 	if !reflect.DeepEqual(ctx.OldResource, unstructured.Unstructured{}) {
-		err := MatchesResourceDescription(ctx.OldResource, rule, ctx.AdmissionInfo, ctx.ExcludeGroupRole, ctx.NamespaceLabels)
+		err := MatchesResourceDescription(ctx.OldResource, rule, ctx.AdmissionInfo, ctx.ExcludeGroupRole, ctx.NamespaceLabels, ctx.Subresource)
 		if err == nil {
 			return true
 		}
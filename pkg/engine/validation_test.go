@@ -2242,3 +2242,58 @@ func TestValidate_context_variable_substitution_CLI(t *testing.T) {
 	}
 	assert.Assert(t, !er.IsSuccessful())
 }
+
+func Test_Validate_RuleValidationFailureAction(t *testing.T) {
+	rawPolicy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+			"name": "require-labels"
+		},
+		"spec": {
+			"validationFailureAction": "audit",
+			"rules": [
+				{
+					"name": "check-app",
+					"match": {"resources": {"kinds": ["Pod"]}},
+					"validate": {
+						"validationFailureAction": "enforce",
+						"pattern": {"metadata": {"labels": {"app": "?*"}}}
+					}
+				},
+				{
+					"name": "check-team",
+					"match": {"resources": {"kinds": ["Pod"]}},
+					"validate": {
+						"pattern": {"metadata": {"labels": {"team": "?*"}}}
+					}
+				}
+			]
+		}
+	}`)
+
+	rawResource := []byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+			"name": "nginx",
+			"namespace": "default"
+		},
+		"spec": {
+			"containers": [{"name": "nginx", "image": "nginx"}]
+		}
+	}`)
+
+	var policy kyverno.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+
+	resourceUnstructured, err := utils.ConvertToUnstructured(rawResource)
+	assert.NilError(t, err)
+
+	er := Validate(&PolicyContext{Policy: policy, NewResource: *resourceUnstructured, JSONContext: context.NewContext()})
+	assert.Equal(t, er.PolicyResponse.ValidationFailureAction, "audit")
+	assert.Equal(t, len(er.PolicyResponse.Rules), 2)
+	assert.Equal(t, er.PolicyResponse.Rules[0].ValidationFailureAction, "enforce")
+	assert.Equal(t, er.PolicyResponse.Rules[1].ValidationFailureAction, "audit")
+}
@@ -4,10 +4,13 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
-	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
+	kyverno "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	kyvernoscheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
 	kyvernolister "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
-	client "github.com/kyverno/kyverno/pkg/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	eventsDropped "github.com/kyverno/kyverno/pkg/metrics/eventsdropped"
 	"github.com/kyverno/kyverno/pkg/resourcecache"
 	v1 "k8s.io/api/core/v1"
 	errors "k8s.io/apimachinery/pkg/api/errors"
@@ -15,16 +18,16 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
-	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
-	"k8s.io/klog/v2"
 )
 
 //Generator generate events
 type Generator struct {
-	client *client.Client
 	// list/get cluster policy
 	cpLister kyvernolister.ClusterPolicyLister
 	// returns true if the cluster policy store has been synced at least once
@@ -35,14 +38,17 @@ type Generator struct {
 	pSynced cache.InformerSynced
 	// queue to store event generation requests
 	queue workqueue.RateLimitingInterface
-	// events generated at policy controller
-	policyCtrRecorder record.EventRecorder
-	// events generated at admission control
-	admissionCtrRecorder record.EventRecorder
-	// events generated at namespaced policy controller to process 'generate' rule
-	genPolicyRecorder record.EventRecorder
-	resCache          resourcecache.ResourceCache
-	log               logr.Logger
+	// broadcaster sending the events to events.k8s.io/v1 if available, core/v1 otherwise,
+	// it aggregates the repeated events on the same object, policy and rule into series
+	broadcaster events.EventBroadcasterAdapter
+	// event recorders per source of the events
+	recorders map[Source]events.EventRecorder
+	// rate limiters per source of the events
+	limiters      map[Source]flowcontrol.RateLimiter
+	configHandler config.Interface
+	resCache      resourcecache.ResourceCache
+	promConfig    *metrics.PromConfig
+	log           logr.Logger
 }
 
 //Interface to generate event
@@ -51,20 +57,31 @@ type Interface interface {
 }
 
 //NewEventGenerator to generate a new event controller
-func NewEventGenerator(client *client.Client, cpInformer kyvernoinformer.ClusterPolicyInformer, pInformer kyvernoinformer.PolicyInformer, resCache resourcecache.ResourceCache, log logr.Logger) *Generator {
+// - qps and burst limit the rate of events emitted per source, the dropped events are counted in the metrics
+func NewEventGenerator(kubeClient kubernetes.Interface, cpInformer kyvernoinformer.ClusterPolicyInformer, pInformer kyvernoinformer.PolicyInformer, resCache resourcecache.ResourceCache, qps float32, burst int, promConfig *metrics.PromConfig, log logr.Logger) *Generator {
+	// the recorders resolve the references of Kyverno resources with the client-go scheme
+	if err := kyvernoscheme.AddToScheme(scheme.Scheme); err != nil {
+		log.Error(err, "failed to add to scheme")
+	}
 
+	broadcaster := events.NewEventBroadcasterAdapter(kubeClient)
 	gen := Generator{
-		client:               client,
-		cpLister:             cpInformer.Lister(),
-		cpSynced:             cpInformer.Informer().HasSynced,
-		pLister:              pInformer.Lister(),
-		pSynced:              pInformer.Informer().HasSynced,
-		queue:                workqueue.NewNamedRateLimitingQueue(rateLimiter(), eventWorkQueueName),
-		policyCtrRecorder:    initRecorder(client, PolicyController, log),
-		admissionCtrRecorder: initRecorder(client, AdmissionController, log),
-		genPolicyRecorder:    initRecorder(client, GeneratePolicyController, log),
-		resCache:             resCache,
-		log:                  log,
+		cpLister:    cpInformer.Lister(),
+		cpSynced:    cpInformer.Informer().HasSynced,
+		pLister:     pInformer.Lister(),
+		pSynced:     pInformer.Informer().HasSynced,
+		queue:       workqueue.NewNamedRateLimitingQueue(rateLimiter(), eventWorkQueueName),
+		broadcaster: broadcaster,
+		recorders:   make(map[Source]events.EventRecorder),
+		limiters:    make(map[Source]flowcontrol.RateLimiter),
+		resCache:    resCache,
+		promConfig:  promConfig,
+		log:         log,
+	}
+
+	for _, source := range []Source{AdmissionController, PolicyController, GeneratePolicyController} {
+		gen.recorders[source] = broadcaster.NewRecorder(source.String())
+		gen.limiters[source] = flowcontrol.NewTokenBucketRateLimiter(qps, burst)
 	}
 	return &gen
 }
@@ -73,29 +90,6 @@ func rateLimiter() workqueue.RateLimiter {
 	return workqueue.DefaultItemBasedRateLimiter()
 }
 
-func initRecorder(client *client.Client, eventSource Source, log logr.Logger) record.EventRecorder {
-	// Initliaze Event Broadcaster
-	err := scheme.AddToScheme(scheme.Scheme)
-	if err != nil {
-		log.Error(err, "failed to add to scheme")
-		return nil
-	}
-	eventBroadcaster := record.NewBroadcaster()
-	eventBroadcaster.StartLogging(klog.V(5).Infof)
-	eventInterface, err := client.GetEventsInterface()
-	if err != nil {
-		log.Error(err, "failed to get event interface for logging")
-		return nil
-	}
-	eventBroadcaster.StartRecordingToSink(
-		&typedcorev1.EventSinkImpl{
-			Interface: eventInterface})
-	recorder := eventBroadcaster.NewRecorder(
-		scheme.Scheme,
-		v1.EventSource{Component: eventSource.String()})
-	return recorder
-}
-
 //Add queues an event for generation
 func (gen *Generator) Add(infos ...Info) {
 	logger := gen.log
@@ -110,8 +104,8 @@ func (gen *Generator) Add(infos ...Info) {
 	}
 }
 
-// Run begins generator
-func (gen *Generator) Run(workers int, stopCh <-chan struct{}) {
+// Run begins generator, the configuration controls the result types events are emitted for
+func (gen *Generator) Run(workers int, configHandler config.Interface, stopCh <-chan struct{}) {
 	logger := gen.log
 	defer utilruntime.HandleCrash()
 
@@ -122,6 +116,10 @@ func (gen *Generator) Run(workers int, stopCh <-chan struct{}) {
 		logger.Info("failed to sync informer cache")
 	}
 
+	gen.configHandler = configHandler
+	gen.broadcaster.StartRecordingToSink(stopCh)
+	defer gen.broadcaster.Shutdown()
+
 	for i := 0; i < workers; i++ {
 		go wait.Until(gen.runWorker, time.Second, stopCh)
 	}
@@ -183,6 +181,32 @@ func (gen *Generator) processNextWorkItem() bool {
 }
 
 func (gen *Generator) syncHandler(key Info) error {
+	logger := gen.log
+	if !gen.emitResult(key.Reason) {
+		logger.V(4).Info("events disabled for the result", "reason", key.Reason, "kind", key.Kind, "name", key.Name, "namespace", key.Namespace)
+		return nil
+	}
+
+	return gen.emit(key)
+}
+
+// emitResult checks if the events for the result type of the reason are enabled
+func (gen *Generator) emitResult(reason string) bool {
+	if gen.configHandler == nil {
+		return true
+	}
+
+	switch reason {
+	case PolicyViolation.String():
+		return gen.configHandler.GetGenerateFailEvents()
+	case PolicyFailed.String():
+		return gen.configHandler.GetGenerateErrorEvents()
+	default:
+		return true
+	}
+}
+
+func (gen *Generator) emit(key Info) error {
 	logger := gen.log
 	var robj runtime.Object
 	var err error
@@ -210,26 +234,72 @@ func (gen *Generator) syncHandler(key Info) error {
 		}
 	}
 
+	// based on the source of event generation, use different event recorders
+	recorder, ok := gen.recorders[key.Source]
+	if !ok {
+		logger.Info("info.source not defined for the request")
+		return nil
+	}
+
+	if !gen.accept(key) {
+		return nil
+	}
+
 	// set the event type based on reason
 	eventType := v1.EventTypeWarning
 	if key.Reason == PolicyApplied.String() {
 		eventType = v1.EventTypeNormal
 	}
 
-	// based on the source of event generation, use different event recorders
-	switch key.Source {
-	case AdmissionController:
-		gen.admissionCtrRecorder.Event(robj, eventType, key.Reason, key.Message)
-	case PolicyController:
-		gen.policyCtrRecorder.Event(robj, eventType, key.Reason, key.Message)
-	case GeneratePolicyController:
-		gen.genPolicyRecorder.Event(robj, eventType, key.Reason, key.Message)
-	default:
-		logger.Info("info.source not defined for the request")
+	action := key.Rule
+	if action == "" {
+		action = key.Reason
 	}
+
+	note := key.Message
+	if len(note) > maxNoteLength {
+		note = note[:maxNoteLength]
+	}
+
+	recorder.Eventf(robj, policyReference(key), eventType, key.Reason, action, "%s", note)
 	return nil
 }
 
+// accept checks the rate limit of the source of the event, the dropped events are counted in the metrics
+func (gen *Generator) accept(key Info) bool {
+	if gen.limiters[key.Source].TryAccept() {
+		return true
+	}
+
+	gen.log.V(4).Info("event rate limit exceeded, dropping event", "source", key.Source.String(), "reason", key.Reason, "kind", key.Kind, "name", key.Name, "namespace", key.Namespace)
+	if gen.promConfig != nil {
+		if err := eventsDropped.ParsePromMetrics(*gen.promConfig.Metrics).RegisterDropped(key.Source.String(), key.Reason); err != nil {
+			gen.log.Error(err, "error occurred while registering kyverno_events_dropped_total metrics")
+		}
+	}
+	return false
+}
+
+// policyReference returns the reference to the policy the event relates to,
+// nil if the event is about the policy itself
+func policyReference(key Info) *v1.ObjectReference {
+	if key.Policy == "" || key.Kind == "ClusterPolicy" || key.Kind == "Policy" {
+		return nil
+	}
+
+	ref := &v1.ObjectReference{
+		APIVersion: kyverno.SchemeGroupVersion.String(),
+		Kind:       "ClusterPolicy",
+		Name:       key.Policy,
+	}
+	if namespace, name, err := cache.SplitMetaNamespaceKey(key.Policy); err == nil && namespace != "" {
+		ref.Kind = "Policy"
+		ref.Namespace = namespace
+		ref.Name = name
+	}
+	return ref
+}
+
 func (gen *Generator) getResource(key Info) (obj *unstructured.Unstructured, err error) {
 	lister, ok := gen.resCache.GetGVRCache(key.Kind)
 	if !ok {
@@ -0,0 +1,39 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"gotest.tools/assert"
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_Generator_accept_countsDroppedEvents(t *testing.T) {
+	promConfig := metrics.NewPromConfig(metrics.MetricsConfig{})
+	gen := &Generator{
+		limiters:   map[Source]flowcontrol.RateLimiter{AdmissionController: flowcontrol.NewTokenBucketRateLimiter(0.001, 1)},
+		promConfig: promConfig,
+		log:        log.Log,
+	}
+
+	info := Info{Kind: "Pod", Namespace: "default", Name: "nginx", Reason: PolicyViolation.String(), Source: AdmissionController}
+	assert.Assert(t, gen.accept(info))
+	assert.Assert(t, !gen.accept(info))
+	assert.Assert(t, !gen.accept(info))
+
+	families, err := promConfig.MetricsRegistry.Gather()
+	assert.NilError(t, err)
+
+	var dropped float64
+	for _, family := range families {
+		if family.GetName() != "kyverno_events_dropped_total" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			dropped += metric.GetCounter().GetValue()
+		}
+	}
+	assert.Equal(t, dropped, float64(2))
+}
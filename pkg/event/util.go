@@ -4,6 +4,9 @@ const eventWorkQueueName = "kyverno-events"
 
 const workQueueRetryLimit = 10
 
+// maxNoteLength is the maximum length of the note of an events.k8s.io/v1 event
+const maxNoteLength = 1024
+
 //Info defines the event details
 type Info struct {
 	Kind      string
@@ -12,4 +15,17 @@ type Info struct {
 	Reason    string
 	Message   string
 	Source    Source
+	// Policy is the key (namespace/name for namespaced policies) of the policy the event relates to
+	Policy string
+	// Rule is the name of the rule, or the ';' separated names of the rules, the event relates to
+	Rule string
+}
+
+// PolicyKey returns the key of the policy used in the events,
+// namespace/name for namespaced policies and name for cluster policies
+func PolicyKey(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
 }
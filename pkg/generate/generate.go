@@ -16,8 +16,10 @@ import (
 	dclient "github.com/kyverno/kyverno/pkg/dclient"
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/policyreport"
 	kyvernoutils "github.com/kyverno/kyverno/pkg/utils"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -146,9 +148,19 @@ func (c *Controller) applyGenerate(resource unstructured.Unstructured, gr kyvern
 		return nil, errors.New(doesNotApply)
 	}
 
-	var applicableRules []string
+	var applicableRules, ruleErrors []string
 	// Removing GR if rule is failed. Used when the generate condition failed but gr exist
 	for _, r := range engineResponse.PolicyResponse.Rules {
+		switch r.GetStatus() {
+		case response.RuleStatusPass:
+			applicableRules = append(applicableRules, r.Name)
+			continue
+		case response.RuleStatusError:
+			ruleErrors = append(ruleErrors, fmt.Sprintf("rule %s: %s", r.Name, r.Message))
+			continue
+		}
+
+		// skipped rules fail when the rule matched the old resource only
 		if !r.Success {
 			logger.V(4).Info("querying all generate requests")
 			selector := labels.SelectorFromSet(labels.Set(map[string]string{
@@ -169,13 +181,52 @@ func (c *Controller) applyGenerate(resource unstructured.Unstructured, gr kyvern
 					logger.Error(err, "failed to delete generate request")
 				}
 			}
-		} else {
-			applicableRules = append(applicableRules, r.Name)
 		}
 	}
 
+	if len(applicableRules) == 0 && len(ruleErrors) == 0 {
+		c.reportResults(engineResponse, logger)
+		logger.V(4).Info(doesNotApply)
+		return nil, errors.New(doesNotApply)
+	}
+
 	// Apply the generate rule on resource
-	return c.applyGeneratePolicy(logger, policyContext, gr, applicableRules)
+	genResources, err := c.applyGeneratePolicy(logger, policyContext, gr, applicableRules, engineResponse)
+	c.reportResults(engineResponse, logger)
+	if err == nil && len(ruleErrors) > 0 {
+		err = fmt.Errorf("failed to apply generate rules: %s", strings.Join(ruleErrors, "; "))
+	}
+	return genResources, err
+}
+
+// reportResults records the outcome of the generate rules that were skipped or processed
+func (c *Controller) reportResults(engineResponse *response.EngineResponse, logger logr.Logger) {
+	if c.prGenerator == nil {
+		return
+	}
+
+	var rules []response.RuleResponse
+	for _, r := range engineResponse.PolicyResponse.Rules {
+		if r.Status != "" {
+			rules = append(rules, r)
+		}
+	}
+
+	engineResponse.PolicyResponse.Rules = rules
+	prInfos := policyreport.GeneratePRsFromEngineResponse([]*response.EngineResponse{engineResponse}, logger)
+	c.prGenerator.Add(prInfos...)
+}
+
+// setRuleStatus updates the status and message of the rule in the engine response
+func setRuleStatus(engineResponse *response.EngineResponse, ruleName string, status response.RuleStatus, message string) {
+	for i := range engineResponse.PolicyResponse.Rules {
+		if engineResponse.PolicyResponse.Rules[i].Name == ruleName {
+			engineResponse.PolicyResponse.Rules[i].Status = status
+			engineResponse.PolicyResponse.Rules[i].Success = status == response.RuleStatusPass
+			engineResponse.PolicyResponse.Rules[i].Message = message
+			return
+		}
+	}
 }
 
 func updateStatus(statusControl StatusControlInterface, gr kyverno.GenerateRequest, err error, genResources []kyverno.ResourceSpec) error {
@@ -187,7 +238,7 @@ func updateStatus(statusControl StatusControlInterface, gr kyverno.GenerateReque
 	return statusControl.Success(gr, genResources)
 }
 
-func (c *Controller) applyGeneratePolicy(log logr.Logger, policyContext *engine.PolicyContext, gr kyverno.GenerateRequest, applicableRules []string) (genResources []kyverno.ResourceSpec, err error) {
+func (c *Controller) applyGeneratePolicy(log logr.Logger, policyContext *engine.PolicyContext, gr kyverno.GenerateRequest, applicableRules []string, engineResponse *response.EngineResponse) (genResources []kyverno.ResourceSpec, err error) {
 	// Get the response as the actions to be performed on the resource
 	// - - substitute values
 	policy := policyContext.Policy
@@ -223,11 +274,13 @@ func (c *Controller) applyGeneratePolicy(log logr.Logger, policyContext *engine.
 		// add configmap json data to context
 		if err := engine.LoadContext(log, rule.Context, resCache, policyContext, rule.Name); err != nil {
 			log.Error(err, "cannot add configmaps to context")
+			setRuleStatus(engineResponse, rule.Name, response.RuleStatusError, fmt.Sprintf("failed to load context: %v", err))
 			return nil, err
 		}
 
 		if rule, err = variables.SubstituteAllInRule(log, policyContext.JSONContext, rule); err != nil {
 			log.Error(err, "variable substitution failed for rule %s", rule.Name)
+			setRuleStatus(engineResponse, rule.Name, response.RuleStatusError, fmt.Sprintf("variable substitution failed: %v", err))
 			return nil, err
 		}
 
@@ -236,8 +289,11 @@ func (c *Controller) applyGeneratePolicy(log logr.Logger, policyContext *engine.
 			if err != nil {
 				log.Error(err, "failed to apply generate rule", "policy", policy.Name,
 					"rule", rule.Name, "resource", resource.GetName(), "suggestion", "users need to grant Kyverno's service account additional privileges")
+				setRuleStatus(engineResponse, rule.Name, response.RuleStatusError, fmt.Sprintf("failed to generate resource: %v", err))
 				return nil, err
 			}
+
+			setRuleStatus(engineResponse, rule.Name, response.RuleStatusPass, fmt.Sprintf("created %s/%s", genResource.Kind, genResource.Name))
 			ruleNameToProcessingTime[rule.Name] = time.Since(startTime)
 			genResources = append(genResources, genResource)
 		}
@@ -317,36 +373,14 @@ func applyRule(log logr.Logger, client *dclient.Client, rule kyverno.Rule, resou
 	var err error
 	var mode ResourceMode
 	var noGenResource kyverno.ResourceSpec
-	genUnst, err := getUnstrRule(rule.Generation.DeepCopy())
-	if err != nil {
-		return noGenResource, err
-	}
-
-	genKind, genName, genNamespace, genAPIVersion, err := getResourceInfo(genUnst.Object)
+	newGenResource, genData, genClone, err := ParseGeneration(rule)
 	if err != nil {
 		return noGenResource, err
 	}
 
+	genKind, genName, genNamespace, genAPIVersion := newGenResource.Kind, newGenResource.Name, newGenResource.Namespace, newGenResource.APIVersion
 	logger := log.WithValues("genKind", genKind, "genAPIVersion", genAPIVersion, "genNamespace", genNamespace, "genName", genName)
 
-	// Resource to be generated
-	newGenResource := kyverno.ResourceSpec{
-		APIVersion: genAPIVersion,
-		Kind:       genKind,
-		Namespace:  genNamespace,
-		Name:       genName,
-	}
-
-	genData, _, err := unstructured.NestedMap(genUnst.Object, "data")
-	if err != nil {
-		return noGenResource, fmt.Errorf("failed to read `data`: %v", err.Error())
-	}
-
-	genClone, _, err := unstructured.NestedMap(genUnst.Object, "clone")
-	if err != nil {
-		return noGenResource, fmt.Errorf("failed to read `clone`: %v", err.Error())
-	}
-
 	if genClone != nil && len(genClone) != 0 {
 		rdata, mode, err = manageClone(logger, genAPIVersion, genKind, genNamespace, genName, policy, genClone, client)
 	} else {
@@ -366,36 +400,10 @@ func applyRule(log logr.Logger, client *dclient.Client, rule kyverno.Rule, resou
 	}
 
 	// build the resource template
-	newResource := &unstructured.Unstructured{}
-	newResource.SetUnstructuredContent(rdata)
-	newResource.SetName(genName)
-	newResource.SetNamespace(genNamespace)
-	if newResource.GetKind() == "" {
-		newResource.SetKind(genKind)
-	}
-
-	newResource.SetAPIVersion(genAPIVersion)
-	// manage labels
-	// - app.kubernetes.io/managed-by: kyverno
-	// "kyverno.io/generated-by-kind": kind (trigger resource)
-	// "kyverno.io/generated-by-namespace": namespace (trigger resource)
-	// "kyverno.io/generated-by-name": name (trigger resource)
-	manageLabels(newResource, resource)
-	// Add Synchronize label
-	label := newResource.GetLabels()
-	label["policy.kyverno.io/policy-name"] = policy
-	label["policy.kyverno.io/gr-name"] = gr.Name
-	delete(label, "generate.kyverno.io/clone-policy-name")
+	newResource := NewGeneratedResource(rdata, newGenResource, resource, policy, gr.Name, rule.Generation.Synchronize)
 	if mode == Create {
-		if rule.Generation.Synchronize {
-			label["policy.kyverno.io/synchronize"] = "enable"
-		} else {
-			label["policy.kyverno.io/synchronize"] = "disable"
-		}
-
 		// Reset resource version
 		newResource.SetResourceVersion("")
-		newResource.SetLabels(label)
 		// Create the resource
 		_, err = client.CreateResource(genAPIVersion, genKind, genNamespace, newResource, false)
 		if err != nil {
@@ -405,14 +413,7 @@ func applyRule(log logr.Logger, client *dclient.Client, rule kyverno.Rule, resou
 		logger.V(2).Info("created generate target resource")
 
 	} else if mode == Update {
-		if rule.Generation.Synchronize {
-			label["policy.kyverno.io/synchronize"] = "enable"
-		} else {
-			label["policy.kyverno.io/synchronize"] = "disable"
-		}
-
 		logger.V(4).Info("updating label in existing resource")
-		newResource.SetLabels(label)
 		_, err := client.UpdateResource(genAPIVersion, genKind, genNamespace, newResource, false)
 		if err != nil {
 			logger.Error(err, "failed to update resource")
@@ -424,6 +425,67 @@ func applyRule(log logr.Logger, client *dclient.Client, rule kyverno.Rule, resou
 	return newGenResource, nil
 }
 
+// ParseGeneration returns the resource generated by the rule, and its data or the clone source
+func ParseGeneration(rule kyverno.Rule) (genResource kyverno.ResourceSpec, data, clone map[string]interface{}, err error) {
+	genUnst, err := getUnstrRule(rule.Generation.DeepCopy())
+	if err != nil {
+		return genResource, nil, nil, err
+	}
+
+	genResource.Kind, genResource.Name, genResource.Namespace, genResource.APIVersion, err = getResourceInfo(genUnst.Object)
+	if err != nil {
+		return genResource, nil, nil, err
+	}
+
+	data, _, err = unstructured.NestedMap(genUnst.Object, "data")
+	if err != nil {
+		return genResource, nil, nil, fmt.Errorf("failed to read `data`: %v", err.Error())
+	}
+
+	clone, _, err = unstructured.NestedMap(genUnst.Object, "clone")
+	if err != nil {
+		return genResource, nil, nil, fmt.Errorf("failed to read `clone`: %v", err.Error())
+	}
+	return genResource, data, clone, nil
+}
+
+// NewGeneratedResource builds the resource generated for the trigger resource from the rule data or the clone source content,
+// it is labeled as managed by kyverno, generated by the trigger resource and owned by the policy and the generate request
+func NewGeneratedResource(content map[string]interface{}, genResource kyverno.ResourceSpec, trigger unstructured.Unstructured, policy, grName string, synchronize bool) *unstructured.Unstructured {
+	newResource := &unstructured.Unstructured{}
+	newResource.SetUnstructuredContent(content)
+	newResource.SetName(genResource.Name)
+	newResource.SetNamespace(genResource.Namespace)
+	if newResource.GetKind() == "" {
+		newResource.SetKind(genResource.Kind)
+	}
+
+	newResource.SetAPIVersion(genResource.APIVersion)
+	// manage labels
+	// - app.kubernetes.io/managed-by: kyverno
+	// "kyverno.io/generated-by-kind": kind (trigger resource)
+	// "kyverno.io/generated-by-namespace": namespace (trigger resource)
+	// "kyverno.io/generated-by-name": name (trigger resource)
+	manageLabels(newResource, trigger)
+	// Add Synchronize label
+	label := newResource.GetLabels()
+	label["policy.kyverno.io/policy-name"] = policy
+	// the resources generated by the CLI have no generate request
+	if grName != "" {
+		label["policy.kyverno.io/gr-name"] = grName
+	}
+
+	delete(label, "generate.kyverno.io/clone-policy-name")
+	if synchronize {
+		label["policy.kyverno.io/synchronize"] = "enable"
+	} else {
+		label["policy.kyverno.io/synchronize"] = "disable"
+	}
+
+	newResource.SetLabels(label)
+	return newResource
+}
+
 func manageData(log logr.Logger, apiVersion, kind, namespace, name string, data map[string]interface{}, client *dclient.Client) (map[string]interface{}, ResourceMode, error) {
 	obj, err := client.GetResource(apiVersion, kind, namespace, name)
 	if err != nil {
@@ -14,6 +14,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	dclient "github.com/kyverno/kyverno/pkg/dclient"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/policyreport"
 	"github.com/kyverno/kyverno/pkg/policystatus"
 	"github.com/kyverno/kyverno/pkg/resourcecache"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -43,6 +44,9 @@ type Controller struct {
 	// event generator interface
 	eventGen event.Interface
 
+	// prGenerator records the generate rule results in policy reports
+	prGenerator policyreport.GeneratorInterface
+
 	// grStatusControl is used to update GR status
 	statusControl StatusControlInterface
 
@@ -82,6 +86,7 @@ func NewController(
 	policyInformer kyvernoinformer.ClusterPolicyInformer,
 	grInformer kyvernoinformer.GenerateRequestInformer,
 	eventGen event.Interface,
+	prGenerator policyreport.GeneratorInterface,
 	dynamicInformer dynamicinformer.DynamicSharedInformerFactory,
 	policyStatus policystatus.Listener,
 	log logr.Logger,
@@ -94,6 +99,7 @@ func NewController(
 		kyvernoClient:        kyvernoClient,
 		policyInformer:       policyInformer,
 		eventGen:             eventGen,
+		prGenerator:          prGenerator,
 		queue:                workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "generate-request"),
 		dynamicInformer:      dynamicInformer,
 		log:                  log,
@@ -0,0 +1,46 @@
+package generate
+
+import (
+	"testing"
+
+	kyverno "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_NewGeneratedResource(t *testing.T) {
+	trigger := unstructured.Unstructured{}
+	trigger.SetKind("Namespace")
+	trigger.SetName("team-a")
+
+	content := map[string]interface{}{
+		"data": map[string]interface{}{"owner": "alice"},
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{
+				"app.kubernetes.io/managed-by":          "helm",
+				"generate.kyverno.io/clone-policy-name": "add-defaults",
+			},
+		},
+	}
+	genResource := kyverno.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Namespace: "team-a", Name: "defaults"}
+
+	resource := NewGeneratedResource(content, genResource, trigger, "add-defaults", "gr-abcde", true)
+	assert.Equal(t, resource.GetAPIVersion(), "v1")
+	assert.Equal(t, resource.GetKind(), "ConfigMap")
+	assert.Equal(t, resource.GetNamespace(), "team-a")
+	assert.Equal(t, resource.GetName(), "defaults")
+	assert.DeepEqual(t, resource.GetLabels(), map[string]string{
+		"app.kubernetes.io/managed-by":      "helm",
+		"kyverno.io/generated-by-kind":      "Namespace",
+		"kyverno.io/generated-by-namespace": "",
+		"kyverno.io/generated-by-name":      "team-a",
+		"policy.kyverno.io/policy-name":     "add-defaults",
+		"policy.kyverno.io/gr-name":         "gr-abcde",
+		"policy.kyverno.io/synchronize":     "enable",
+	})
+
+	resource = NewGeneratedResource(map[string]interface{}{}, genResource, trigger, "add-defaults", "", false)
+	_, ok := resource.GetLabels()["policy.kyverno.io/gr-name"]
+	assert.Assert(t, !ok)
+	assert.Equal(t, resource.GetLabels()["policy.kyverno.io/synchronize"], "disable")
+}
@@ -15,6 +15,7 @@ func failedEvents(err error, gr kyverno.GenerateRequest, resource unstructured.U
 	re.Name = resource.GetName()
 	re.Reason = event.PolicyFailed.String()
 	re.Source = event.GeneratePolicyController
+	re.Policy = gr.Spec.Policy
 	re.Message = fmt.Sprintf("policy %s failed to apply: %v", gr.Spec.Policy, err)
 
 	return []event.Info{re}
@@ -2,6 +2,7 @@ package apply
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -18,6 +19,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/kyverno/store"
 	"github.com/kyverno/kyverno/pkg/openapi"
 	policy2 "github.com/kyverno/kyverno/pkg/policy"
+	"github.com/kyverno/kyverno/pkg/utils"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -60,6 +62,26 @@ To apply on a resource:
 To apply on a cluster:
 	kyverno apply /path/to/policy.yaml /path/to/folderOfPolicies --cluster
 
+To print the results in a machine-readable format (json, junit or sarif):
+	kyverno apply /path/to/policy.yaml --resource /path/to/resource.yaml --output-format sarif
+
+To apply policies with the user info, roles and operation of an admission request:
+	kyverno apply /path/to/policy.yaml --resource /path/to/resource.yaml --userinfo /path/to/user_info.yaml
+
+	Format of user_info.yaml:
+
+	operation: CREATE
+	roles:
+	- <namespace>:<role name>
+	clusterRoles:
+	- <cluster role name>
+	userInfo:
+		username: <user name>
+		groups:
+		- <group name>
+
+To only fail on validation failures of policies in enforce mode:
+	kyverno apply /path/to/policy.yaml --resource /path/to/resource.yaml --fail-on enforce
 
 To apply policy with variables:
 
@@ -101,6 +123,12 @@ To apply policy with variables:
 			- name: <namespace2 name>
 			labels:
 				<label key>: <label value>
+		clusterResources:
+			- <file or directory of the resources served as the cluster state>
+
+		The ConfigMaps of context entries and the resources of API calls are read from the cluster resources,
+		the labels of their Namespaces are used when no namespaceSelector is given for the namespace.
+		The CustomResourceDefinitions of the cluster resources declare the scope of the custom kinds.
 
 More info: https://kyverno.io/docs/kyverno-cli/
 `
@@ -109,7 +137,7 @@ func Command() *cobra.Command {
 	var cmd *cobra.Command
 	var resourcePaths []string
 	var cluster, policyReport, stdin bool
-	var mutateLogPath, variablesString, valuesFile, namespace string
+	var mutateLogPath, variablesString, valuesFile, namespace, outputFormat, failOn, userInfoPath string
 
 	cmd = &cobra.Command{
 		Use:     "apply",
@@ -125,12 +153,43 @@ func Command() *cobra.Command {
 				}
 			}()
 
-			validateEngineResponses, rc, resources, skippedPolicies, err := applyCommandHelper(resourcePaths, cluster, policyReport, mutateLogPath, variablesString, valuesFile, namespace, policyPaths, stdin)
+			if !utils.ContainsString(outputFormats, outputFormat) {
+				return sanitizederror.NewWithError(fmt.Sprintf("invalid output format %s, supported formats are %s", outputFormat, strings.Join(outputFormats, ", ")), nil)
+			}
+
+			if !utils.ContainsString(failOnPolicies, failOn) {
+				return sanitizederror.NewWithError(fmt.Sprintf("invalid fail-on value %s, supported values are %s", failOn, strings.Join(failOnPolicies, ", ")), nil)
+			}
+
+			if policyReport && outputFormat != outputFormatText {
+				return sanitizederror.NewWithError("the policy-report flag can only be used with the text output format", nil)
+			}
+
+			// messages printed while applying the policies go to stderr to keep the machine-readable output parsable
+			out := cmd.OutOrStdout()
+			if outputFormat != outputFormatText {
+				out = cmd.ErrOrStderr()
+			}
+
+			validateEngineResponses, engineResponses, rc, resources, skippedPolicies, err := applyCommandHelper(out, resourcePaths, cluster, policyReport, mutateLogPath, variablesString, valuesFile, namespace, policyPaths, stdin, userInfoPath)
 			if err != nil {
 				return err
 			}
 
-			printReportOrViolation(policyReport, validateEngineResponses, rc, resourcePaths, len(resources), skippedPolicies, stdin)
+			if outputFormat == outputFormatText {
+				printReportOrViolation(out, policyReport, validateEngineResponses, rc, resourcePaths, len(resources), skippedPolicies, stdin)
+			} else if err := printOutput(cmd.OutOrStdout(), outputFormat, engineResponses, rc, skippedPolicies, resourcePaths); err != nil {
+				return sanitizederror.NewWithError("failed to print the results", err)
+			}
+
+			// policy reports only fail the command when a fail-on policy is explicitly requested
+			if policyReport && !cmd.Flags().Changed("fail-on") {
+				return nil
+			}
+
+			if shouldFail(failOn, rc, engineResponses) {
+				os.Exit(1)
+			}
 			return nil
 		},
 	}
@@ -143,69 +202,89 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVarP(&policyReport, "policy-report", "", false, "Generates policy report when passed (default policyviolation r")
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Optional Policy parameter passed with cluster flag")
 	cmd.Flags().BoolVarP(&stdin, "stdin", "i", false, "Optional mutate policy parameter to pipe directly through to kubectl")
+	cmd.Flags().StringVarP(&userInfoPath, "userinfo", "u", "", "Admission request information file with the user info, roles, cluster roles and operation")
+	cmd.Flags().StringVar(&outputFormat, "output-format", outputFormatText, "Output format of the results, one of: text, json, junit, sarif")
+	cmd.Flags().StringVar(&failOn, "fail-on", failOnAny, "Results that fail the command, one of: any (any rule failure or error), enforce (validate failures with the enforce action), never")
 	return cmd
 }
 
-func applyCommandHelper(resourcePaths []string, cluster bool, policyReport bool, mutateLogPath string,
-	variablesString string, valuesFile string, namespace string, policyPaths []string, stdin bool) (validateEngineResponses []*response.EngineResponse, rc *resultCounts, resources []*unstructured.Unstructured, skippedPolicies []SkippedPolicy, err error) {
+func applyCommandHelper(out io.Writer, resourcePaths []string, cluster bool, policyReport bool, mutateLogPath string,
+	variablesString string, valuesFile string, namespace string, policyPaths []string, stdin bool, userInfoPath string) (validateEngineResponses []*response.EngineResponse, engineResponses []*response.EngineResponse, rc *resultCounts, resources []*unstructured.Unstructured, skippedPolicies []SkippedPolicy, err error) {
 
 	store.SetMock(true)
 	kubernetesConfig := genericclioptions.NewConfigFlags(true)
 	fs := memfs.New()
 
 	if valuesFile != "" && variablesString != "" {
-		return validateEngineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError("pass the values either using set flag or values_file flag", err)
+		return validateEngineResponses, engineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError("pass the values either using set flag or values_file flag", err)
 	}
 
-	variables, valuesMap, namespaceSelectorMap, err := common.GetVariable(variablesString, valuesFile, fs, false, "")
+	variables, valuesMap, namespaceSelectorMap, clusterResources, err := common.GetVariable(variablesString, valuesFile, fs, false, "")
 	if err != nil {
 		if !sanitizederror.IsErrorSanitized(err) {
-			return validateEngineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError("failed to decode yaml", err)
+			return validateEngineResponses, engineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError("failed to decode yaml", err)
+		}
+		return validateEngineResponses, engineResponses, rc, resources, skippedPolicies, err
+	}
+
+	var userInfo *common.UserInfo
+	if userInfoPath != "" {
+		userInfo, err = common.GetUserInfoFromPath(fs, userInfoPath, false, "")
+		if err != nil {
+			return validateEngineResponses, engineResponses, rc, resources, skippedPolicies, err
 		}
-		return validateEngineResponses, rc, resources, skippedPolicies, err
 	}
 
 	openAPIController, err := openapi.NewOpenAPIController()
 	if err != nil {
-		return validateEngineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError("failed to initialize openAPIController", err)
+		return validateEngineResponses, engineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError("failed to initialize openAPIController", err)
+	}
+
+	// the context entries are loaded from the cluster resources of the values file
+	var fixtureClient *client.Client
+	if len(clusterResources) > 0 {
+		fixtureClient, err = common.NewFixtureClient(clusterResources)
+		if err != nil {
+			return validateEngineResponses, engineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError("failed to serve the cluster resources", err)
+		}
 	}
 
 	var dClient *client.Client
 	if cluster {
 		restConfig, err := kubernetesConfig.ToRESTConfig()
 		if err != nil {
-			return validateEngineResponses, rc, resources, skippedPolicies, err
+			return validateEngineResponses, engineResponses, rc, resources, skippedPolicies, err
 		}
 		dClient, err = client.NewClient(restConfig, 15*time.Minute, make(chan struct{}), log.Log)
 		if err != nil {
-			return validateEngineResponses, rc, resources, skippedPolicies, err
+			return validateEngineResponses, engineResponses, rc, resources, skippedPolicies, err
 		}
 	}
 
 	if len(policyPaths) == 0 {
-		return validateEngineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError(fmt.Sprintf("require policy"), err)
+		return validateEngineResponses, engineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError(fmt.Sprintf("require policy"), err)
 	}
 
 	if (len(policyPaths) > 0 && policyPaths[0] == "-") && len(resourcePaths) > 0 && resourcePaths[0] == "-" {
-		return validateEngineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError("a stdin pipe can be used for either policies or resources, not both", err)
+		return validateEngineResponses, engineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError("a stdin pipe can be used for either policies or resources, not both", err)
 	}
 
 	policies, err := common.GetPoliciesFromPaths(fs, policyPaths, false, "")
 	if err != nil {
-		fmt.Printf("Error: failed to load policies\nCause: %s\n", err)
+		fmt.Fprintf(out, "Error: failed to load policies\nCause: %s\n", err)
 		os.Exit(1)
 	}
 
 	if len(resourcePaths) == 0 && !cluster {
-		return validateEngineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError(fmt.Sprintf("resource file(s) or cluster required"), err)
+		return validateEngineResponses, engineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError(fmt.Sprintf("resource file(s) or cluster required"), err)
 	}
 
 	mutateLogPathIsDir, err := checkMutateLogPath(mutateLogPath)
 	if err != nil {
 		if !sanitizederror.IsErrorSanitized(err) {
-			return validateEngineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError("failed to create file/folder", err)
+			return validateEngineResponses, engineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError("failed to create file/folder", err)
 		}
-		return validateEngineResponses, rc, resources, skippedPolicies, err
+		return validateEngineResponses, engineResponses, rc, resources, skippedPolicies, err
 	}
 
 	// empty the previous contents of the file just in case if the file already existed before with some content(so as to perform overwrites)
@@ -214,22 +293,22 @@ func applyCommandHelper(resourcePaths []string, cluster bool, policyReport bool,
 		_, err := os.OpenFile(mutateLogPath, os.O_TRUNC|os.O_WRONLY, 0644)
 		if err != nil {
 			if !sanitizederror.IsErrorSanitized(err) {
-				return validateEngineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError("failed to truncate the existing file at "+mutateLogPath, err)
+				return validateEngineResponses, engineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError("failed to truncate the existing file at "+mutateLogPath, err)
 			}
-			return validateEngineResponses, rc, resources, skippedPolicies, err
+			return validateEngineResponses, engineResponses, rc, resources, skippedPolicies, err
 		}
 	}
 
 	mutatedPolicies, err := common.MutatePolices(policies)
 	if err != nil {
 		if !sanitizederror.IsErrorSanitized(err) {
-			return validateEngineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError("failed to mutate policy", err)
+			return validateEngineResponses, engineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError("failed to mutate policy", err)
 		}
 	}
 
-	resources, err = common.GetResourceAccordingToResourcePath(fs, resourcePaths, cluster, mutatedPolicies, dClient, namespace, policyReport, false, "")
+	resources, err = common.GetResourceAccordingToResourcePath(fs, resourcePaths, cluster, mutatedPolicies, dClient, namespace, policyReport, false, "", out)
 	if err != nil {
-		fmt.Printf("Error: failed to load resources\nCause: %s\n", err)
+		fmt.Fprintf(out, "Error: failed to load resources\nCause: %s\n", err)
 		os.Exit(1)
 	}
 
@@ -245,12 +324,12 @@ func applyCommandHelper(resourcePaths []string, cluster bool, policyReport bool,
 
 	if len(mutatedPolicies) > 0 && len(resources) > 0 {
 		if !stdin {
-			fmt.Printf("\napplying %s to %s... \n", msgPolicies, msgResources)
+			fmt.Fprintf(out, "\napplying %s to %s... \n", msgPolicies, msgResources)
 		}
 	}
 
 	rc = &resultCounts{}
-	engineResponses := make([]*response.EngineResponse, 0)
+	engineResponses = make([]*response.EngineResponse, 0)
 	validateEngineResponses = make([]*response.EngineResponse, 0)
 	skippedPolicies = make([]SkippedPolicy, 0)
 
@@ -263,6 +342,9 @@ func applyCommandHelper(resourcePaths []string, cluster bool, policyReport bool,
 		}
 
 		matches := common.PolicyHasVariables(*policy)
+		if userInfo != nil {
+			matches = common.RemoveUserInfoVariables(matches)
+		}
 		variable := common.RemoveDuplicateVariables(matches)
 
 		if len(matches) > 0 && variablesString == "" && valuesFile == "" {
@@ -288,13 +370,13 @@ func applyCommandHelper(resourcePaths []string, cluster bool, policyReport bool,
 				thisPolicyResourceValues[k] = v
 			}
 
-			if len(common.PolicyHasVariables(*policy)) > 0 && len(thisPolicyResourceValues) == 0 && len(store.GetContext().Policies) == 0 {
-				return validateEngineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError(fmt.Sprintf("policy %s have variables. pass the values for the variables using set/values_file flag", policy.Name), err)
+			if len(matches) > 0 && len(thisPolicyResourceValues) == 0 && len(store.GetContext().Policies) == 0 && fixtureClient == nil {
+				return validateEngineResponses, engineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError(fmt.Sprintf("policy %s have variables. pass the values for the variables using set/values_file flag", policy.Name), err)
 			}
 
-			ers, validateErs, responseError, rcErs, err := common.ApplyPolicyOnResource(policy, resource, mutateLogPath, mutateLogPathIsDir, thisPolicyResourceValues, policyReport, namespaceSelectorMap, stdin)
+			ers, validateErs, responseError, rcErs, err := common.ApplyPolicyOnResource(policy, resource, mutateLogPath, mutateLogPathIsDir, thisPolicyResourceValues, policyReport, namespaceSelectorMap, stdin, userInfo, fixtureClient, out)
 			if err != nil {
-				return validateEngineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError(fmt.Errorf("failed to apply policy %v on resource %v", policy.Name, resource.GetName()).Error(), err)
+				return validateEngineResponses, engineResponses, rc, resources, skippedPolicies, sanitizederror.NewWithError(fmt.Errorf("failed to apply policy %v on resource %v", policy.Name, resource.GetName()).Error(), err)
 			}
 			if responseError == true {
 				rc.fail++
@@ -305,11 +387,12 @@ func applyCommandHelper(resourcePaths []string, cluster bool, policyReport bool,
 				rc.error++
 			}
 			engineResponses = append(engineResponses, ers...)
+			engineResponses = append(engineResponses, validateErs)
 			validateEngineResponses = append(validateEngineResponses, validateErs)
 		}
 	}
 
-	return validateEngineResponses, rc, resources, skippedPolicies, nil
+	return validateEngineResponses, engineResponses, rc, resources, skippedPolicies, nil
 }
 
 // checkMutateLogPath - checking path for printing mutated resource (-o flag)
@@ -334,18 +417,18 @@ func checkMutateLogPath(mutateLogPath string) (mutateLogPathIsDir bool, err erro
 	return mutateLogPathIsDir, err
 }
 
-// printReportOrViolation - printing policy report/violations
-func printReportOrViolation(policyReport bool, validateEngineResponses []*response.EngineResponse, rc *resultCounts, resourcePaths []string, resourcesLen int, skippedPolicies []SkippedPolicy, stdin bool) {
+// printReportOrViolation - printing policy report/violations to out
+func printReportOrViolation(out io.Writer, policyReport bool, validateEngineResponses []*response.EngineResponse, rc *resultCounts, resourcePaths []string, resourcesLen int, skippedPolicies []SkippedPolicy, stdin bool) {
 	if policyReport {
 		os.Setenv("POLICY-TYPE", pkgCommon.PolicyReport)
 		resps := buildPolicyReports(validateEngineResponses, skippedPolicies)
 		if len(resps) > 0 || resourcesLen == 0 {
-			fmt.Println("----------------------------------------------------------------------\nPOLICY REPORT:\n----------------------------------------------------------------------")
+			fmt.Fprintln(out, "----------------------------------------------------------------------\nPOLICY REPORT:\n----------------------------------------------------------------------")
 			report, _ := generateCLIRaw(resps)
 			yamlReport, _ := yaml1.Marshal(report)
-			fmt.Println(string(yamlReport))
+			fmt.Fprintln(out, string(yamlReport))
 		} else {
-			fmt.Println("----------------------------------------------------------------------\nPOLICY REPORT: skip generating policy report (no validate policy found/resource skipped)")
+			fmt.Fprintln(out, "----------------------------------------------------------------------\nPOLICY REPORT: skip generating policy report (no validate policy found/resource skipped)")
 		}
 	} else {
 		rcCount := rc.pass + rc.fail + rc.warn + rc.error + rc.skip
@@ -353,13 +436,9 @@ func printReportOrViolation(policyReport bool, validateEngineResponses []*respon
 			rc.skip += len(resourcePaths) - rcCount
 		}
 		if !stdin {
-			fmt.Printf("\npass: %d, fail: %d, warn: %d, error: %d, skip: %d \n",
+			fmt.Fprintf(out, "\npass: %d, fail: %d, warn: %d, error: %d, skip: %d \n",
 				rc.pass, rc.fail, rc.warn, rc.error, rc.skip)
 		}
-
-		if rc.fail > 0 || rc.error > 0 {
-			os.Exit(1)
-		}
 	}
 }
 
@@ -1,9 +1,13 @@
 package apply
 
 import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
 	"testing"
 
-	preport "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha1"
+	preport "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha2"
 	"gotest.tools/assert"
 )
 
@@ -56,10 +60,25 @@ func Test_Apply(t *testing.T) {
 	}
 
 	for _, tc := range testcases {
-		validateEngineResponses, _, _, skippedPolicies, _ := applyCommandHelper(tc.ResourcePaths, false, true, "", "", "", "", tc.PolicyPaths, false)
+		validateEngineResponses, _, _, _, skippedPolicies, _ := applyCommandHelper(ioutil.Discard, tc.ResourcePaths, false, true, "", "", "", "", tc.PolicyPaths, false, "")
 		resps := buildPolicyReports(validateEngineResponses, skippedPolicies)
 		for i, resp := range resps {
 			compareSummary(tc.expectedPolicyReports[i].Summary, resp.UnstructuredContent()["summary"].(map[string]interface{}))
 		}
 	}
 }
+
+func Test_Apply_outputStreams(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cmd := Command()
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"../../../test/best_practices/disallow_latest_tag.yaml",
+		"--resource", "../../../test/resources/pod_with_version_tag.yaml", "--output-format", "json"})
+	assert.NilError(t, cmd.Execute())
+
+	// the messages printed while applying the policies do not break the machine-readable output
+	var output jsonOutput
+	assert.NilError(t, json.Unmarshal(stdout.Bytes(), &output))
+	assert.Assert(t, strings.Contains(stderr.String(), "applying 1 policy to 1 resource"))
+}
@@ -0,0 +1,492 @@
+package apply
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	"github.com/kyverno/kyverno/pkg/kyverno/common"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	yaml1 "sigs.k8s.io/yaml"
+)
+
+const (
+	outputFormatText  = "text"
+	outputFormatJSON  = "json"
+	outputFormatJUnit = "junit"
+	outputFormatSARIF = "sarif"
+)
+
+const (
+	// failOnAny fails the command when any rule fails or errors
+	failOnAny = "any"
+	// failOnEnforce fails the command only when a validate rule with the enforce action fails
+	failOnEnforce = "enforce"
+	// failOnNever never fails the command because of rule results
+	failOnNever = "never"
+)
+
+var outputFormats = []string{outputFormatText, outputFormatJSON, outputFormatJUnit, outputFormatSARIF}
+
+var failOnPolicies = []string{failOnAny, failOnEnforce, failOnNever}
+
+// shouldFail checks if the rule results require a non-zero exit code according to the fail-on policy
+func shouldFail(failOn string, rc *resultCounts, engineResponses []*response.EngineResponse) bool {
+	switch failOn {
+	case failOnNever:
+		return false
+	case failOnEnforce:
+		for _, er := range engineResponses {
+			for _, rule := range er.PolicyResponse.Rules {
+				if rule.Type == engineutils.Validation.String() && rule.GetStatus() == response.RuleStatusFail && strings.EqualFold(rule.ValidationFailureAction, "enforce") {
+					return true
+				}
+			}
+		}
+		return false
+	default:
+		if rc != nil && (rc.fail > 0 || rc.error > 0) {
+			return true
+		}
+
+		for _, er := range engineResponses {
+			for _, rule := range er.PolicyResponse.Rules {
+				if status := rule.GetStatus(); status == response.RuleStatusFail || status == response.RuleStatusError {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// printOutput writes the engine responses to out in the given machine-readable format
+func printOutput(out io.Writer, format string, engineResponses []*response.EngineResponse, rc *resultCounts, skippedPolicies []SkippedPolicy, resourcePaths []string) error {
+	var data []byte
+	var err error
+
+	switch format {
+	case outputFormatJSON:
+		data, err = json.MarshalIndent(buildJSONOutput(engineResponses, rc, skippedPolicies), "", "  ")
+	case outputFormatJUnit:
+		data, err = xml.MarshalIndent(buildJUnitOutput(engineResponses, skippedPolicies), "", "  ")
+		if err == nil {
+			data = append([]byte(xml.Header), data...)
+		}
+	case outputFormatSARIF:
+		data, err = json.MarshalIndent(buildSARIFOutput(engineResponses, resourceLocations(resourcePaths)), "", "  ")
+	default:
+		return fmt.Errorf("unsupported output format %s", format)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(out, string(data))
+	return err
+}
+
+// withRules returns the engine responses that contain at least one rule response
+func withRules(engineResponses []*response.EngineResponse) []*response.EngineResponse {
+	var resps []*response.EngineResponse
+	for _, er := range engineResponses {
+		if er != nil && len(er.PolicyResponse.Rules) > 0 {
+			resps = append(resps, er)
+		}
+	}
+	return resps
+}
+
+func resourceKey(resource response.ResourceSpec) string {
+	if resource.Namespace == "" {
+		return fmt.Sprintf("%s/%s", resource.Kind, resource.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s", resource.Kind, resource.Namespace, resource.Name)
+}
+
+type jsonOutput struct {
+	Results         []jsonResult `json:"results"`
+	SkippedPolicies []string     `json:"skippedPolicies,omitempty"`
+	Summary         jsonSummary  `json:"summary"`
+}
+
+type jsonSummary struct {
+	Pass  int `json:"pass"`
+	Fail  int `json:"fail"`
+	Warn  int `json:"warn"`
+	Error int `json:"error"`
+	Skip  int `json:"skip"`
+}
+
+type jsonResult struct {
+	Policy                  string                     `json:"policy"`
+	Resource                response.ResourceSpec      `json:"resource"`
+	ValidationFailureAction string                     `json:"validationFailureAction,omitempty"`
+	Rules                   []jsonRuleResult           `json:"rules"`
+	PatchedResource         *unstructured.Unstructured `json:"patchedResource,omitempty"`
+}
+
+type jsonRuleResult struct {
+	Name                    string            `json:"name"`
+	Type                    string            `json:"type"`
+	Status                  string            `json:"status"`
+	Message                 string            `json:"message,omitempty"`
+	ValidationFailureAction string            `json:"validationFailureAction,omitempty"`
+	Patches                 []json.RawMessage `json:"patches,omitempty"`
+}
+
+func buildJSONOutput(engineResponses []*response.EngineResponse, rc *resultCounts, skippedPolicies []SkippedPolicy) jsonOutput {
+	output := jsonOutput{Results: []jsonResult{}}
+	if rc != nil {
+		output.Summary = jsonSummary{Pass: rc.pass, Fail: rc.fail, Warn: rc.warn, Error: rc.error, Skip: rc.skip}
+	}
+
+	for _, sp := range skippedPolicies {
+		output.SkippedPolicies = append(output.SkippedPolicies, sp.Name)
+	}
+
+	for _, er := range withRules(engineResponses) {
+		result := jsonResult{
+			Policy:                  er.PolicyResponse.Policy.Name,
+			Resource:                er.PolicyResponse.Resource,
+			ValidationFailureAction: er.PolicyResponse.ValidationFailureAction,
+		}
+
+		for _, rule := range er.PolicyResponse.Rules {
+			ruleResult := jsonRuleResult{
+				Name:                    rule.Name,
+				Type:                    rule.Type,
+				Status:                  string(rule.GetStatus()),
+				Message:                 rule.Message,
+				ValidationFailureAction: rule.ValidationFailureAction,
+			}
+
+			for _, patch := range rule.Patches {
+				if json.Valid(patch) {
+					ruleResult.Patches = append(ruleResult.Patches, json.RawMessage(patch))
+				}
+			}
+
+			result.Rules = append(result.Rules, ruleResult)
+		}
+
+		if len(er.GetPatches()) > 0 {
+			patched := er.PatchedResource
+			result.PatchedResource = &patched
+		}
+
+		output.Results = append(output.Results, result)
+	}
+
+	return output
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+}
+
+func buildJUnitOutput(engineResponses []*response.EngineResponse, skippedPolicies []SkippedPolicy) junitTestSuites {
+	output := junitTestSuites{Name: "kyverno"}
+	suites := map[string]int{}
+
+	suite := func(policy string) *junitTestSuite {
+		i, ok := suites[policy]
+		if !ok {
+			i = len(output.Suites)
+			suites[policy] = i
+			output.Suites = append(output.Suites, junitTestSuite{Name: policy})
+		}
+		return &output.Suites[i]
+	}
+
+	for _, er := range withRules(engineResponses) {
+		policy := er.PolicyResponse.Policy.Name
+		for _, rule := range er.PolicyResponse.Rules {
+			testCase := junitTestCase{
+				Name:      fmt.Sprintf("%s %s", rule.Name, resourceKey(er.PolicyResponse.Resource)),
+				ClassName: policy,
+			}
+
+			switch rule.GetStatus() {
+			case response.RuleStatusFail:
+				testCase.Failure = &junitMessage{Message: rule.Message, Type: rule.Type}
+			case response.RuleStatusError:
+				testCase.Error = &junitMessage{Message: rule.Message, Type: rule.Type}
+			case response.RuleStatusSkip:
+				testCase.Skipped = &junitMessage{Message: rule.Message}
+			}
+
+			suite(policy).add(testCase)
+		}
+	}
+
+	for _, sp := range skippedPolicies {
+		for _, rule := range sp.Rules {
+			suite(sp.Name).add(junitTestCase{
+				Name:      rule.Name,
+				ClassName: sp.Name,
+				Skipped:   &junitMessage{Message: fmt.Sprintf("skipped policy with variables - %s", sp.Variable)},
+			})
+		}
+	}
+
+	for _, s := range output.Suites {
+		output.Tests += s.Tests
+		output.Failures += s.Failures
+		output.Errors += s.Errors
+		output.Skipped += s.Skipped
+	}
+
+	return output
+}
+
+func (s *junitTestSuite) add(testCase junitTestCase) {
+	s.Tests++
+	switch {
+	case testCase.Failure != nil:
+		s.Failures++
+	case testCase.Error != nil:
+		s.Errors++
+	case testCase.Skipped != nil:
+		s.Skipped++
+	}
+	s.TestCases = append(s.TestCases, testCase)
+}
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// resourceLocation is the position of a resource manifest in a file
+type resourceLocation struct {
+	file string
+	line int
+}
+
+func buildSARIFOutput(engineResponses []*response.EngineResponse, locations map[string]resourceLocation) sarifLog {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "kyverno",
+				InformationURI: "https://kyverno.io",
+				Rules:          []sarifRule{},
+			},
+		},
+		Results: []sarifResult{},
+	}
+
+	ruleIndexes := map[string]int{}
+	for _, er := range withRules(engineResponses) {
+		policy := er.PolicyResponse.Policy.Name
+		for _, rule := range er.PolicyResponse.Rules {
+			status := rule.GetStatus()
+			if status != response.RuleStatusFail && status != response.RuleStatusError {
+				continue
+			}
+
+			id := policy + "/" + rule.Name
+			index, ok := ruleIndexes[id]
+			if !ok {
+				index = len(run.Tool.Driver.Rules)
+				ruleIndexes[id] = index
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+					ID:               id,
+					ShortDescription: sarifMessage{Text: fmt.Sprintf("%s rule %s of policy %s", rule.Type, rule.Name, policy)},
+				})
+			}
+
+			level := "warning"
+			if status == response.RuleStatusError || strings.EqualFold(rule.ValidationFailureAction, "enforce") {
+				level = "error"
+			}
+
+			result := sarifResult{
+				RuleID:    id,
+				RuleIndex: index,
+				Level:     level,
+				Message:   sarifMessage{Text: fmt.Sprintf("%s: %s", resourceKey(er.PolicyResponse.Resource), rule.Message)},
+			}
+
+			if location, ok := locations[resourceKey(er.PolicyResponse.Resource)]; ok {
+				result.Locations = []sarifLocation{
+					{
+						PhysicalLocation: sarifPhysicalLocation{
+							ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(location.file)},
+							Region:           sarifRegion{StartLine: location.line},
+						},
+					},
+				}
+			}
+
+			run.Results = append(run.Results, result)
+		}
+	}
+
+	return sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}}
+}
+
+// resourceLocations maps the resources of the local resource files to the file and the line their manifest starts at
+func resourceLocations(resourcePaths []string) map[string]resourceLocation {
+	locations := map[string]resourceLocation{}
+	for _, path := range resourcePaths {
+		if path == "-" || common.IsHttpRegex.MatchString(path) {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		for key, line := range manifestLines(data) {
+			if _, ok := locations[key]; !ok {
+				locations[key] = resourceLocation{file: path, line: line}
+			}
+		}
+	}
+	return locations
+}
+
+// manifestLines returns the start line of each YAML document, keyed by the resource it declares
+func manifestLines(data []byte) map[string]int {
+	lines := map[string]int{}
+	var doc bytes.Buffer
+	start := 1
+
+	addDoc := func() {
+		defer doc.Reset()
+		var resource unstructured.Unstructured
+		if err := yaml1.Unmarshal(doc.Bytes(), &resource.Object); err != nil || resource.GetKind() == "" {
+			return
+		}
+
+		if resource.GetNamespace() == "" {
+			resource.SetNamespace("default")
+		}
+
+		offset := firstContentLine(doc.Bytes())
+		lines[resourceKey(response.ResourceSpec{Kind: resource.GetKind(), Namespace: resource.GetNamespace(), Name: resource.GetName()})] = start + offset
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		if strings.HasPrefix(line, "---") {
+			addDoc()
+			start = lineNumber + 1
+			continue
+		}
+		doc.WriteString(line)
+		doc.WriteString("\n")
+	}
+	addDoc()
+
+	return lines
+}
+
+// firstContentLine returns the offset of the first line that is neither blank nor a comment
+func firstContentLine(doc []byte) int {
+	for i, line := range strings.Split(string(doc), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			return i
+		}
+	}
+	return 0
+}
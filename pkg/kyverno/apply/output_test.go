@@ -0,0 +1,132 @@
+package apply
+
+import (
+	"encoding/json"
+	"testing"
+
+	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"gotest.tools/assert"
+)
+
+func outputTestResponses() []*response.EngineResponse {
+	resource := response.ResourceSpec{Kind: "Pod", Namespace: "default", Name: "nginx"}
+	return []*response.EngineResponse{
+		{
+			PolicyResponse: response.PolicyResponse{
+				Policy:   response.PolicySpec{Name: "add-labels"},
+				Resource: resource,
+				Rules: []response.RuleResponse{
+					{Name: "add-team", Type: "Mutation", Success: true, Patches: [][]byte{[]byte(`{"op":"add","path":"/metadata/labels/team","value":"a"}`)}},
+				},
+			},
+		},
+		{
+			PolicyResponse: response.PolicyResponse{
+				Policy:                  response.PolicySpec{Name: "require-limits"},
+				Resource:                resource,
+				ValidationFailureAction: "audit",
+				Rules: []response.RuleResponse{
+					{Name: "check-limits", Type: "Validation", Success: false, Message: "limits are required", ValidationFailureAction: "audit"},
+					{Name: "check-requests", Type: "Validation", Success: false, Message: "requests are required", ValidationFailureAction: "enforce"},
+					{Name: "check-image", Type: "Validation", Status: response.RuleStatusSkip, ValidationFailureAction: "audit"},
+				},
+			},
+		},
+		{
+			PolicyResponse: response.PolicyResponse{
+				Policy:   response.PolicySpec{Name: "empty"},
+				Resource: resource,
+			},
+		},
+	}
+}
+
+func outputTestPolicyRules() []v1.Rule {
+	return []v1.Rule{{Name: "check-labels"}}
+}
+
+func Test_ShouldFail(t *testing.T) {
+	resps := outputTestResponses()
+	auditOnly := outputTestResponses()
+	auditOnly[1].PolicyResponse.Rules = auditOnly[1].PolicyResponse.Rules[:1]
+
+	assert.Assert(t, shouldFail(failOnAny, &resultCounts{}, resps))
+	assert.Assert(t, shouldFail(failOnAny, &resultCounts{error: 1}, nil))
+	assert.Assert(t, !shouldFail(failOnAny, &resultCounts{pass: 1}, resps[:1]))
+	assert.Assert(t, shouldFail(failOnEnforce, &resultCounts{fail: 1}, resps))
+	assert.Assert(t, !shouldFail(failOnEnforce, &resultCounts{fail: 1}, auditOnly))
+	assert.Assert(t, !shouldFail(failOnNever, &resultCounts{fail: 1}, resps))
+}
+
+func Test_JSONOutput(t *testing.T) {
+	output := buildJSONOutput(outputTestResponses(), &resultCounts{pass: 1, fail: 1}, []SkippedPolicy{{Name: "with-variables"}})
+	assert.Equal(t, len(output.Results), 2)
+	assert.Equal(t, output.Summary.Fail, 1)
+	assert.DeepEqual(t, output.SkippedPolicies, []string{"with-variables"})
+
+	mutate := output.Results[0]
+	assert.Equal(t, len(mutate.Rules[0].Patches), 1)
+	assert.Assert(t, mutate.PatchedResource != nil)
+	assert.Equal(t, output.Results[1].Rules[0].Status, string(response.RuleStatusFail))
+	assert.Assert(t, output.Results[1].PatchedResource == nil)
+
+	_, err := json.Marshal(output)
+	assert.NilError(t, err)
+}
+
+func Test_JUnitOutput(t *testing.T) {
+	output := buildJUnitOutput(outputTestResponses(), []SkippedPolicy{{Name: "with-variables", Rules: outputTestPolicyRules()}})
+	assert.Equal(t, len(output.Suites), 3)
+	assert.Equal(t, output.Tests, 5)
+	assert.Equal(t, output.Failures, 2)
+	assert.Equal(t, output.Skipped, 2)
+
+	validate := output.Suites[1]
+	assert.Equal(t, validate.Name, "require-limits")
+	assert.Equal(t, validate.TestCases[0].Name, "check-limits Pod/default/nginx")
+	assert.Equal(t, validate.TestCases[0].Failure.Message, "limits are required")
+}
+
+func Test_SARIFOutput(t *testing.T) {
+	locations := map[string]resourceLocation{"Pod/default/nginx": {file: "resources/pod.yaml", line: 5}}
+	output := buildSARIFOutput(outputTestResponses(), locations)
+	assert.Equal(t, output.Version, sarifVersion)
+
+	run := output.Runs[0]
+	assert.Equal(t, len(run.Tool.Driver.Rules), 2)
+	assert.Equal(t, len(run.Results), 2)
+	assert.Equal(t, run.Results[0].RuleID, "require-limits/check-limits")
+	assert.Equal(t, run.Results[0].Level, "warning")
+	assert.Equal(t, run.Results[1].Level, "error")
+	assert.Equal(t, run.Results[1].RuleIndex, 1)
+	assert.Equal(t, run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI, "resources/pod.yaml")
+	assert.Equal(t, run.Results[0].Locations[0].PhysicalLocation.Region.StartLine, 5)
+}
+
+func Test_ManifestLines(t *testing.T) {
+	manifest := []byte(`apiVersion: v1
+kind: Namespace
+metadata:
+  name: prod
+---
+# a comment
+apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx
+  namespace: prod
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: busybox
+`)
+
+	lines := manifestLines(manifest)
+	assert.DeepEqual(t, lines, map[string]int{
+		"Namespace/default/prod": 1,
+		"Pod/prod/nginx":         7,
+		"Pod/default/busybox":    13,
+	})
+}
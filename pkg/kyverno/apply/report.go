@@ -4,8 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
-	report "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha1"
+	report "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha2"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/engine/utils"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
@@ -28,10 +29,13 @@ func buildPolicyReports(resps []*response.EngineResponse, skippedPolicies []Skip
 		for _, r := range sp.Rules {
 			result := []*report.PolicyReportResult{
 				{
-					Message: fmt.Sprintln("skipped policy with variables -", sp.Variable),
-					Policy:  sp.Name,
-					Rule:    r.Name,
-					Status:  "skip",
+					Source:    policyreport.SourceKyverno,
+					Message:   fmt.Sprintln("skipped policy with variables -", sp.Variable),
+					Policy:    sp.Name,
+					Rule:      r.Name,
+					Type:      policyreport.GetRuleType(r),
+					Timestamp: metav1.Timestamp{Seconds: time.Now().Unix()},
+					Result:    report.StatusSkip,
 				},
 			}
 
@@ -127,6 +131,7 @@ func buildPolicyResults(resps []*response.EngineResponse) map[string][]*report.P
 				}
 
 				result := report.PolicyReportResult{
+					Source: policyreport.SourceKyverno,
 					Policy: info.PolicyName,
 					Resources: []*corev1.ObjectReference{
 						{
@@ -141,8 +146,17 @@ func buildPolicyResults(resps []*response.EngineResponse) map[string][]*report.P
 				}
 
 				result.Rule = rule.Name
+				result.Type = policyreport.RuleType(rule.Type)
 				result.Message = rule.Message
-				result.Status = report.PolicyStatus(rule.Check)
+				result.Timestamp = metav1.Timestamp{Seconds: time.Now().Unix()}
+				result.Result = report.PolicyResult(rule.Check)
+				action := rule.ValidationFailureAction
+				if action == "" {
+					action = infoResult.ValidationFailureAction
+				}
+				if action != "" {
+					result.Properties = map[string]string{policyreport.ValidationFailureActionKey: action}
+				}
 				results[appname] = append(results[appname], &result)
 			}
 		}
@@ -158,7 +172,7 @@ func mergeSucceededResults(results map[string][]*report.PolicyReportResult) map[
 
 		resourcesMap := make(map[string]*report.PolicyReportResult)
 		for _, result := range scopedResults {
-			if result.Status != report.PolicyStatus("pass") {
+			if result.Result != report.StatusPass {
 				resultsNew[scope] = append(resultsNew[scope], result)
 				continue
 			}
@@ -180,10 +194,13 @@ func mergeSucceededResults(results map[string][]*report.PolicyReportResult) map[
 			}
 
 			r := &report.PolicyReportResult{
+				Source:    v.Source,
 				Policy:    names[0],
 				Rule:      names[1],
+				Type:      v.Type,
+				Timestamp: v.Timestamp,
 				Resources: v.Resources,
-				Status:    report.PolicyStatus(v.Status),
+				Result:    v.Result,
 			}
 
 			resultsNew[scope] = append(resultsNew[scope], r)
@@ -194,7 +211,7 @@ func mergeSucceededResults(results map[string][]*report.PolicyReportResult) map[
 
 func calculateSummary(results []*report.PolicyReportResult) (summary report.PolicyReportSummary) {
 	for _, res := range results {
-		switch string(res.Status) {
+		switch string(res.Result) {
 		case report.StatusPass:
 			summary.Pass++
 		case report.StatusFail:
@@ -0,0 +1,381 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/go-git/go-billy/v5"
+	openapiv2 "github.com/googleapis/gnostic/openapiv2"
+	data "github.com/kyverno/kyverno/api"
+	client "github.com/kyverno/kyverno/pkg/dclient"
+	sanitizederror "github.com/kyverno/kyverno/pkg/kyverno/sanitizedError"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes/scheme"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// resourceScopes tells whether the kinds are namespaced, the scopes of the built-in kinds are read from the
+// embedded API resources and the CustomResourceDefinitions of the resources declare the scopes of their kinds
+type resourceScopes struct {
+	gvks  map[schema.GroupVersionKind]bool
+	kinds map[string]bool
+}
+
+var (
+	builtinScopes     resourceScopes
+	builtinScopesOnce sync.Once
+)
+
+// getBuiltinScopes returns the scopes of the kinds of the embedded API resources
+func getBuiltinScopes() resourceScopes {
+	builtinScopesOnce.Do(func() {
+		builtinScopes = resourceScopes{gvks: make(map[schema.GroupVersionKind]bool), kinds: make(map[string]bool)}
+		var apiResourceLists []*metav1.APIResourceList
+		if err := json.Unmarshal([]byte(data.APIResourceLists), &apiResourceLists); err != nil {
+			log.Log.Error(err, "failed to read the API resources")
+			return
+		}
+
+		for _, list := range apiResourceLists {
+			gv, err := schema.ParseGroupVersion(list.GroupVersion)
+			if err != nil {
+				continue
+			}
+
+			for _, r := range list.APIResources {
+				// subresources have the kind of their parent resource
+				if !strings.Contains(r.Name, "/") {
+					builtinScopes.add(gv.WithKind(r.Kind), r.Namespaced)
+				}
+			}
+		}
+	})
+	return builtinScopes
+}
+
+// newResourceScopes returns the scopes of the built-in kinds and of the kinds defined by the CustomResourceDefinitions of the resources
+func newResourceScopes(resources []*unstructured.Unstructured) resourceScopes {
+	scopes := resourceScopes{gvks: make(map[schema.GroupVersionKind]bool), kinds: make(map[string]bool)}
+	for gvk, namespaced := range getBuiltinScopes().gvks {
+		scopes.add(gvk, namespaced)
+	}
+
+	for _, resource := range resources {
+		if resource.GetKind() != "CustomResourceDefinition" {
+			continue
+		}
+
+		group, _, _ := unstructured.NestedString(resource.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(resource.Object, "spec", "names", "kind")
+		scope, _, _ := unstructured.NestedString(resource.Object, "spec", "scope")
+		versions, _, _ := unstructured.NestedSlice(resource.Object, "spec", "versions")
+		for _, v := range versions {
+			if name, ok := v.(map[string]interface{})["name"].(string); ok {
+				scopes.add(schema.GroupVersionKind{Group: group, Version: name, Kind: kind}, scope != "Cluster")
+			}
+		}
+
+		// v1beta1 CustomResourceDefinitions may declare a single version
+		if version, ok, _ := unstructured.NestedString(resource.Object, "spec", "version"); ok {
+			scopes.add(schema.GroupVersionKind{Group: group, Version: version, Kind: kind}, scope != "Cluster")
+		}
+	}
+	return scopes
+}
+
+func (s resourceScopes) add(gvk schema.GroupVersionKind, namespaced bool) {
+	s.gvks[gvk] = namespaced
+	s.kinds[gvk.Kind] = namespaced
+}
+
+// isClusterScoped checks if the kind is not namespaced, only the kind is used when the group and version are unknown,
+// unknown kinds are namespaced
+func (s resourceScopes) isClusterScoped(gvk schema.GroupVersionKind) bool {
+	if namespaced, ok := s.gvks[gvk]; ok {
+		return !namespaced
+	}
+
+	namespaced, ok := s.kinds[gvk.Kind]
+	return ok && !namespaced
+}
+
+// IsClusterScopedKind checks if the built-in kind is not namespaced
+func IsClusterScopedKind(kind string) bool {
+	return getBuiltinScopes().isClusterScoped(schema.GroupVersionKind{Kind: kind})
+}
+
+// ReadResources reads the resources of the files and directories, directories are read recursively
+func ReadResources(fs billy.Filesystem, paths []string, isGit bool, policyResourcePath string) ([]*unstructured.Unstructured, error) {
+	var resources []*unstructured.Unstructured
+	for _, path := range paths {
+		path = filepath.Join(policyResourcePath, path)
+
+		var files [][]byte
+		var err error
+		if isGit {
+			files, err = readGitFiles(fs, path)
+		} else {
+			files, err = readLocalFiles(path)
+		}
+
+		if err != nil {
+			return nil, sanitizederror.NewWithError(fmt.Sprintf("failed to read resources %s", path), err)
+		}
+
+		for _, file := range files {
+			fileResources, err := GetResource(file)
+			if err != nil {
+				return nil, sanitizederror.NewWithError(fmt.Sprintf("failed to decode resources %s", path), err)
+			}
+
+			resources = append(resources, fileResources...)
+		}
+	}
+
+	// the resources of the kinds which are not cluster-scoped are put in the default namespace
+	scopes := newResourceScopes(resources)
+	for _, resource := range resources {
+		if scopes.isClusterScoped(resource.GroupVersionKind()) {
+			resource.SetNamespace("")
+		} else if resource.GetNamespace() == "" {
+			resource.SetNamespace("default")
+		}
+	}
+	return resources, nil
+}
+
+func isManifest(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".yaml" || ext == ".yml" || ext == ".json"
+}
+
+func readLocalFiles(path string) ([][]byte, error) {
+	var files [][]byte
+	err := filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() || (p != path && !isManifest(p)) {
+			return nil
+		}
+
+		data, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, data)
+		return nil
+	})
+
+	return files, err
+}
+
+func readGitFiles(fs billy.Filesystem, path string) ([][]byte, error) {
+	info, err := fs.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		file, err := fs.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		data, err := ioutil.ReadAll(file)
+		if err != nil {
+			return nil, err
+		}
+		return [][]byte{data}, nil
+	}
+
+	infos, err := fs.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var files [][]byte
+	for _, info := range infos {
+		name := filepath.Join(path, info.Name())
+		if !info.IsDir() && !isManifest(name) {
+			continue
+		}
+
+		more, err := readGitFiles(fs, name)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, more...)
+	}
+	return files, nil
+}
+
+// GetNamespaceLabels returns the labels of the Namespace cluster fixtures
+func GetNamespaceLabels(resources []*unstructured.Unstructured) map[string]map[string]string {
+	labels := make(map[string]map[string]string)
+	for _, resource := range resources {
+		if resource.GetKind() == "Namespace" {
+			labels[resource.GetName()] = resource.GetLabels()
+		}
+	}
+	return labels
+}
+
+// NewFixtureClient returns a client that serves the cluster fixtures, the built-in
+// resources and the kinds of the fixtures can be listed and fetched
+func NewFixtureClient(resources []*unstructured.Unstructured) (*client.Client, error) {
+	discoveryClient := newFixtureDiscovery(resources)
+
+	gvrToListKind := make(map[schema.GroupVersionResource]string)
+	for _, r := range discoveryClient.resources {
+		gvrToListKind[r.gvr] = r.kind + "List"
+	}
+
+	objects := make([]runtime.Object, 0, len(resources))
+	for _, resource := range resources {
+		objects = append(objects, resource)
+	}
+
+	dClient, err := client.NewMockClient(runtime.NewScheme(), gvrToListKind, objects...)
+	if err != nil {
+		return nil, err
+	}
+
+	dClient.SetDiscovery(discoveryClient)
+	return dClient, nil
+}
+
+type fixtureResource struct {
+	gvr        schema.GroupVersionResource
+	kind       string
+	namespaced bool
+}
+
+// fixtureDiscovery resolves kinds and resource names, e.g. Pod or pods, to the resources
+// of the cluster fixtures and of the built-in types
+type fixtureDiscovery struct {
+	resources []fixtureResource
+}
+
+func newFixtureDiscovery(resources []*unstructured.Unstructured) *fixtureDiscovery {
+	d := &fixtureDiscovery{}
+	scopes := newResourceScopes(resources)
+	seen := make(map[schema.GroupVersionResource]bool)
+	add := func(gvk schema.GroupVersionKind) {
+		gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+		if seen[gvr] {
+			return
+		}
+
+		seen[gvr] = true
+		d.resources = append(d.resources, fixtureResource{
+			gvr:        gvr,
+			kind:       gvk.Kind,
+			namespaced: !scopes.isClusterScoped(gvk),
+		})
+	}
+
+	// the kinds of the fixtures take precedence over the built-in kinds
+	for _, resource := range resources {
+		add(resource.GroupVersionKind())
+	}
+
+	for gvk := range scheme.Scheme.AllKnownTypes() {
+		if gvk.Version == runtime.APIVersionInternal || strings.HasSuffix(gvk.Kind, "List") {
+			continue
+		}
+
+		if !scheme.Scheme.Recognizes(gvk.GroupVersion().WithKind(gvk.Kind + "List")) {
+			continue
+		}
+
+		add(gvk)
+	}
+
+	return d
+}
+
+// find returns the resource of the kind or resource name, the API version is optional
+func (d *fixtureDiscovery) find(apiVersion, kind string) (fixtureResource, bool) {
+	var found *fixtureResource
+	for i, r := range d.resources {
+		if !strings.EqualFold(r.kind, kind) && !strings.EqualFold(r.gvr.Resource, kind) {
+			continue
+		}
+
+		if apiVersion != "" && r.gvr.GroupVersion().String() != apiVersion {
+			continue
+		}
+
+		if found == nil || preferredVersion(r.gvr, found.gvr) {
+			found = &d.resources[i]
+		}
+	}
+
+	if found == nil {
+		return fixtureResource{}, false
+	}
+	return *found, true
+}
+
+// preferredVersion checks if a is preferred over b, stable versions and groups other than extensions are preferred
+func preferredVersion(a, b schema.GroupVersionResource) bool {
+	if (a.Group == "extensions") != (b.Group == "extensions") {
+		return b.Group == "extensions"
+	}
+	return version.CompareKubeAwareVersionStrings(a.Version, b.Version) > 0
+}
+
+func (d *fixtureDiscovery) FindResource(apiVersion string, kind string) (*metav1.APIResource, schema.GroupVersionResource, error) {
+	r, ok := d.find(apiVersion, kind)
+	if !ok {
+		return nil, schema.GroupVersionResource{}, fmt.Errorf("kind %s not found", kind)
+	}
+
+	return &metav1.APIResource{
+		Name:       r.gvr.Resource,
+		Kind:       r.kind,
+		Group:      r.gvr.Group,
+		Version:    r.gvr.Version,
+		Namespaced: r.namespaced,
+	}, r.gvr, nil
+}
+
+func (d *fixtureDiscovery) GetGVRFromKind(kind string) (schema.GroupVersionResource, error) {
+	r, ok := d.find("", kind)
+	if !ok {
+		return schema.GroupVersionResource{}, fmt.Errorf("kind %s not found", kind)
+	}
+	return r.gvr, nil
+}
+
+func (d *fixtureDiscovery) GetGVRFromAPIVersionKind(apiVersion string, kind string) schema.GroupVersionResource {
+	r, _ := d.find(apiVersion, kind)
+	return r.gvr
+}
+
+func (d *fixtureDiscovery) GetServerVersion() (*version.Info, error) {
+	return &version.Info{}, nil
+}
+
+func (d *fixtureDiscovery) OpenAPISchema() (*openapiv2.Document, error) {
+	return nil, fmt.Errorf("the OpenAPI schema is not available for cluster fixtures")
+}
+
+func (d *fixtureDiscovery) DiscoveryCache() discovery.CachedDiscoveryInterface {
+	return nil
+}
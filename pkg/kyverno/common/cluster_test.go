@@ -0,0 +1,118 @@
+package common
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_FixtureClient(t *testing.T) {
+	dir := t.TempDir()
+	assert.NilError(t, os.Mkdir(filepath.Join(dir, "apps"), 0755))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("not a manifest"), 0644))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "core.yaml"), []byte(`apiVersion: v1
+kind: Namespace
+metadata:
+  name: team-a
+  labels:
+    quota: limited
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: pod-quota
+data:
+  maxPods: "2"
+`), 0644))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "apps", "deployments.yaml"), []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: team-a
+`), 0644))
+
+	resources, err := ReadResources(nil, []string{"."}, false, dir)
+	assert.NilError(t, err)
+	assert.Equal(t, len(resources), 3)
+
+	assert.DeepEqual(t, GetNamespaceLabels(resources), map[string]map[string]string{"team-a": {"quota": "limited"}})
+
+	dClient, err := NewFixtureClient(resources)
+	assert.NilError(t, err)
+
+	configMap, err := dClient.GetResource("v1", "ConfigMap", "default", "pod-quota")
+	assert.NilError(t, err)
+	maxPods, _, _ := unstructured.NestedString(configMap.Object, "data", "maxPods")
+	assert.Equal(t, maxPods, "2")
+
+	// resource names of API calls resolve as well as kinds
+	deployments, err := dClient.ListResource("apps/v1", "deployments", "team-a", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(deployments.Items), 1)
+
+	pods, err := dClient.ListResource("", "pods", "team-a", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(pods.Items), 0)
+
+	_, err = dClient.GetResource("", "namespaces", "", "team-a")
+	assert.NilError(t, err)
+}
+
+func Test_ReadResources_scopes(t *testing.T) {
+	dir := t.TempDir()
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "resources.yaml"), []byte(`apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: tenants.example.com
+spec:
+  group: example.com
+  scope: Cluster
+  names:
+    kind: Tenant
+    plural: tenants
+  versions:
+  - name: v1
+    served: true
+    storage: true
+---
+apiVersion: example.com/v1
+kind: Tenant
+metadata:
+  name: team-a
+---
+apiVersion: example.com/v1
+kind: Database
+metadata:
+  name: orders
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: view
+`), 0644))
+
+	resources, err := ReadResources(nil, []string{"resources.yaml"}, false, dir)
+	assert.NilError(t, err)
+	assert.Equal(t, len(resources), 4)
+
+	// the CustomResourceDefinition declares the scope of its kind, unknown kinds are namespaced
+	namespaces := make(map[string]string)
+	for _, resource := range resources {
+		namespaces[resource.GetKind()] = resource.GetNamespace()
+	}
+	assert.DeepEqual(t, namespaces, map[string]string{
+		"CustomResourceDefinition": "",
+		"Tenant":                   "",
+		"Database":                 "default",
+		"ClusterRole":              "",
+	})
+
+	assert.Assert(t, IsClusterScopedKind("ClusterRole"))
+	assert.Assert(t, IsClusterScopedKind("Namespace"))
+	assert.Assert(t, !IsClusterScopedKind("Pod"))
+	assert.Assert(t, !IsClusterScopedKind("Tenant"))
+}
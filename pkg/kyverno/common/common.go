@@ -49,6 +49,10 @@ type Rule struct {
 type Values struct {
 	Policies           []Policy            `json:"policies"`
 	NamespaceSelectors []NamespaceSelector `json:"namespaceSelector"`
+
+	// ClusterResources are the files and directories of the resources served as the cluster state,
+	// e.g. the ConfigMaps of context entries and the resources of API calls
+	ClusterResources []string `json:"clusterResources"`
 }
 
 type Resource struct {
@@ -380,11 +384,13 @@ func RemoveDuplicateVariables(matches [][]string) string {
 	return variableStr
 }
 
-func GetVariable(variablesString, valuesFile string, fs billy.Filesystem, isGit bool, policyResourcePath string) (map[string]string, map[string]map[string]Resource, map[string]map[string]string, error) {
+// GetVariable reads the variables, the values file and the cluster resources it references
+func GetVariable(variablesString, valuesFile string, fs billy.Filesystem, isGit bool, policyResourcePath string) (map[string]string, map[string]map[string]Resource, map[string]map[string]string, []*unstructured.Unstructured, error) {
 	valuesMapResource := make(map[string]map[string]Resource)
 	valuesMapRule := make(map[string]map[string]Rule)
 	namespaceSelectorMap := make(map[string]map[string]string)
 	variables := make(map[string]string)
+	var clusterResources []*unstructured.Unstructured
 	var yamlFile []byte
 	var err error
 	if variablesString != "" {
@@ -406,17 +412,17 @@ func GetVariable(variablesString, valuesFile string, fs billy.Filesystem, isGit
 		}
 
 		if err != nil {
-			return variables, valuesMapResource, namespaceSelectorMap, sanitizederror.NewWithError("unable to read yaml", err)
+			return variables, valuesMapResource, namespaceSelectorMap, clusterResources, sanitizederror.NewWithError("unable to read yaml", err)
 		}
 
 		valuesBytes, err := yaml.ToJSON(yamlFile)
 		if err != nil {
-			return variables, valuesMapResource, namespaceSelectorMap, sanitizederror.NewWithError("failed to convert json", err)
+			return variables, valuesMapResource, namespaceSelectorMap, clusterResources, sanitizederror.NewWithError("failed to convert json", err)
 		}
 
 		values := &Values{}
 		if err := json.Unmarshal(valuesBytes, values); err != nil {
-			return variables, valuesMapResource, namespaceSelectorMap, sanitizederror.NewWithError("failed to decode yaml", err)
+			return variables, valuesMapResource, namespaceSelectorMap, clusterResources, sanitizederror.NewWithError("failed to decode yaml", err)
 		}
 
 		for _, p := range values.Policies {
@@ -438,6 +444,18 @@ func GetVariable(variablesString, valuesFile string, fs billy.Filesystem, isGit
 		for _, n := range values.NamespaceSelectors {
 			namespaceSelectorMap[n.Name] = n.Labels
 		}
+
+		clusterResources, err = ReadResources(fs, values.ClusterResources, isGit, policyResourcePath)
+		if err != nil {
+			return variables, valuesMapResource, namespaceSelectorMap, clusterResources, err
+		}
+
+		// the labels of the namespaces in the cluster resources are used unless they are set explicitly
+		for name, labels := range GetNamespaceLabels(clusterResources) {
+			if _, ok := namespaceSelectorMap[name]; !ok {
+				namespaceSelectorMap[name] = labels
+			}
+		}
 	}
 
 	storePolices := make([]store.Policy, 0)
@@ -459,7 +477,7 @@ func GetVariable(variablesString, valuesFile string, fs billy.Filesystem, isGit
 		Policies: storePolices,
 	})
 
-	return variables, valuesMapResource, namespaceSelectorMap, nil
+	return variables, valuesMapResource, namespaceSelectorMap, clusterResources, nil
 }
 
 // MutatePolices - function to apply mutation on policies
@@ -480,9 +498,9 @@ func MutatePolices(policies []*v1.ClusterPolicy) ([]*v1.ClusterPolicy, error) {
 	return newPolicies, nil
 }
 
-// ApplyPolicyOnResource - function to apply policy on resource
+// ApplyPolicyOnResource - function to apply policy on resource, the results are printed to out
 func ApplyPolicyOnResource(policy *v1.ClusterPolicy, resource *unstructured.Unstructured,
-	mutateLogPath string, mutateLogPathIsDir bool, variables map[string]string, policyReport bool, namespaceSelectorMap map[string]map[string]string, stdin bool) ([]*response.EngineResponse, *response.EngineResponse, bool, bool, error) {
+	mutateLogPath string, mutateLogPathIsDir bool, variables map[string]string, policyReport bool, namespaceSelectorMap map[string]map[string]string, stdin bool, userInfo *UserInfo, dClient *client.Client, out io.Writer) ([]*response.EngineResponse, *response.EngineResponse, bool, bool, error) {
 
 	responseError := false
 	rcError := false
@@ -496,6 +514,21 @@ func ApplyPolicyOnResource(policy *v1.ClusterPolicy, resource *unstructured.Unst
 			policyWithNamespaceSelector = true
 			break
 		}
+		for _, filters := range []v1.ResourceFilters{p.MatchResources.Any, p.MatchResources.All, p.ExcludeResources.Any, p.ExcludeResources.All} {
+			for _, rf := range filters {
+				if rf.NamespaceSelector != nil {
+					policyWithNamespaceSelector = true
+				}
+			}
+		}
+		if policyWithNamespaceSelector {
+			break
+		}
+	}
+	for _, override := range policy.Spec.ValidationFailureActionOverrides {
+		if override.NamespaceSelector != nil {
+			policyWithNamespaceSelector = true
+		}
 	}
 
 	if policyWithNamespaceSelector {
@@ -510,18 +543,34 @@ func ApplyPolicyOnResource(policy *v1.ClusterPolicy, resource *unstructured.Unst
 	log.Log.V(3).Info("applying policy on resource", "policy", policy.Name, "resource", resPath)
 
 	ctx := context.NewContext()
+	if userInfo != nil {
+		if err := userInfo.AddToContext(ctx); err != nil {
+			return engineResponses, &response.EngineResponse{}, responseError, rcError, sanitizederror.NewWithError("failed to add user info to the context", err)
+		}
+	}
+
+	// the resource is added as request.object, values passed for its variables take precedence
+	resourceRaw, err := resource.MarshalJSON()
+	if err != nil {
+		return engineResponses, &response.EngineResponse{}, responseError, rcError, sanitizederror.NewWithError("failed to marshal the resource", err)
+	}
+
+	if err := ctx.AddResource(resourceRaw); err != nil {
+		return engineResponses, &response.EngineResponse{}, responseError, rcError, sanitizederror.NewWithError("failed to add the resource to the context", err)
+	}
+
 	for key, value := range variables {
 		jsonData := pkgcommon.VariableToJSON(key, value)
 		ctx.AddJSON(jsonData)
 	}
 
-	mutateResponse := engine.Mutate(&engine.PolicyContext{Policy: *policy, NewResource: *resource, JSONContext: ctx, NamespaceLabels: namespaceLabels})
+	mutateResponse := engine.Mutate(&engine.PolicyContext{Policy: *policy, NewResource: *resource, JSONContext: ctx, NamespaceLabels: namespaceLabels, AdmissionInfo: userInfo.GetRequestInfo(), Client: dClient})
 	engineResponses = append(engineResponses, mutateResponse)
 
 	if !mutateResponse.IsSuccessful() {
-		fmt.Printf("Failed to apply mutate policy %s -> resource %s", policy.Name, resPath)
+		fmt.Fprintf(out, "Failed to apply mutate policy %s -> resource %s", policy.Name, resPath)
 		for i, r := range mutateResponse.PolicyResponse.Rules {
-			fmt.Printf("\n%d. %s", i+1, r.Message)
+			fmt.Fprintf(out, "\n%d. %s", i+1, r.Message)
 		}
 		responseError = true
 	} else {
@@ -535,17 +584,17 @@ func ApplyPolicyOnResource(policy *v1.ClusterPolicy, resource *unstructured.Unst
 				mutatedResource := string(yamlEncodedResource) + string("\n---")
 				if len(strings.TrimSpace(mutatedResource)) > 0 {
 					if !stdin {
-						fmt.Printf("\nmutate policy %s applied to %s:", policy.Name, resPath)
+						fmt.Fprintf(out, "\nmutate policy %s applied to %s:", policy.Name, resPath)
 					}
-					fmt.Printf("\n" + mutatedResource)
-					fmt.Printf("\n")
+					fmt.Fprintf(out, "\n"+mutatedResource)
+					fmt.Fprintf(out, "\n")
 				}
 			} else {
 				err := PrintMutatedOutput(mutateLogPath, mutateLogPathIsDir, string(yamlEncodedResource), resource.GetName()+"-mutated")
 				if err != nil {
 					return engineResponses, &response.EngineResponse{}, responseError, rcError, sanitizederror.NewWithError("failed to print mutated result", err)
 				}
-				fmt.Printf("\n\nMutation:\nMutation has been applied successfully. Check the files.")
+				fmt.Fprintf(out, "\n\nMutation:\nMutation has been applied successfully. Check the files.")
 			}
 
 		}
@@ -559,14 +608,22 @@ func ApplyPolicyOnResource(policy *v1.ClusterPolicy, resource *unstructured.Unst
 		}
 	}
 
-	policyCtx := &engine.PolicyContext{Policy: *policy, NewResource: mutateResponse.PatchedResource, JSONContext: ctx, NamespaceLabels: namespaceLabels}
+	policyCtx := &engine.PolicyContext{Policy: *policy, NewResource: mutateResponse.PatchedResource, JSONContext: ctx, NamespaceLabels: namespaceLabels, AdmissionInfo: userInfo.GetRequestInfo(), Client: dClient}
 	validateResponse := engine.Validate(policyCtx)
 	if !policyReport {
 		if !validateResponse.IsSuccessful() {
-			fmt.Printf("\npolicy %s -> resource %s failed: \n", policy.Name, resPath)
+			if action := validateResponse.PolicyResponse.ValidationFailureAction; action != "" {
+				fmt.Fprintf(out, "\npolicy %s -> resource %s failed (validationFailureAction: %s): \n", policy.Name, resPath, action)
+			} else {
+				fmt.Fprintf(out, "\npolicy %s -> resource %s failed: \n", policy.Name, resPath)
+			}
 			for i, r := range validateResponse.PolicyResponse.Rules {
 				if !r.Success {
-					fmt.Printf("%d. %s: %s \n", i+1, r.Name, r.Message)
+					if r.ValidationFailureAction != validateResponse.PolicyResponse.ValidationFailureAction {
+						fmt.Fprintf(out, "%d. %s (validationFailureAction: %s): %s \n", i+1, r.Name, r.ValidationFailureAction, r.Message)
+					} else {
+						fmt.Fprintf(out, "%d. %s: %s \n", i+1, r.Name, r.Message)
+					}
 				}
 			}
 
@@ -591,15 +648,17 @@ func ApplyPolicyOnResource(policy *v1.ClusterPolicy, resource *unstructured.Unst
 			},
 			JSONContext:     context.NewContext(),
 			NamespaceLabels: namespaceLabels,
+			AdmissionInfo:   userInfo.GetRequestInfo(),
+			Client:          dClient,
 		}
 		generateResponse := engine.Generate(policyContext)
 		engineResponses = append(engineResponses, generateResponse)
 		if len(generateResponse.PolicyResponse.Rules) > 0 {
 			log.Log.V(3).Info("generate resource is valid", "policy", policy.Name, "resource", resPath)
 		} else {
-			fmt.Printf("generate policy %s resource %s is invalid \n", policy.Name, resPath)
+			fmt.Fprintf(out, "generate policy %s resource %s is invalid \n", policy.Name, resPath)
 			for i, r := range generateResponse.PolicyResponse.Rules {
-				fmt.Printf("%d. %s \b", i+1, r.Message)
+				fmt.Fprintf(out, "%d. %s \b", i+1, r.Message)
 			}
 
 			responseError = true
@@ -700,7 +759,7 @@ func GetPoliciesFromPaths(fs billy.Filesystem, dirPath []string, isGit bool, pol
 
 // GetResourceAccordingToResourcePath - get resources according to the resource path
 func GetResourceAccordingToResourcePath(fs billy.Filesystem, resourcePaths []string,
-	cluster bool, policies []*v1.ClusterPolicy, dClient *client.Client, namespace string, policyReport bool, isGit bool, policyResourcePath string) (resources []*unstructured.Unstructured, err error) {
+	cluster bool, policies []*v1.ClusterPolicy, dClient *client.Client, namespace string, policyReport bool, isGit bool, policyResourcePath string, out io.Writer) (resources []*unstructured.Unstructured, err error) {
 	if isGit {
 		resources, err = GetResourcesWithTest(fs, policies, resourcePaths, isGit, policyResourcePath)
 		if err != nil {
@@ -722,7 +781,7 @@ func GetResourceAccordingToResourcePath(fs billy.Filesystem, resourcePaths []str
 				}
 			}
 		} else if (len(resourcePaths) > 0 && resourcePaths[0] != "-") || len(resourcePaths) < 0 || cluster {
-			resources, err = GetResources(policies, resourcePaths, dClient, cluster, namespace, policyReport)
+			resources, err = GetResources(policies, resourcePaths, dClient, cluster, namespace, policyReport, out)
 			if err != nil {
 				return resources, err
 			}
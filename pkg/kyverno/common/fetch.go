@@ -3,6 +3,7 @@ package common
 import (
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"path/filepath"
@@ -24,7 +25,8 @@ import (
 // the resources are fetched from
 // - local paths to resources, if given
 // - the k8s cluster, if given
-func GetResources(policies []*v1.ClusterPolicy, resourcePaths []string, dClient *client.Client, cluster bool, namespace string, policyReport bool) ([]*unstructured.Unstructured, error) {
+// the resources which are not found are reported to out unless a policy report is generated
+func GetResources(policies []*v1.ClusterPolicy, resourcePaths []string, dClient *client.Client, cluster bool, namespace string, policyReport bool, out io.Writer) ([]*unstructured.Unstructured, error) {
 	resources := make([]*unstructured.Unstructured, 0)
 	var err error
 	var resourceTypesMap = make(map[string]bool)
@@ -32,7 +34,7 @@ func GetResources(policies []*v1.ClusterPolicy, resourcePaths []string, dClient
 
 	for _, policy := range policies {
 		for _, rule := range policy.Spec.Rules {
-			for _, kind := range rule.MatchResources.Kinds {
+			for _, kind := range rule.MatchResources.GetKinds() {
 				resourceTypesMap[kind] = true
 			}
 		}
@@ -43,12 +45,12 @@ func GetResources(policies []*v1.ClusterPolicy, resourcePaths []string, dClient
 	}
 
 	if cluster && dClient != nil {
-		resources, err = whenClusterIsTrue(resourceTypes, dClient, namespace, resourcePaths, policyReport)
+		resources, err = whenClusterIsTrue(resourceTypes, dClient, namespace, resourcePaths, policyReport, out)
 		if err != nil {
 			return resources, err
 		}
 	} else if len(resourcePaths) > 0 {
-		resources, err = whenClusterIsFalse(resourcePaths, policyReport)
+		resources, err = whenClusterIsFalse(resourcePaths, policyReport, out)
 		if err != nil {
 			return resources, err
 		}
@@ -56,7 +58,7 @@ func GetResources(policies []*v1.ClusterPolicy, resourcePaths []string, dClient
 	return resources, err
 }
 
-func whenClusterIsTrue(resourceTypes []string, dClient *client.Client, namespace string, resourcePaths []string, policyReport bool) ([]*unstructured.Unstructured, error) {
+func whenClusterIsTrue(resourceTypes []string, dClient *client.Client, namespace string, resourcePaths []string, policyReport bool, out io.Writer) ([]*unstructured.Unstructured, error) {
 	resources := make([]*unstructured.Unstructured, 0)
 	resourceMap, err := getResourcesOfTypeFromCluster(resourceTypes, dClient, namespace)
 	if err != nil {
@@ -81,7 +83,7 @@ func whenClusterIsTrue(resourceTypes []string, dClient *client.Client, namespace
 				if policyReport {
 					log.Log.V(3).Info(fmt.Sprintf("%s not found in cluster", resourcePath))
 				} else {
-					fmt.Printf("\n----------------------------------------------------------------------\nresource %s not found in cluster\n----------------------------------------------------------------------\n", resourcePath)
+					fmt.Fprintf(out, "\n----------------------------------------------------------------------\nresource %s not found in cluster\n----------------------------------------------------------------------\n", resourcePath)
 				}
 				return nil, fmt.Errorf("%s not found in cluster", resourcePath)
 			}
@@ -90,7 +92,7 @@ func whenClusterIsTrue(resourceTypes []string, dClient *client.Client, namespace
 	return resources, nil
 }
 
-func whenClusterIsFalse(resourcePaths []string, policyReport bool) ([]*unstructured.Unstructured, error) {
+func whenClusterIsFalse(resourcePaths []string, policyReport bool, out io.Writer) ([]*unstructured.Unstructured, error) {
 	resources := make([]*unstructured.Unstructured, 0)
 	for _, resourcePath := range resourcePaths {
 		resourceBytes, err := getFileBytes(resourcePath)
@@ -98,7 +100,7 @@ func whenClusterIsFalse(resourcePaths []string, policyReport bool) ([]*unstructu
 			if policyReport {
 				log.Log.V(3).Info(fmt.Sprintf("failed to load resources: %s.", resourcePath), "error", err)
 			} else {
-				fmt.Printf("\n----------------------------------------------------------------------\nfailed to load resources: %s. \nerror: %s\n----------------------------------------------------------------------\n", resourcePath, err)
+				fmt.Fprintf(out, "\n----------------------------------------------------------------------\nfailed to load resources: %s. \nerror: %s\n----------------------------------------------------------------------\n", resourcePath, err)
 			}
 			continue
 		}
@@ -120,7 +122,7 @@ func GetResourcesWithTest(fs billy.Filesystem, policies []*v1.ClusterPolicy, res
 	var resourceTypes []string
 	for _, policy := range policies {
 		for _, rule := range policy.Spec.Rules {
-			for _, kind := range rule.MatchResources.Kinds {
+			for _, kind := range rule.MatchResources.GetKinds() {
 				resourceTypesMap[kind] = true
 			}
 		}
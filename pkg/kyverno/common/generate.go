@@ -0,0 +1,104 @@
+package common
+
+import (
+	"fmt"
+
+	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	pkgcommon "github.com/kyverno/kyverno/pkg/common"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/generate"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// GenerateResource builds the resource that a generate rule creates for the trigger resource with the labels
+// of the generate controller, the source of a clone is looked up in the resources
+func GenerateResource(policy *v1.ClusterPolicy, ruleName string, trigger *unstructured.Unstructured, vars map[string]string, userInfo *UserInfo, resources []*unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	var rule *v1.Rule
+	for i := range policy.Spec.Rules {
+		if policy.Spec.Rules[i].Name == ruleName && policy.Spec.Rules[i].HasGenerate() {
+			rule = &policy.Spec.Rules[i]
+			break
+		}
+	}
+
+	if rule == nil {
+		return nil, fmt.Errorf("generate rule %s not found in policy %s", ruleName, policy.Name)
+	}
+
+	ctx := context.NewContext()
+	if userInfo != nil {
+		if err := userInfo.AddToContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	for key, value := range vars {
+		if err := ctx.AddJSON(pkgcommon.VariableToJSON(key, value)); err != nil {
+			return nil, err
+		}
+	}
+
+	triggerRaw, err := trigger.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.AddResource(triggerRaw); err != nil {
+		return nil, err
+	}
+
+	substitutedRule, err := variables.SubstituteAllInRule(log.Log, ctx, *rule)
+	if err != nil {
+		return nil, fmt.Errorf("variable substitution failed for rule %s: %v", rule.Name, err)
+	}
+
+	genResource, data, clone, err := generate.ParseGeneration(substitutedRule)
+	if err != nil {
+		return nil, err
+	}
+
+	content := data
+	if len(clone) != 0 {
+		cloneNamespace, _, _ := unstructured.NestedString(clone, "namespace")
+		cloneName, _, _ := unstructured.NestedString(clone, "name")
+		source := findResource(resources, genResource.Kind, cloneNamespace, cloneName)
+		if source == nil {
+			return nil, fmt.Errorf("source resource %s %s/%s not found in the resources", genResource.Kind, cloneNamespace, cloneName)
+		}
+
+		// the metadata assigned by the API server is not part of the generated resource
+		content = source.DeepCopy().Object
+		unstructured.RemoveNestedField(content, "metadata", "uid")
+		unstructured.RemoveNestedField(content, "metadata", "resourceVersion")
+		unstructured.RemoveNestedField(content, "metadata", "creationTimestamp")
+		unstructured.RemoveNestedField(content, "metadata", "managedFields")
+		unstructured.RemoveNestedField(content, "metadata", "selfLink")
+	}
+
+	if content == nil {
+		content = make(map[string]interface{})
+	}
+
+	// the resource is built like the generate controller does, without a generate request,
+	// the namespace defaulted when the resources are read is not part of a cluster-scoped trigger
+	triggerResource := *trigger.DeepCopy()
+	if IsClusterScopedKind(triggerResource.GetKind()) {
+		triggerResource.SetNamespace("")
+	}
+	return generate.NewGeneratedResource(content, genResource, triggerResource, policy.Name, "", substitutedRule.Generation.Synchronize), nil
+}
+
+func findResource(resources []*unstructured.Unstructured, kind, namespace, name string) *unstructured.Unstructured {
+	for _, resource := range resources {
+		if resource.GetKind() != kind || resource.GetName() != name {
+			continue
+		}
+
+		if resource.GetNamespace() == namespace || (namespace == "" && resource.GetNamespace() == "default") {
+			return resource
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package common
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-billy/v5"
+	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+	sanitizederror "github.com/kyverno/kyverno/pkg/kyverno/sanitizedError"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// UserInfo is the admission request context used to apply policies offline, e.g.
+//
+//	operation: UPDATE
+//	roles:
+//	- dev:developer
+//	clusterRoles:
+//	- cluster-admin
+//	userInfo:
+//	  username: system:serviceaccount:dev:builder
+//	  groups:
+//	  - system:serviceaccounts
+type UserInfo struct {
+	v1.RequestInfo `json:",inline"`
+
+	// Operation is the operation of the admission request: CREATE, UPDATE, DELETE or CONNECT
+	Operation string `json:"operation,omitempty"`
+}
+
+// userInfoVariables are the variables resolved by the admission request context
+var userInfoVariables = []string{"request.userInfo", "request.roles", "request.clusterRoles", "request.operation", "serviceAccountName", "serviceAccountNamespace"}
+
+// GetUserInfoFromPath reads the admission request context from a file
+func GetUserInfoFromPath(fs billy.Filesystem, path string, isGit bool, policyResourcePath string) (*UserInfo, error) {
+	var yamlFile []byte
+	var err error
+	if isGit {
+		filep, err := fs.Open(filepath.Join(policyResourcePath, path))
+		if err != nil {
+			return nil, sanitizederror.NewWithError("unable to open user info file "+path, err)
+		}
+		defer filep.Close()
+
+		yamlFile, err = ioutil.ReadAll(filep)
+		if err != nil {
+			return nil, sanitizederror.NewWithError("unable to read user info file "+path, err)
+		}
+	} else {
+		yamlFile, err = ioutil.ReadFile(filepath.Join(policyResourcePath, path))
+		if err != nil {
+			return nil, sanitizederror.NewWithError("unable to read user info file "+path, err)
+		}
+	}
+
+	userInfoBytes, err := yaml.ToJSON(yamlFile)
+	if err != nil {
+		return nil, sanitizederror.NewWithError("failed to convert json", err)
+	}
+
+	userInfo := &UserInfo{}
+	if err := json.Unmarshal(userInfoBytes, userInfo); err != nil {
+		return nil, sanitizederror.NewWithError("failed to decode user info yaml", err)
+	}
+
+	userInfo.Operation = strings.ToUpper(userInfo.Operation)
+	return userInfo, nil
+}
+
+// AddToContext adds the request.userInfo, request.roles, request.clusterRoles, request.operation,
+// serviceAccountName and serviceAccountNamespace variables to the context
+func (u *UserInfo) AddToContext(ctx *context.Context) error {
+	if err := ctx.AddUserInfo(u.RequestInfo); err != nil {
+		return err
+	}
+
+	if err := ctx.AddServiceAccount(u.AdmissionUserInfo.Username); err != nil {
+		return err
+	}
+
+	if u.Operation == "" {
+		return nil
+	}
+
+	operation, err := json.Marshal(map[string]interface{}{
+		"request": map[string]string{"operation": u.Operation},
+	})
+	if err != nil {
+		return err
+	}
+	return ctx.AddJSON(operation)
+}
+
+// GetRequestInfo returns the request info of the admission request, the request info is empty
+// when no user info is given so that the user info of match and exclude blocks is ignored
+func (u *UserInfo) GetRequestInfo() v1.RequestInfo {
+	if u == nil {
+		return v1.RequestInfo{}
+	}
+	return u.RequestInfo
+}
+
+// RemoveUserInfoVariables removes the variables that are resolved by the admission request context
+func RemoveUserInfoVariables(matches [][]string) [][]string {
+	var remaining [][]string
+	for _, m := range matches {
+		if len(m) == 0 || !isUserInfoVariable(m[0]) {
+			remaining = append(remaining, m)
+		}
+	}
+	return remaining
+}
+
+func isUserInfoVariable(variable string) bool {
+	variable = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(variable, "{{"), "}}"))
+	for _, v := range userInfoVariables {
+		if variable == v || strings.HasPrefix(variable, v+".") || strings.HasPrefix(variable, v+"[") {
+			return true
+		}
+	}
+	return false
+}
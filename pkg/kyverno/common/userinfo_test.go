@@ -0,0 +1,58 @@
+package common
+
+import (
+	"testing"
+
+	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+	"gotest.tools/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+func Test_RemoveUserInfoVariables(t *testing.T) {
+	matches := [][]string{
+		{"{{request.userInfo.username}}"},
+		{"{{ request.operation }}"},
+		{"{{serviceAccountName}}"},
+		{"{{request.roles}}"},
+		{"{{request.object.metadata.name}}"},
+		{"{{request.operationType}}"},
+	}
+
+	remaining := RemoveUserInfoVariables(matches)
+	assert.DeepEqual(t, remaining, [][]string{
+		{"{{request.object.metadata.name}}"},
+		{"{{request.operationType}}"},
+	})
+}
+
+func Test_UserInfoAddToContext(t *testing.T) {
+	userInfo := &UserInfo{
+		RequestInfo: v1.RequestInfo{
+			Roles:        []string{"dev:builder"},
+			ClusterRoles: []string{"edit"},
+			AdmissionUserInfo: authenticationv1.UserInfo{
+				Username: "system:serviceaccount:dev:builder",
+			},
+		},
+		Operation: "UPDATE",
+	}
+
+	ctx := context.NewContext()
+	assert.NilError(t, userInfo.AddToContext(ctx))
+
+	for query, expected := range map[string]interface{}{
+		"request.operation":         "UPDATE",
+		"request.userInfo.username": "system:serviceaccount:dev:builder",
+		"request.clusterRoles[0]":   "edit",
+		"serviceAccountName":        "builder",
+		"serviceAccountNamespace":   "dev",
+	} {
+		value, err := ctx.Query(query)
+		assert.NilError(t, err)
+		assert.Equal(t, value, expected, query)
+	}
+
+	var noUserInfo *UserInfo
+	assert.DeepEqual(t, noUserInfo.GetRequestInfo(), v1.RequestInfo{})
+}
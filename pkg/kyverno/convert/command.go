@@ -0,0 +1,247 @@
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	client "github.com/kyverno/kyverno/pkg/dclient"
+	"github.com/kyverno/kyverno/pkg/kyverno/common"
+	sanitizederror "github.com/kyverno/kyverno/pkg/kyverno/sanitizedError"
+	"github.com/kyverno/kyverno/pkg/openapi"
+	policy2 "github.com/kyverno/kyverno/pkg/policy"
+	"github.com/spf13/cobra"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+)
+
+var pspHelp = `
+Converts PodSecurityPolicies to ClusterPolicies. The restrictions of a PodSecurityPolicy are converted to
+validate rules and its defaults, e.g. defaultAddCapabilities, to mutate rules. The fields that cannot be
+converted, or are converted in part, are reported on stderr.
+
+To convert the PodSecurityPolicies of files and directories:
+	kyverno convert psp /path/to/psp.yaml /path/to/folderOfPSPs > policies.yaml
+
+To convert the PodSecurityPolicies of a cluster and enforce the policies:
+	kyverno convert psp --cluster --validation-failure-action enforce --output policies.yaml
+`
+
+// Command returns the convert command
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "convert",
+		Short: "converts resources to Kyverno policies",
+	}
+
+	cmd.AddCommand(pspCommand())
+	return cmd
+}
+
+func pspCommand() *cobra.Command {
+	var outputPath, validationFailureAction string
+	var cluster bool
+	cmd := &cobra.Command{
+		Use:     "psp",
+		Short:   "converts PodSecurityPolicies to ClusterPolicies",
+		Example: pspHelp,
+		RunE: func(cmd *cobra.Command, paths []string) (err error) {
+			defer func() {
+				if err != nil {
+					if !sanitizederror.IsErrorSanitized(err) {
+						log.Log.Error(err, "failed to sanitize")
+						err = fmt.Errorf("internal error")
+					}
+				}
+			}()
+
+			if validationFailureAction != "audit" && validationFailureAction != "enforce" {
+				return sanitizederror.NewWithError(fmt.Sprintf("invalid validation failure action %s, supported actions are audit, enforce", validationFailureAction), nil)
+			}
+
+			if len(paths) == 0 && !cluster {
+				return sanitizederror.NewWithError("PodSecurityPolicy file(s) are required, or use --cluster", nil)
+			}
+
+			psps, err := loadPodSecurityPolicies(paths, cluster)
+			if err != nil {
+				return err
+			}
+
+			if len(psps) == 0 {
+				return sanitizederror.NewWithError("no PodSecurityPolicies found", nil)
+			}
+
+			conversions, err := convertPodSecurityPolicies(psps, validationFailureAction)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if outputPath != "" {
+				file, err := os.Create(outputPath)
+				if err != nil {
+					return sanitizederror.NewWithError(fmt.Sprintf("failed to create %s", outputPath), err)
+				}
+				defer file.Close()
+				out = file
+			}
+
+			if err := printPolicies(out, conversions); err != nil {
+				return sanitizederror.NewWithError("failed to print the policies", err)
+			}
+
+			printReport(cmd.ErrOrStderr(), conversions)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "File the policies are written to, the policies are printed when not set")
+	cmd.Flags().BoolVarP(&cluster, "cluster", "c", false, "Convert the PodSecurityPolicies of the cluster")
+	cmd.Flags().StringVar(&validationFailureAction, "validation-failure-action", "audit", "Validation failure action of the policies, one of: audit, enforce")
+	return cmd
+}
+
+func loadPodSecurityPolicies(paths []string, cluster bool) ([]*policyv1beta1.PodSecurityPolicy, error) {
+	var resources []*unstructured.Unstructured
+	if len(paths) > 0 {
+		fileResources, err := common.ReadResources(memfs.New(), paths, false, "")
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, fileResources...)
+	}
+
+	if cluster {
+		restConfig, err := genericclioptions.NewConfigFlags(true).ToRESTConfig()
+		if err != nil {
+			return nil, sanitizederror.NewWithError("failed to load the cluster configuration", err)
+		}
+
+		dClient, err := client.NewClient(restConfig, 15*time.Minute, make(chan struct{}), log.Log)
+		if err != nil {
+			return nil, sanitizederror.NewWithError("failed to create the cluster client", err)
+		}
+
+		list, err := dClient.ListResource("policy/v1beta1", "PodSecurityPolicy", "", nil)
+		if err != nil {
+			return nil, sanitizederror.NewWithError("failed to list the PodSecurityPolicies of the cluster", err)
+		}
+
+		for i := range list.Items {
+			resources = append(resources, &list.Items[i])
+		}
+	}
+
+	var psps []*policyv1beta1.PodSecurityPolicy
+	for _, resource := range resources {
+		if resource.GetKind() != "PodSecurityPolicy" {
+			continue
+		}
+
+		psp := &policyv1beta1.PodSecurityPolicy{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(resource.UnstructuredContent(), psp); err != nil {
+			return nil, sanitizederror.NewWithError(fmt.Sprintf("failed to decode the PodSecurityPolicy %s", resource.GetName()), err)
+		}
+		psps = append(psps, psp)
+	}
+	return psps, nil
+}
+
+// convertPodSecurityPolicies converts the PodSecurityPolicies and validates the policies
+func convertPodSecurityPolicies(psps []*policyv1beta1.PodSecurityPolicy, validationFailureAction string) ([]Conversion, error) {
+	openAPIController, err := openapi.NewOpenAPIController()
+	if err != nil {
+		return nil, sanitizederror.NewWithError("failed to initialize openAPIController", err)
+	}
+
+	var conversions []Conversion
+	for _, psp := range psps {
+		conversion, err := ConvertPodSecurityPolicy(psp, validationFailureAction)
+		if err != nil {
+			return nil, sanitizederror.NewWithError(fmt.Sprintf("failed to convert the PodSecurityPolicy %s", psp.GetName()), err)
+		}
+
+		if err := policy2.Validate(conversion.Policy, nil, true, openAPIController); err != nil {
+			return nil, sanitizederror.NewWithError(fmt.Sprintf("the policy converted from the PodSecurityPolicy %s is invalid", psp.GetName()), err)
+		}
+		conversions = append(conversions, conversion)
+	}
+	return conversions, nil
+}
+
+// policyYAML returns the YAML of the policy without its status, creation timestamp and the empty fields of the rules
+func policyYAML(policy *v1.ClusterPolicy) ([]byte, error) {
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj map[string]interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	delete(obj, "status")
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "creationTimestamp")
+	}
+
+	if spec, ok := obj["spec"].(map[string]interface{}); ok {
+		if rules, ok := spec["rules"].([]interface{}); ok {
+			for _, rule := range rules {
+				if rule, ok := rule.(map[string]interface{}); ok {
+					removeEmptyMaps(rule)
+				}
+			}
+		}
+	}
+	return yaml.Marshal(obj)
+}
+
+// removeEmptyMaps removes the fields of the map whose values are empty maps, after their own empty maps are removed
+func removeEmptyMaps(obj map[string]interface{}) {
+	for key, value := range obj {
+		if m, ok := value.(map[string]interface{}); ok {
+			removeEmptyMaps(m)
+			if len(m) == 0 {
+				delete(obj, key)
+			}
+		}
+	}
+}
+
+func printPolicies(out io.Writer, conversions []Conversion) error {
+	for i, conversion := range conversions {
+		data, err := policyYAML(conversion.Policy)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			fmt.Fprintln(out, "---")
+		}
+
+		if _, err := out.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printReport(out io.Writer, conversions []Conversion) {
+	for _, conversion := range conversions {
+		fmt.Fprintf(out, "converted the PodSecurityPolicy %s to a ClusterPolicy with %d rules\n", conversion.PodSecurityPolicy, len(conversion.Policy.Spec.Rules))
+		for _, field := range conversion.NotConverted {
+			fmt.Fprintf(out, "  not converted: %s\n", field)
+		}
+	}
+}
@@ -0,0 +1,679 @@
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// safeSysctls are the sysctls that pods can set without allowedUnsafeSysctls
+var safeSysctls = []string{
+	"kernel.shm_rmid_forced", "net.ipv4.ip_local_port_range", "net.ipv4.ip_unprivileged_port_start",
+	"net.ipv4.tcp_syncookies", "net.ipv4.ping_group_range",
+}
+
+// containerLists are the keys of the container lists in the patterns, the init and ephemeral containers are checked if there are any
+var containerLists = []string{"containers", "=(initContainers)", "=(ephemeralContainers)"}
+
+// allContainers is the JMESPath expression of the containers, init containers and ephemeral containers of the pod
+const allContainers = "request.object.spec.[containers, initContainers, ephemeralContainers][]"
+
+// profileAnnotations are the PodSecurityPolicy annotations of the seccomp and AppArmor profiles
+var profileAnnotations = []string{
+	"seccomp.security.alpha.kubernetes.io/allowedProfileNames",
+	"seccomp.security.alpha.kubernetes.io/defaultProfileName",
+	"apparmor.security.beta.kubernetes.io/allowedProfileNames",
+	"apparmor.security.beta.kubernetes.io/defaultProfileName",
+}
+
+// Conversion is a ClusterPolicy converted from a PodSecurityPolicy and the fields of the PodSecurityPolicy that
+// are not converted or are only converted in part
+type Conversion struct {
+	PodSecurityPolicy string            `json:"podSecurityPolicy"`
+	Policy            *v1.ClusterPolicy `json:"-"`
+	NotConverted      []string          `json:"notConverted,omitempty"`
+}
+
+type pspConverter struct {
+	spec policyv1beta1.PodSecurityPolicySpec
+	// podDefaults and containerDefaults are the patchStrategicMerge patterns of the pod and of each container that set the defaults
+	podDefaults       map[string]interface{}
+	containerDefaults map[string]interface{}
+	rules             []v1.Rule
+	notConverted      []string
+}
+
+// ConvertPodSecurityPolicy converts the PodSecurityPolicy to a ClusterPolicy with validate rules for the
+// restrictions and mutate rules for the defaults
+func ConvertPodSecurityPolicy(psp *policyv1beta1.PodSecurityPolicy, validationFailureAction string) (Conversion, error) {
+	c := &pspConverter{
+		spec:              psp.Spec,
+		podDefaults:       make(map[string]interface{}),
+		containerDefaults: make(map[string]interface{}),
+	}
+
+	c.convertPrivileged()
+	c.convertHostNamespaces()
+	c.convertHostPorts()
+	c.convertVolumes()
+	c.convertHostPaths()
+	c.convertFlexVolumes()
+	c.convertCSIDrivers()
+	c.convertReadOnlyRootFilesystem()
+	c.convertRunAsUser()
+	c.convertRunAsGroup()
+	c.convertSupplementalGroups()
+	c.convertFSGroup()
+	c.convertPrivilegeEscalation()
+	c.convertCapabilities()
+	c.convertSELinux()
+	c.convertProcMount()
+	c.convertSysctls()
+	c.convertRuntimeClass()
+
+	for _, annotation := range profileAnnotations {
+		if _, ok := psp.GetAnnotations()[annotation]; ok {
+			c.notConverted = append(c.notConverted, fmt.Sprintf("metadata.annotations[%s]: the profile annotations are not converted", annotation))
+		}
+	}
+
+	c.notConverted = append(c.notConverted, "the policy applies to all pods, the users and service accounts authorized to use the PodSecurityPolicy are not taken into account")
+
+	rules := c.defaultRules()
+	rules = append(rules, c.rules...)
+	background := true
+	policy := &v1.ClusterPolicy{
+		TypeMeta: metav1.TypeMeta{APIVersion: "kyverno.io/v1", Kind: "ClusterPolicy"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: psp.GetName(),
+			Annotations: map[string]string{
+				"policies.kyverno.io/title":       psp.GetName(),
+				"policies.kyverno.io/category":    "Pod Security",
+				"policies.kyverno.io/subject":     "Pod",
+				"policies.kyverno.io/description": fmt.Sprintf("Converted from the PodSecurityPolicy %s.", psp.GetName()),
+			},
+		},
+		Spec: v1.Spec{
+			ValidationFailureAction: validationFailureAction,
+			Background:              &background,
+			Rules:                   rules,
+		},
+	}
+
+	// the policy is decoded again so that it is the same as a policy read from its YAML
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return Conversion{}, err
+	}
+
+	decoded := &v1.ClusterPolicy{}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		return Conversion{}, err
+	}
+
+	return Conversion{PodSecurityPolicy: psp.GetName(), Policy: decoded, NotConverted: c.notConverted}, nil
+}
+
+func podRule(name string) v1.Rule {
+	return v1.Rule{
+		Name:           name,
+		MatchResources: v1.MatchResources{ResourceDescription: v1.ResourceDescription{Kinds: []string{"Pod"}}},
+	}
+}
+
+func (c *pspConverter) addPattern(name, message string, pattern map[string]interface{}) {
+	rule := podRule(name)
+	rule.Validation = v1.Validation{Message: message, Pattern: pattern}
+	c.rules = append(c.rules, rule)
+}
+
+func (c *pspConverter) addDeny(name, message, key string, operator v1.ConditionOperator, value interface{}) {
+	rule := podRule(name)
+	rule.Validation = v1.Validation{
+		Message: message,
+		Deny: &v1.Deny{
+			AnyAllConditions: map[string]interface{}{
+				"any": []interface{}{
+					map[string]interface{}{"key": key, "operator": operator, "value": value},
+				},
+			},
+		},
+	}
+	c.rules = append(c.rules, rule)
+}
+
+// containersPattern returns the pattern of the pod that checks each container with the container pattern
+func containersPattern(container map[string]interface{}) map[string]interface{} {
+	spec := make(map[string]interface{})
+	for _, list := range containerLists {
+		spec[list] = []interface{}{container}
+	}
+	return map[string]interface{}{"spec": spec}
+}
+
+// securityContextPattern returns the pattern of a securityContext that is checked if it is set
+func securityContextPattern(fields map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"=(securityContext)": fields}
+}
+
+// podAndContainersPattern returns the pattern that checks the securityContext of the pod and of each container
+func podAndContainersPattern(fields map[string]interface{}) map[string]interface{} {
+	pattern := containersPattern(securityContextPattern(fields))
+	pattern["spec"].(map[string]interface{})["=(securityContext)"] = fields
+	return pattern
+}
+
+// rangesPattern returns the pattern of a value in one of the ranges, e.g. >=1000 & <=2000 | 3000
+func rangesPattern(ranges [][2]int64) string {
+	var conditions []string
+	for _, r := range ranges {
+		if r[0] == r[1] {
+			conditions = append(conditions, fmt.Sprintf("%d", r[0]))
+			continue
+		}
+		conditions = append(conditions, fmt.Sprintf(">=%d & <=%d", r[0], r[1]))
+	}
+	return strings.Join(conditions, " | ")
+}
+
+// rangesText returns the ranges for the messages, e.g. 1000-2000, 3000
+func rangesText(ranges [][2]int64) string {
+	var texts []string
+	for _, r := range ranges {
+		if r[0] == r[1] {
+			texts = append(texts, fmt.Sprintf("%d", r[0]))
+			continue
+		}
+		texts = append(texts, fmt.Sprintf("%d-%d", r[0], r[1]))
+	}
+	return strings.Join(texts, ", ")
+}
+
+// rangesFilter returns the JMESPath filter of the values that are not in any of the ranges
+func rangesFilter(ranges [][2]int64) string {
+	var conditions []string
+	for _, r := range ranges {
+		conditions = append(conditions, fmt.Sprintf("(@ >= `%d` && @ <= `%d`)", r[0], r[1]))
+	}
+	return fmt.Sprintf("[?!(%s)]", strings.Join(conditions, " || "))
+}
+
+func idRanges(ranges []policyv1beta1.IDRange) [][2]int64 {
+	var result [][2]int64
+	for _, r := range ranges {
+		result = append(result, [2]int64{r.Min, r.Max})
+	}
+	return result
+}
+
+func (c *pspConverter) convertPrivileged() {
+	if c.spec.Privileged {
+		return
+	}
+
+	c.addPattern("disallow-privileged-containers", "Privileged containers are not allowed.",
+		containersPattern(securityContextPattern(map[string]interface{}{"=(privileged)": false})))
+}
+
+func (c *pspConverter) convertHostNamespaces() {
+	spec := make(map[string]interface{})
+	if !c.spec.HostNetwork {
+		spec["=(hostNetwork)"] = false
+	}
+
+	if !c.spec.HostPID {
+		spec["=(hostPID)"] = false
+	}
+
+	if !c.spec.HostIPC {
+		spec["=(hostIPC)"] = false
+	}
+
+	if len(spec) == 0 {
+		return
+	}
+
+	c.addPattern("disallow-host-namespaces", "Sharing the host network, PID or IPC namespaces is not allowed.", map[string]interface{}{"spec": spec})
+}
+
+func (c *pspConverter) convertHostPorts() {
+	if len(c.spec.HostPorts) == 0 {
+		c.addPattern("disallow-host-ports", "Host ports are not allowed.",
+			containersPattern(map[string]interface{}{"=(ports)": []interface{}{map[string]interface{}{"=(hostPort)": 0}}}))
+		return
+	}
+
+	var ranges [][2]int64
+	for _, r := range c.spec.HostPorts {
+		ranges = append(ranges, [2]int64{int64(r.Min), int64(r.Max)})
+	}
+
+	pattern := rangesPattern(ranges)
+	c.addPattern("restrict-host-ports", fmt.Sprintf("Host ports must be in %s.", rangesText(ranges)),
+		containersPattern(map[string]interface{}{"=(ports)": []interface{}{map[string]interface{}{"=(hostPort)": pattern}}}))
+}
+
+func (c *pspConverter) volumeAllowed(volume policyv1beta1.FSType) bool {
+	for _, v := range c.spec.Volumes {
+		if v == policyv1beta1.All || v == volume {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *pspConverter) convertVolumes() {
+	if c.volumeAllowed(policyv1beta1.All) {
+		return
+	}
+
+	// the keys of a volume are its name and its source
+	allowed := []interface{}{"name"}
+	var names []string
+	for _, volume := range c.spec.Volumes {
+		allowed = append(allowed, string(volume))
+		names = append(names, string(volume))
+	}
+
+	message := "Volumes are not allowed."
+	if len(names) > 0 {
+		message = fmt.Sprintf("Only the volume types %s are allowed.", strings.Join(names, ", "))
+	}
+
+	c.addDeny("restrict-volume-types", message, "{{ request.object.spec.volumes[].keys(@)[] || `[]` }}", v1.NotIn, allowed)
+}
+
+func (c *pspConverter) convertHostPaths() {
+	if len(c.spec.AllowedHostPaths) == 0 || !c.volumeAllowed(policyv1beta1.HostPath) {
+		return
+	}
+
+	var paths []string
+	for i, hostPath := range c.spec.AllowedHostPaths {
+		prefix := strings.TrimSuffix(hostPath.PathPrefix, "/")
+		if prefix == "" {
+			paths = append(paths, "/*")
+		} else {
+			paths = append(paths, prefix, prefix+"/*")
+		}
+
+		if hostPath.ReadOnly {
+			c.notConverted = append(c.notConverted, fmt.Sprintf("allowedHostPaths[%d].readOnly: the host path %s is allowed without requiring read-only mounts", i, hostPath.PathPrefix))
+		}
+	}
+
+	c.addPattern("restrict-host-paths", fmt.Sprintf("Only the host paths %s are allowed.", strings.Join(paths, ", ")),
+		map[string]interface{}{
+			"spec": map[string]interface{}{
+				"=(volumes)": []interface{}{
+					map[string]interface{}{"=(hostPath)": map[string]interface{}{"path": strings.Join(paths, " | ")}},
+				},
+			},
+		})
+}
+
+func (c *pspConverter) convertFlexVolumes() {
+	if len(c.spec.AllowedFlexVolumes) == 0 || !c.volumeAllowed(policyv1beta1.FlexVolume) {
+		return
+	}
+
+	var drivers []string
+	for _, volume := range c.spec.AllowedFlexVolumes {
+		drivers = append(drivers, volume.Driver)
+	}
+
+	c.addPattern("restrict-flex-volume-drivers", fmt.Sprintf("Only the flexVolume drivers %s are allowed.", strings.Join(drivers, ", ")),
+		map[string]interface{}{
+			"spec": map[string]interface{}{
+				"=(volumes)": []interface{}{
+					map[string]interface{}{"=(flexVolume)": map[string]interface{}{"driver": strings.Join(drivers, " | ")}},
+				},
+			},
+		})
+}
+
+func (c *pspConverter) convertCSIDrivers() {
+	if len(c.spec.AllowedCSIDrivers) == 0 || !c.volumeAllowed(policyv1beta1.CSI) {
+		return
+	}
+
+	var drivers []string
+	for _, driver := range c.spec.AllowedCSIDrivers {
+		drivers = append(drivers, driver.Name)
+	}
+
+	c.addPattern("restrict-csi-drivers", fmt.Sprintf("Only the CSI drivers %s are allowed.", strings.Join(drivers, ", ")),
+		map[string]interface{}{
+			"spec": map[string]interface{}{
+				"=(volumes)": []interface{}{
+					map[string]interface{}{"=(csi)": map[string]interface{}{"driver": strings.Join(drivers, " | ")}},
+				},
+			},
+		})
+}
+
+func (c *pspConverter) convertReadOnlyRootFilesystem() {
+	if !c.spec.ReadOnlyRootFilesystem {
+		return
+	}
+
+	c.addPattern("require-read-only-root-filesystem", "The root filesystem of the containers must be read-only.",
+		containersPattern(map[string]interface{}{"securityContext": map[string]interface{}{"readOnlyRootFilesystem": true}}))
+}
+
+func (c *pspConverter) convertRunAsUser() {
+	switch c.spec.RunAsUser.Rule {
+	case policyv1beta1.RunAsUserStrategyMustRunAsNonRoot:
+		// either the pod or each container runs as non-root, and no container runs as root
+		nonRootContainer := map[string]interface{}{"=(runAsNonRoot)": true, "=(runAsUser)": ">0"}
+		podNonRoot := containersPattern(securityContextPattern(nonRootContainer))
+		podNonRoot["spec"].(map[string]interface{})["securityContext"] = map[string]interface{}{"runAsNonRoot": true, "=(runAsUser)": ">0"}
+
+		containersNonRoot := containersPattern(map[string]interface{}{"securityContext": map[string]interface{}{"runAsNonRoot": true, "=(runAsUser)": ">0"}})
+		containersNonRoot["spec"].(map[string]interface{})["=(securityContext)"] = map[string]interface{}{"=(runAsUser)": ">0"}
+
+		rule := podRule("require-run-as-non-root")
+		rule.Validation = v1.Validation{
+			Message:    "Running as root is not allowed, set runAsNonRoot to true in the pod or in each container.",
+			AnyPattern: []interface{}{podNonRoot, containersNonRoot},
+		}
+		c.rules = append(c.rules, rule)
+	case policyv1beta1.RunAsUserStrategyMustRunAs:
+		c.convertIDRanges("runAsUser", "run-as-user", "user", c.spec.RunAsUser.Ranges, true)
+	}
+}
+
+func (c *pspConverter) convertRunAsGroup() {
+	if c.spec.RunAsGroup == nil {
+		return
+	}
+
+	switch c.spec.RunAsGroup.Rule {
+	case policyv1beta1.RunAsGroupStrategyMustRunAs:
+		c.convertIDRanges("runAsGroup", "run-as-group", "group", c.spec.RunAsGroup.Ranges, true)
+	case policyv1beta1.RunAsGroupStrategyMayRunAs:
+		c.convertIDRanges("runAsGroup", "run-as-group", "group", c.spec.RunAsGroup.Ranges, false)
+	}
+}
+
+// convertIDRanges adds the rule that checks the field of the pod and of the containers, and the default of the pod when the field is required
+func (c *pspConverter) convertIDRanges(field, name, description string, ranges []policyv1beta1.IDRange, required bool) {
+	if len(ranges) == 0 {
+		c.notConverted = append(c.notConverted, fmt.Sprintf("%s: the rule requires ranges", field))
+		return
+	}
+
+	pattern := rangesPattern(idRanges(ranges))
+	c.addPattern("restrict-"+name, fmt.Sprintf("The %s must be in %s.", description, rangesText(idRanges(ranges))),
+		podAndContainersPattern(map[string]interface{}{"=(" + field + ")": pattern}))
+
+	if required {
+		c.podDefaults["+("+field+")"] = ranges[0].Min
+	}
+}
+
+func (c *pspConverter) convertSupplementalGroups() {
+	rule := c.spec.SupplementalGroups.Rule
+	if rule != policyv1beta1.SupplementalGroupsStrategyMustRunAs && rule != policyv1beta1.SupplementalGroupsStrategyMayRunAs {
+		return
+	}
+
+	ranges := c.spec.SupplementalGroups.Ranges
+	if len(ranges) == 0 {
+		c.notConverted = append(c.notConverted, "supplementalGroups: the rule requires ranges")
+		return
+	}
+
+	c.addDeny("restrict-supplemental-groups", fmt.Sprintf("The supplemental groups must be in %s.", rangesText(idRanges(ranges))),
+		fmt.Sprintf("{{ request.object.spec.securityContext.supplementalGroups%s || `[]` | length(@) }}", rangesFilter(idRanges(ranges))), v1.GreaterThan, 0)
+
+	if rule == policyv1beta1.SupplementalGroupsStrategyMustRunAs {
+		c.podDefaults["+(supplementalGroups)"] = []interface{}{ranges[0].Min}
+	}
+}
+
+func (c *pspConverter) convertFSGroup() {
+	rule := c.spec.FSGroup.Rule
+	if rule != policyv1beta1.FSGroupStrategyMustRunAs && rule != policyv1beta1.FSGroupStrategyMayRunAs {
+		return
+	}
+
+	ranges := c.spec.FSGroup.Ranges
+	if len(ranges) == 0 {
+		c.notConverted = append(c.notConverted, "fsGroup: the rule requires ranges")
+		return
+	}
+
+	pattern := rangesPattern(idRanges(ranges))
+	c.addPattern("restrict-fs-group", fmt.Sprintf("The fsGroup must be in %s.", rangesText(idRanges(ranges))),
+		map[string]interface{}{"spec": securityContextPattern(map[string]interface{}{"=(fsGroup)": pattern})})
+
+	if rule == policyv1beta1.FSGroupStrategyMustRunAs {
+		c.podDefaults["+(fsGroup)"] = ranges[0].Min
+	}
+}
+
+func (c *pspConverter) convertPrivilegeEscalation() {
+	if c.spec.AllowPrivilegeEscalation != nil && !*c.spec.AllowPrivilegeEscalation {
+		c.addPattern("disallow-privilege-escalation", "Privilege escalation is not allowed, set allowPrivilegeEscalation to false.",
+			containersPattern(securityContextPattern(map[string]interface{}{"=(allowPrivilegeEscalation)": false})))
+	}
+
+	if c.spec.DefaultAllowPrivilegeEscalation != nil {
+		c.containerDefaultSecurityContext()["+(allowPrivilegeEscalation)"] = *c.spec.DefaultAllowPrivilegeEscalation
+	}
+}
+
+func (c *pspConverter) containerDefaultSecurityContext() map[string]interface{} {
+	if _, ok := c.containerDefaults["securityContext"]; !ok {
+		c.containerDefaults["securityContext"] = make(map[string]interface{})
+	}
+	return c.containerDefaults["securityContext"].(map[string]interface{})
+}
+
+func capabilityNames(capabilities []corev1.Capability) []string {
+	var names []string
+	for _, capability := range capabilities {
+		names = append(names, string(capability))
+	}
+	return names
+}
+
+func (c *pspConverter) convertCapabilities() {
+	if drop := capabilityNames(c.spec.RequiredDropCapabilities); len(drop) > 0 {
+		// a container drops a capability when it drops the capability or ALL
+		var missing []string
+		for _, capability := range drop {
+			dropped := fmt.Sprintf("@ == '%s'", capability)
+			if capability != "ALL" {
+				dropped += " || @ == 'ALL'"
+			}
+			missing = append(missing, fmt.Sprintf("length((securityContext.capabilities.drop || `[]`)[?%s]) == `0`", dropped))
+		}
+
+		c.addDeny("require-drop-capabilities", fmt.Sprintf("The containers must drop the capabilities %s.", strings.Join(drop, ", ")),
+			fmt.Sprintf("{{ %s || `[]` | [?%s] | length(@) }}", allContainers, strings.Join(missing, " || ")), v1.GreaterThan, 0)
+		c.notConverted = append(c.notConverted, "requiredDropCapabilities: the capabilities are required in the containers, they are not added to the containers")
+	}
+
+	// the default capabilities are allowed to be added
+	allowed := capabilityNames(c.spec.AllowedCapabilities)
+	for _, capability := range capabilityNames(c.spec.DefaultAddCapabilities) {
+		if !utils.ContainsString(allowed, capability) {
+			allowed = append(allowed, capability)
+		}
+	}
+
+	allowAll := false
+	for _, capability := range allowed {
+		allowAll = allowAll || capability == string(policyv1beta1.AllowAllCapabilities)
+	}
+
+	if !allowAll {
+		values := []interface{}{}
+		for _, capability := range allowed {
+			values = append(values, capability)
+		}
+
+		message := "Adding capabilities is not allowed."
+		if len(allowed) > 0 {
+			message = fmt.Sprintf("Only the capabilities %s can be added.", strings.Join(allowed, ", "))
+		}
+
+		c.addDeny("restrict-added-capabilities", message,
+			fmt.Sprintf("{{ %s | [].securityContext.capabilities.add[] || `[]` }}", allContainers), v1.NotIn, values)
+	}
+
+	if add := capabilityNames(c.spec.DefaultAddCapabilities); len(add) > 0 {
+		values := []interface{}{}
+		for _, capability := range add {
+			values = append(values, capability)
+		}
+		c.containerDefaultSecurityContext()["capabilities"] = map[string]interface{}{"+(add)": values}
+		c.notConverted = append(c.notConverted, "defaultAddCapabilities: the capabilities are only added to the containers that do not add capabilities")
+	}
+}
+
+func (c *pspConverter) convertSELinux() {
+	if c.spec.SELinux.Rule != policyv1beta1.SELinuxStrategyMustRunAs || c.spec.SELinux.SELinuxOptions == nil {
+		return
+	}
+
+	options := c.spec.SELinux.SELinuxOptions
+	fields := make(map[string]interface{})
+	for key, value := range map[string]string{"user": options.User, "role": options.Role, "type": options.Type, "level": options.Level} {
+		if value != "" {
+			fields["=("+key+")"] = value
+		}
+	}
+
+	if len(fields) == 0 {
+		return
+	}
+
+	c.addPattern("restrict-selinux-options", "The SELinux options must be the options of the PodSecurityPolicy.",
+		podAndContainersPattern(map[string]interface{}{"=(seLinuxOptions)": fields}))
+	c.notConverted = append(c.notConverted, "seLinux.seLinuxOptions: the options are checked when they are set, they are not set as defaults")
+}
+
+func (c *pspConverter) convertProcMount() {
+	for _, procMount := range c.spec.AllowedProcMountTypes {
+		if procMount == corev1.UnmaskedProcMount {
+			return
+		}
+	}
+
+	c.addPattern("restrict-proc-mount", "Only the Default proc mount type is allowed.",
+		containersPattern(securityContextPattern(map[string]interface{}{"=(procMount)": string(corev1.DefaultProcMount)})))
+}
+
+func sysctlsPattern(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"spec": securityContextPattern(map[string]interface{}{
+			"=(sysctls)": []interface{}{map[string]interface{}{"name": name}},
+		}),
+	}
+}
+
+func (c *pspConverter) convertSysctls() {
+	if len(c.spec.ForbiddenSysctls) > 0 {
+		var conditions []string
+		for _, sysctl := range c.spec.ForbiddenSysctls {
+			conditions = append(conditions, "!"+sysctl)
+		}
+
+		c.addPattern("disallow-forbidden-sysctls", fmt.Sprintf("The sysctls %s are not allowed.", strings.Join(c.spec.ForbiddenSysctls, ", ")),
+			sysctlsPattern(strings.Join(conditions, " & ")))
+	}
+
+	for _, sysctl := range c.spec.AllowedUnsafeSysctls {
+		if sysctl == "*" {
+			return
+		}
+	}
+
+	message := "Only the safe sysctls are allowed."
+	if len(c.spec.AllowedUnsafeSysctls) > 0 {
+		message = fmt.Sprintf("Only the safe sysctls and the sysctls %s are allowed.", strings.Join(c.spec.AllowedUnsafeSysctls, ", "))
+	}
+
+	allowed := append(append([]string{}, safeSysctls...), c.spec.AllowedUnsafeSysctls...)
+	c.addPattern("restrict-unsafe-sysctls", message, sysctlsPattern(strings.Join(allowed, " | ")))
+}
+
+func (c *pspConverter) convertRuntimeClass() {
+	if c.spec.RuntimeClass == nil {
+		return
+	}
+
+	names := c.spec.RuntimeClass.AllowedRuntimeClassNames
+	allowAll := false
+	for _, name := range names {
+		allowAll = allowAll || name == policyv1beta1.AllowAllRuntimeClassNames
+	}
+
+	if !allowAll {
+		message := "Setting a runtime class is not allowed."
+		pattern := map[string]interface{}{"spec": map[string]interface{}{"=(runtimeClassName)": ""}}
+		if len(names) > 0 {
+			message = fmt.Sprintf("Only the runtime classes %s are allowed.", strings.Join(names, ", "))
+			pattern = map[string]interface{}{"spec": map[string]interface{}{"=(runtimeClassName)": strings.Join(names, " | ")}}
+		}
+		c.addPattern("restrict-runtime-class", message, pattern)
+	}
+
+	if c.spec.RuntimeClass.DefaultRuntimeClassName != nil {
+		c.podDefaults["+(runtimeClassName)"] = *c.spec.RuntimeClass.DefaultRuntimeClassName
+	}
+}
+
+// defaultRules returns the mutate rules that set the defaults of the pod and of the containers
+func (c *pspConverter) defaultRules() []v1.Rule {
+	var rules []v1.Rule
+	spec := make(map[string]interface{})
+	for key, value := range c.podDefaults {
+		if key == "+(runtimeClassName)" {
+			spec[key] = value
+			continue
+		}
+
+		if _, ok := spec["securityContext"]; !ok {
+			spec["securityContext"] = make(map[string]interface{})
+		}
+		spec["securityContext"].(map[string]interface{})[key] = value
+	}
+
+	container := map[string]interface{}{"(name)": "*"}
+	for key, value := range c.containerDefaults {
+		container[key] = value
+	}
+
+	if len(c.containerDefaults) > 0 {
+		spec["containers"] = []interface{}{container}
+	}
+
+	if len(spec) > 0 {
+		rule := podRule("set-defaults")
+		rule.Mutation = v1.Mutation{PatchStrategicMerge: map[string]interface{}{"spec": spec}}
+		rules = append(rules, rule)
+	}
+
+	if len(c.containerDefaults) > 0 {
+		// the init containers are patched in a rule of their own so that the list is not added to pods without init containers
+		rule := podRule("set-init-container-defaults")
+		rule.AnyAllConditions = map[string]interface{}{
+			"any": []interface{}{
+				map[string]interface{}{"key": "{{ request.object.spec.initContainers || `[]` | length(@) }}", "operator": v1.GreaterThan, "value": 0},
+			},
+		}
+		rule.Mutation = v1.Mutation{PatchStrategicMerge: map[string]interface{}{"spec": map[string]interface{}{"initContainers": []interface{}{container}}}}
+		rules = append(rules, rule)
+	}
+	return rules
+}
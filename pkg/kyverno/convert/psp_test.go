@@ -0,0 +1,70 @@
+package convert
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/openapi"
+	policy2 "github.com/kyverno/kyverno/pkg/policy"
+	"gotest.tools/assert"
+)
+
+func Test_ConvertPodSecurityPolicy(t *testing.T) {
+	openAPIController, err := openapi.NewOpenAPIController()
+	assert.NilError(t, err)
+
+	testcases := []struct {
+		fixture      string
+		notConverted []string
+	}{
+		{
+			fixture: "psp-restricted",
+			notConverted: []string{
+				"requiredDropCapabilities",
+				"metadata.annotations[seccomp.security.alpha.kubernetes.io/allowedProfileNames]",
+				"the users and service accounts",
+			},
+		},
+		{
+			fixture: "psp-defaults",
+			notConverted: []string{
+				"allowedHostPaths[0].readOnly",
+				"defaultAddCapabilities",
+				"metadata.annotations[apparmor.security.beta.kubernetes.io/defaultProfileName]",
+				"the users and service accounts",
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		dir := filepath.Join("..", "..", "..", "test", "cli", "test", tc.fixture)
+		psps, err := loadPodSecurityPolicies([]string{filepath.Join(dir, "psp.yaml")}, false)
+		assert.NilError(t, err)
+		assert.Equal(t, len(psps), 1)
+
+		conversion, err := ConvertPodSecurityPolicy(psps[0], "audit")
+		assert.NilError(t, err)
+		assert.NilError(t, policy2.Validate(conversion.Policy, nil, true, openAPIController), tc.fixture)
+
+		// the policy of the fixture is the expected output of the command
+		data, err := policyYAML(conversion.Policy)
+		assert.NilError(t, err)
+		expected, err := ioutil.ReadFile(filepath.Join(dir, "policy.yaml"))
+		assert.NilError(t, err)
+		assert.Equal(t, string(data), string(expected), tc.fixture)
+
+		report := strings.Join(conversion.NotConverted, "\n")
+		for _, field := range tc.notConverted {
+			assert.Assert(t, strings.Contains(report, field), "%s: %s is not reported in\n%s", tc.fixture, field, report)
+		}
+	}
+}
+
+func Test_LoadPodSecurityPolicies_SkipsOtherKinds(t *testing.T) {
+	dir := filepath.Join("..", "..", "..", "test", "cli", "test", "psp-defaults")
+	psps, err := loadPodSecurityPolicies([]string{filepath.Join(dir, "resources.yaml")}, false)
+	assert.NilError(t, err)
+	assert.Equal(t, len(psps), 0)
+}
@@ -109,6 +109,366 @@ const PolicyCRD = `
 						  "exclude": {
 							"description": "ExcludeResources defines when this policy rule should not be applied. The exclude criteria can include resource information (e.g. kind, name, namespace, labels) and admission review request information like the name or role.",
 							"properties": {
+							  "all": {
+								"description": "All allows specifying resources which will be ANDed. Cannot be used together with the resources and user info fields.",
+								"items": {
+								  "schema": {
+									"description": "ResourceFilter allow users to \"AND\" or \"OR\" between resources",
+									"properties": {
+									  "clusterRoles": {
+										"description": "ClusterRoles is the list of cluster-wide role names for the user.",
+										"items": {
+										  "schema": {
+											"type": "string"
+										  }
+										},
+										"type": "array"
+									  },
+									  "resources": {
+										"description": "ResourceDescription contains information about the resource being created or modified.",
+										"properties": {
+										  "annotations": {
+											"description": "Annotations is a  map of annotations (key-value pairs of type string). Annotation keys and values support the wildcard characters \"*\" (matches zero or many characters) and \"?\" (matches at least one character).",
+											"type": "object"
+										  },
+										  "kinds": {
+											"description": "Kinds is a list of resource kinds. Kinds can be qualified as version/Kind, group/version/Kind or group/*/Kind, and \"*\" matches all kinds. Subresources can be matched using the form Kind/subresource, e.g. Pod/exec.",
+											"items": {
+											  "schema": {
+												"type": "string"
+											  }
+											},
+											"type": "array"
+										  },
+										  "name": {
+											"description": "Name is the name of the resource. The name supports wildcard characters \"*\" (matches zero or many characters) and \"?\" (at least one character).",
+											"type": "string"
+										  },
+										  "namespaceSelector": {
+											"description": "NamespaceSelector is a label selector for the resource namespace. Label keys and values in 'matchLabels' support the wildcard characters '*' (matches zero or many characters) and '?' (matches one character).Wildcards allows writing label selectors like [\"storage.k8s.io/*\": \"*\"]. Note that using [\"*\" : \"*\"] matches any key and value but does not match an empty label set.",
+											"properties": {
+											  "matchExpressions": {
+												"description": "matchExpressions is a list of label selector requirements. The requirements are ANDed.",
+												"items": {
+												  "schema": {
+													"description": "A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
+													"properties": {
+													  "key": {
+														"description": "key is the label key that the selector applies to.",
+														"type": "string"
+													  },
+													  "operator": {
+														"description": "operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.",
+														"type": "string"
+													  },
+													  "values": {
+														"description": "values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.",
+														"items": {
+														  "schema": {
+															"type": "string"
+														  }
+														},
+														"type": "array"
+													  }
+													},
+													"required": [
+													  "key",
+													  "operator"
+													],
+													"type": "object"
+												  }
+												},
+												"type": "array"
+											  },
+											  "matchLabels": {
+												"description": "matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is \"key\", the operator is \"In\", and the values array contains only \"value\". The requirements are ANDed.",
+												"type": "object"
+											  }
+											},
+											"type": "object"
+										  },
+										  "namespaces": {
+											"description": "Namespaces is a list of namespaces names. Each name supports wildcard characters \"*\" (matches zero or many characters) and \"?\" (at least one character).",
+											"items": {
+											  "schema": {
+												"type": "string"
+											  }
+											},
+											"type": "array"
+										  },
+										  "selector": {
+											"description": "Selector is a label selector. Label keys and values in 'matchLabels' support the wildcard characters '*' (matches zero or many characters) and '?' (matches one character). Wildcards allows writing label selectors like [\"storage.k8s.io/*\": \"*\"]. Note that using [\"*\" : \"*\"] matches any key and value but does not match an empty label set.",
+											"properties": {
+											  "matchExpressions": {
+												"description": "matchExpressions is a list of label selector requirements. The requirements are ANDed.",
+												"items": {
+												  "schema": {
+													"description": "A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
+													"properties": {
+													  "key": {
+														"description": "key is the label key that the selector applies to.",
+														"type": "string"
+													  },
+													  "operator": {
+														"description": "operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.",
+														"type": "string"
+													  },
+													  "values": {
+														"description": "values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.",
+														"items": {
+														  "schema": {
+															"type": "string"
+														  }
+														},
+														"type": "array"
+													  }
+													},
+													"required": [
+													  "key",
+													  "operator"
+													],
+													"type": "object"
+												  }
+												},
+												"type": "array"
+											  },
+											  "matchLabels": {
+												"description": "matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is \"key\", the operator is \"In\", and the values array contains only \"value\". The requirements are ANDed.",
+												"type": "object"
+											  }
+											},
+											"type": "object"
+										  }
+										},
+										"type": "object"
+									  },
+									  "roles": {
+										"description": "Roles is the list of namespaced role names for the user.",
+										"items": {
+										  "schema": {
+											"type": "string"
+										  }
+										},
+										"type": "array"
+									  },
+									  "subjects": {
+										"description": "Subjects is the list of subject names like users, user groups, and service accounts.",
+										"items": {
+										  "schema": {
+											"description": "Subject contains a reference to the object or user identities a role binding applies to.  This can either hold a direct API object reference, or a value for non-objects such as user and group names.",
+											"properties": {
+											  "apiGroup": {
+												"description": "APIGroup holds the API group of the referenced subject. Defaults to \"\" for ServiceAccount subjects. Defaults to \"rbac.authorization.k8s.io\" for User and Group subjects.",
+												"type": "string"
+											  },
+											  "kind": {
+												"description": "Kind of object being referenced. Values defined by this API group are \"User\", \"Group\", and \"ServiceAccount\". If the Authorizer does not recognized the kind value, the Authorizer should report an error.",
+												"type": "string"
+											  },
+											  "name": {
+												"description": "Name of the object being referenced.",
+												"type": "string"
+											  },
+											  "namespace": {
+												"description": "Namespace of the referenced object.  If the object kind is non-namespace, such as \"User\" or \"Group\", and this value is not empty the Authorizer should report an error.",
+												"type": "string"
+											  }
+											},
+											"required": [
+											  "kind",
+											  "name"
+											],
+											"type": "object"
+										  }
+										},
+										"type": "array"
+									  }
+									},
+									"type": "object"
+								  }
+								},
+								"type": "array"
+							  },
+							  "any": {
+								"description": "Any allows specifying resources which will be ORed. Cannot be used together with the resources and user info fields.",
+								"items": {
+								  "schema": {
+									"description": "ResourceFilter allow users to \"AND\" or \"OR\" between resources",
+									"properties": {
+									  "clusterRoles": {
+										"description": "ClusterRoles is the list of cluster-wide role names for the user.",
+										"items": {
+										  "schema": {
+											"type": "string"
+										  }
+										},
+										"type": "array"
+									  },
+									  "resources": {
+										"description": "ResourceDescription contains information about the resource being created or modified.",
+										"properties": {
+										  "annotations": {
+											"description": "Annotations is a  map of annotations (key-value pairs of type string). Annotation keys and values support the wildcard characters \"*\" (matches zero or many characters) and \"?\" (matches at least one character).",
+											"type": "object"
+										  },
+										  "kinds": {
+											"description": "Kinds is a list of resource kinds. Kinds can be qualified as version/Kind, group/version/Kind or group/*/Kind, and \"*\" matches all kinds. Subresources can be matched using the form Kind/subresource, e.g. Pod/exec.",
+											"items": {
+											  "schema": {
+												"type": "string"
+											  }
+											},
+											"type": "array"
+										  },
+										  "name": {
+											"description": "Name is the name of the resource. The name supports wildcard characters \"*\" (matches zero or many characters) and \"?\" (at least one character).",
+											"type": "string"
+										  },
+										  "namespaceSelector": {
+											"description": "NamespaceSelector is a label selector for the resource namespace. Label keys and values in 'matchLabels' support the wildcard characters '*' (matches zero or many characters) and '?' (matches one character).Wildcards allows writing label selectors like [\"storage.k8s.io/*\": \"*\"]. Note that using [\"*\" : \"*\"] matches any key and value but does not match an empty label set.",
+											"properties": {
+											  "matchExpressions": {
+												"description": "matchExpressions is a list of label selector requirements. The requirements are ANDed.",
+												"items": {
+												  "schema": {
+													"description": "A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
+													"properties": {
+													  "key": {
+														"description": "key is the label key that the selector applies to.",
+														"type": "string"
+													  },
+													  "operator": {
+														"description": "operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.",
+														"type": "string"
+													  },
+													  "values": {
+														"description": "values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.",
+														"items": {
+														  "schema": {
+															"type": "string"
+														  }
+														},
+														"type": "array"
+													  }
+													},
+													"required": [
+													  "key",
+													  "operator"
+													],
+													"type": "object"
+												  }
+												},
+												"type": "array"
+											  },
+											  "matchLabels": {
+												"description": "matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is \"key\", the operator is \"In\", and the values array contains only \"value\". The requirements are ANDed.",
+												"type": "object"
+											  }
+											},
+											"type": "object"
+										  },
+										  "namespaces": {
+											"description": "Namespaces is a list of namespaces names. Each name supports wildcard characters \"*\" (matches zero or many characters) and \"?\" (at least one character).",
+											"items": {
+											  "schema": {
+												"type": "string"
+											  }
+											},
+											"type": "array"
+										  },
+										  "selector": {
+											"description": "Selector is a label selector. Label keys and values in 'matchLabels' support the wildcard characters '*' (matches zero or many characters) and '?' (matches one character). Wildcards allows writing label selectors like [\"storage.k8s.io/*\": \"*\"]. Note that using [\"*\" : \"*\"] matches any key and value but does not match an empty label set.",
+											"properties": {
+											  "matchExpressions": {
+												"description": "matchExpressions is a list of label selector requirements. The requirements are ANDed.",
+												"items": {
+												  "schema": {
+													"description": "A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
+													"properties": {
+													  "key": {
+														"description": "key is the label key that the selector applies to.",
+														"type": "string"
+													  },
+													  "operator": {
+														"description": "operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.",
+														"type": "string"
+													  },
+													  "values": {
+														"description": "values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.",
+														"items": {
+														  "schema": {
+															"type": "string"
+														  }
+														},
+														"type": "array"
+													  }
+													},
+													"required": [
+													  "key",
+													  "operator"
+													],
+													"type": "object"
+												  }
+												},
+												"type": "array"
+											  },
+											  "matchLabels": {
+												"description": "matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is \"key\", the operator is \"In\", and the values array contains only \"value\". The requirements are ANDed.",
+												"type": "object"
+											  }
+											},
+											"type": "object"
+										  }
+										},
+										"type": "object"
+									  },
+									  "roles": {
+										"description": "Roles is the list of namespaced role names for the user.",
+										"items": {
+										  "schema": {
+											"type": "string"
+										  }
+										},
+										"type": "array"
+									  },
+									  "subjects": {
+										"description": "Subjects is the list of subject names like users, user groups, and service accounts.",
+										"items": {
+										  "schema": {
+											"description": "Subject contains a reference to the object or user identities a role binding applies to.  This can either hold a direct API object reference, or a value for non-objects such as user and group names.",
+											"properties": {
+											  "apiGroup": {
+												"description": "APIGroup holds the API group of the referenced subject. Defaults to \"\" for ServiceAccount subjects. Defaults to \"rbac.authorization.k8s.io\" for User and Group subjects.",
+												"type": "string"
+											  },
+											  "kind": {
+												"description": "Kind of object being referenced. Values defined by this API group are \"User\", \"Group\", and \"ServiceAccount\". If the Authorizer does not recognized the kind value, the Authorizer should report an error.",
+												"type": "string"
+											  },
+											  "name": {
+												"description": "Name of the object being referenced.",
+												"type": "string"
+											  },
+											  "namespace": {
+												"description": "Namespace of the referenced object.  If the object kind is non-namespace, such as \"User\" or \"Group\", and this value is not empty the Authorizer should report an error.",
+												"type": "string"
+											  }
+											},
+											"required": [
+											  "kind",
+											  "name"
+											],
+											"type": "object"
+										  }
+										},
+										"type": "array"
+									  }
+									},
+									"type": "object"
+								  }
+								},
+								"type": "array"
+							  },
 							  "clusterRoles": {
 								"description": "ClusterRoles is the list of cluster-wide role names for the user.",
 								"items": {
@@ -126,7 +486,7 @@ const PolicyCRD = `
 									"type": "object"
 								  },
 								  "kinds": {
-									"description": "Kinds is a list of resource kinds.",
+									"description": "Kinds is a list of resource kinds. Kinds can be qualified as version/Kind, group/version/Kind or group/*/Kind, and \"*\" matches all kinds. Subresources can be matched using the form Kind/subresource, e.g. Pod/exec.",
 									"items": {
 									  "schema": {
 										"type": "string"
@@ -327,6 +687,366 @@ const PolicyCRD = `
 						  "match": {
 							"description": "MatchResources defines when this policy rule should be applied. The match criteria can include resource information (e.g. kind, name, namespace, labels) and admission review request information like the user name or role. At least one kind is required.",
 							"properties": {
+							  "all": {
+								"description": "All allows specifying resources which will be ANDed. Cannot be used together with the resources and user info fields.",
+								"items": {
+								  "schema": {
+									"description": "ResourceFilter allow users to \"AND\" or \"OR\" between resources",
+									"properties": {
+									  "clusterRoles": {
+										"description": "ClusterRoles is the list of cluster-wide role names for the user.",
+										"items": {
+										  "schema": {
+											"type": "string"
+										  }
+										},
+										"type": "array"
+									  },
+									  "resources": {
+										"description": "ResourceDescription contains information about the resource being created or modified. Requires at least one tag to be specified when under MatchResources.",
+										"properties": {
+										  "annotations": {
+											"description": "Annotations is a  map of annotations (key-value pairs of type string). Annotation keys and values support the wildcard characters \"*\" (matches zero or many characters) and \"?\" (matches at least one character).",
+											"type": "object"
+										  },
+										  "kinds": {
+											"description": "Kinds is a list of resource kinds. Kinds can be qualified as version/Kind, group/version/Kind or group/*/Kind, and \"*\" matches all kinds. Subresources can be matched using the form Kind/subresource, e.g. Pod/exec.",
+											"items": {
+											  "schema": {
+												"type": "string"
+											  }
+											},
+											"type": "array"
+										  },
+										  "name": {
+											"description": "Name is the name of the resource. The name supports wildcard characters \"*\" (matches zero or many characters) and \"?\" (at least one character).",
+											"type": "string"
+										  },
+										  "namespaceSelector": {
+											"description": "NamespaceSelector is a label selector for the resource namespace. Label keys and values in 'matchLabels' support the wildcard characters '*' (matches zero or many characters) and '?' (matches one character).Wildcards allows writing label selectors like [\"storage.k8s.io/*\": \"*\"]. Note that using [\"*\" : \"*\"] matches any key and value but does not match an empty label set.",
+											"properties": {
+											  "matchExpressions": {
+												"description": "matchExpressions is a list of label selector requirements. The requirements are ANDed.",
+												"items": {
+												  "schema": {
+													"description": "A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
+													"properties": {
+													  "key": {
+														"description": "key is the label key that the selector applies to.",
+														"type": "string"
+													  },
+													  "operator": {
+														"description": "operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.",
+														"type": "string"
+													  },
+													  "values": {
+														"description": "values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.",
+														"items": {
+														  "schema": {
+															"type": "string"
+														  }
+														},
+														"type": "array"
+													  }
+													},
+													"required": [
+													  "key",
+													  "operator"
+													],
+													"type": "object"
+												  }
+												},
+												"type": "array"
+											  },
+											  "matchLabels": {
+												"description": "matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is \"key\", the operator is \"In\", and the values array contains only \"value\". The requirements are ANDed.",
+												"type": "object"
+											  }
+											},
+											"type": "object"
+										  },
+										  "namespaces": {
+											"description": "Namespaces is a list of namespaces names. Each name supports wildcard characters \"*\" (matches zero or many characters) and \"?\" (at least one character).",
+											"items": {
+											  "schema": {
+												"type": "string"
+											  }
+											},
+											"type": "array"
+										  },
+										  "selector": {
+											"description": "Selector is a label selector. Label keys and values in 'matchLabels' support the wildcard characters '*' (matches zero or many characters) and '?' (matches one character). Wildcards allows writing label selectors like [\"storage.k8s.io/*\": \"*\"]. Note that using [\"*\" : \"*\"] matches any key and value but does not match an empty label set.",
+											"properties": {
+											  "matchExpressions": {
+												"description": "matchExpressions is a list of label selector requirements. The requirements are ANDed.",
+												"items": {
+												  "schema": {
+													"description": "A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
+													"properties": {
+													  "key": {
+														"description": "key is the label key that the selector applies to.",
+														"type": "string"
+													  },
+													  "operator": {
+														"description": "operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.",
+														"type": "string"
+													  },
+													  "values": {
+														"description": "values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.",
+														"items": {
+														  "schema": {
+															"type": "string"
+														  }
+														},
+														"type": "array"
+													  }
+													},
+													"required": [
+													  "key",
+													  "operator"
+													],
+													"type": "object"
+												  }
+												},
+												"type": "array"
+											  },
+											  "matchLabels": {
+												"description": "matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is \"key\", the operator is \"In\", and the values array contains only \"value\". The requirements are ANDed.",
+												"type": "object"
+											  }
+											},
+											"type": "object"
+										  }
+										},
+										"type": "object"
+									  },
+									  "roles": {
+										"description": "Roles is the list of namespaced role names for the user.",
+										"items": {
+										  "schema": {
+											"type": "string"
+										  }
+										},
+										"type": "array"
+									  },
+									  "subjects": {
+										"description": "Subjects is the list of subject names like users, user groups, and service accounts.",
+										"items": {
+										  "schema": {
+											"description": "Subject contains a reference to the object or user identities a role binding applies to.  This can either hold a direct API object reference, or a value for non-objects such as user and group names.",
+											"properties": {
+											  "apiGroup": {
+												"description": "APIGroup holds the API group of the referenced subject. Defaults to \"\" for ServiceAccount subjects. Defaults to \"rbac.authorization.k8s.io\" for User and Group subjects.",
+												"type": "string"
+											  },
+											  "kind": {
+												"description": "Kind of object being referenced. Values defined by this API group are \"User\", \"Group\", and \"ServiceAccount\". If the Authorizer does not recognized the kind value, the Authorizer should report an error.",
+												"type": "string"
+											  },
+											  "name": {
+												"description": "Name of the object being referenced.",
+												"type": "string"
+											  },
+											  "namespace": {
+												"description": "Namespace of the referenced object.  If the object kind is non-namespace, such as \"User\" or \"Group\", and this value is not empty the Authorizer should report an error.",
+												"type": "string"
+											  }
+											},
+											"required": [
+											  "kind",
+											  "name"
+											],
+											"type": "object"
+										  }
+										},
+										"type": "array"
+									  }
+									},
+									"type": "object"
+								  }
+								},
+								"type": "array"
+							  },
+							  "any": {
+								"description": "Any allows specifying resources which will be ORed. Cannot be used together with the resources and user info fields.",
+								"items": {
+								  "schema": {
+									"description": "ResourceFilter allow users to \"AND\" or \"OR\" between resources",
+									"properties": {
+									  "clusterRoles": {
+										"description": "ClusterRoles is the list of cluster-wide role names for the user.",
+										"items": {
+										  "schema": {
+											"type": "string"
+										  }
+										},
+										"type": "array"
+									  },
+									  "resources": {
+										"description": "ResourceDescription contains information about the resource being created or modified. Requires at least one tag to be specified when under MatchResources.",
+										"properties": {
+										  "annotations": {
+											"description": "Annotations is a  map of annotations (key-value pairs of type string). Annotation keys and values support the wildcard characters \"*\" (matches zero or many characters) and \"?\" (matches at least one character).",
+											"type": "object"
+										  },
+										  "kinds": {
+											"description": "Kinds is a list of resource kinds. Kinds can be qualified as version/Kind, group/version/Kind or group/*/Kind, and \"*\" matches all kinds. Subresources can be matched using the form Kind/subresource, e.g. Pod/exec.",
+											"items": {
+											  "schema": {
+												"type": "string"
+											  }
+											},
+											"type": "array"
+										  },
+										  "name": {
+											"description": "Name is the name of the resource. The name supports wildcard characters \"*\" (matches zero or many characters) and \"?\" (at least one character).",
+											"type": "string"
+										  },
+										  "namespaceSelector": {
+											"description": "NamespaceSelector is a label selector for the resource namespace. Label keys and values in 'matchLabels' support the wildcard characters '*' (matches zero or many characters) and '?' (matches one character).Wildcards allows writing label selectors like [\"storage.k8s.io/*\": \"*\"]. Note that using [\"*\" : \"*\"] matches any key and value but does not match an empty label set.",
+											"properties": {
+											  "matchExpressions": {
+												"description": "matchExpressions is a list of label selector requirements. The requirements are ANDed.",
+												"items": {
+												  "schema": {
+													"description": "A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
+													"properties": {
+													  "key": {
+														"description": "key is the label key that the selector applies to.",
+														"type": "string"
+													  },
+													  "operator": {
+														"description": "operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.",
+														"type": "string"
+													  },
+													  "values": {
+														"description": "values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.",
+														"items": {
+														  "schema": {
+															"type": "string"
+														  }
+														},
+														"type": "array"
+													  }
+													},
+													"required": [
+													  "key",
+													  "operator"
+													],
+													"type": "object"
+												  }
+												},
+												"type": "array"
+											  },
+											  "matchLabels": {
+												"description": "matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is \"key\", the operator is \"In\", and the values array contains only \"value\". The requirements are ANDed.",
+												"type": "object"
+											  }
+											},
+											"type": "object"
+										  },
+										  "namespaces": {
+											"description": "Namespaces is a list of namespaces names. Each name supports wildcard characters \"*\" (matches zero or many characters) and \"?\" (at least one character).",
+											"items": {
+											  "schema": {
+												"type": "string"
+											  }
+											},
+											"type": "array"
+										  },
+										  "selector": {
+											"description": "Selector is a label selector. Label keys and values in 'matchLabels' support the wildcard characters '*' (matches zero or many characters) and '?' (matches one character). Wildcards allows writing label selectors like [\"storage.k8s.io/*\": \"*\"]. Note that using [\"*\" : \"*\"] matches any key and value but does not match an empty label set.",
+											"properties": {
+											  "matchExpressions": {
+												"description": "matchExpressions is a list of label selector requirements. The requirements are ANDed.",
+												"items": {
+												  "schema": {
+													"description": "A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
+													"properties": {
+													  "key": {
+														"description": "key is the label key that the selector applies to.",
+														"type": "string"
+													  },
+													  "operator": {
+														"description": "operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.",
+														"type": "string"
+													  },
+													  "values": {
+														"description": "values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.",
+														"items": {
+														  "schema": {
+															"type": "string"
+														  }
+														},
+														"type": "array"
+													  }
+													},
+													"required": [
+													  "key",
+													  "operator"
+													],
+													"type": "object"
+												  }
+												},
+												"type": "array"
+											  },
+											  "matchLabels": {
+												"description": "matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is \"key\", the operator is \"In\", and the values array contains only \"value\". The requirements are ANDed.",
+												"type": "object"
+											  }
+											},
+											"type": "object"
+										  }
+										},
+										"type": "object"
+									  },
+									  "roles": {
+										"description": "Roles is the list of namespaced role names for the user.",
+										"items": {
+										  "schema": {
+											"type": "string"
+										  }
+										},
+										"type": "array"
+									  },
+									  "subjects": {
+										"description": "Subjects is the list of subject names like users, user groups, and service accounts.",
+										"items": {
+										  "schema": {
+											"description": "Subject contains a reference to the object or user identities a role binding applies to.  This can either hold a direct API object reference, or a value for non-objects such as user and group names.",
+											"properties": {
+											  "apiGroup": {
+												"description": "APIGroup holds the API group of the referenced subject. Defaults to \"\" for ServiceAccount subjects. Defaults to \"rbac.authorization.k8s.io\" for User and Group subjects.",
+												"type": "string"
+											  },
+											  "kind": {
+												"description": "Kind of object being referenced. Values defined by this API group are \"User\", \"Group\", and \"ServiceAccount\". If the Authorizer does not recognized the kind value, the Authorizer should report an error.",
+												"type": "string"
+											  },
+											  "name": {
+												"description": "Name of the object being referenced.",
+												"type": "string"
+											  },
+											  "namespace": {
+												"description": "Namespace of the referenced object.  If the object kind is non-namespace, such as \"User\" or \"Group\", and this value is not empty the Authorizer should report an error.",
+												"type": "string"
+											  }
+											},
+											"required": [
+											  "kind",
+											  "name"
+											],
+											"type": "object"
+										  }
+										},
+										"type": "array"
+									  }
+									},
+									"type": "object"
+								  }
+								},
+								"type": "array"
+							  },
 							  "clusterRoles": {
 								"description": "ClusterRoles is the list of cluster-wide role names for the user.",
 								"items": {
@@ -344,7 +1064,7 @@ const PolicyCRD = `
 									"type": "object"
 								  },
 								  "kinds": {
-									"description": "Kinds is a list of resource kinds.",
+									"description": "Kinds is a list of resource kinds. Kinds can be qualified as version/Kind, group/version/Kind or group/*/Kind, and \"*\" matches all kinds. Subresources can be matched using the form Kind/subresource, e.g. Pod/exec.",
 									"items": {
 									  "schema": {
 										"type": "string"
@@ -575,6 +1295,10 @@ const PolicyCRD = `
 							  "pattern": {
 								"description": "Pattern specifies an overlay-style pattern used to check resources.",
 								"x-kubernetes-preserve-unknown-fields": true
+							  },
+							  "validationFailureAction": {
+								"description": "ValidationFailureAction overrides the policy ValidationFailureAction for this rule. Allowed values are \"enforce\" and \"audit\". Optional, by default the action of the policy applies.",
+								"type": "string"
 							  }
 							},
 							"type": "object"
@@ -588,6 +1312,74 @@ const PolicyCRD = `
 				  "validationFailureAction": {
 					"description": "ValidationFailureAction controls if a validation policy rule failure should disallow the admission review request (enforce), or allow (audit) the admission review request and report an error in a policy report. Optional. The default value is \"audit\".",
 					"type": "string"
+				  },
+				  "validationFailureActionOverrides": {
+					"description": "ValidationFailureActionOverrides is a list of ValidationFailureActionOverride instances which override the ValidationFailureAction for the namespaces they select. The first override that selects the namespace of a resource is applied.",
+					"items": {
+					  "schema": {
+						"description": "ValidationFailureActionOverride overrides the ValidationFailureAction of a policy for the namespaces selected by name or by labels.",
+						"properties": {
+						  "action": {
+							"description": "Action is the validation failure action applied in the selected namespaces, either \"enforce\" or \"audit\".",
+							"type": "string"
+						  },
+						  "namespaceSelector": {
+							"description": "NamespaceSelector is a label selector for the namespaces. When both namespaces and namespaceSelector are specified, a namespace must satisfy both.",
+							"properties": {
+							  "matchExpressions": {
+								"description": "matchExpressions is a list of label selector requirements. The requirements are ANDed.",
+								"items": {
+								  "schema": {
+									"description": "A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
+									"properties": {
+									  "key": {
+										"description": "key is the label key that the selector applies to.",
+										"type": "string"
+									  },
+									  "operator": {
+										"description": "operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.",
+										"type": "string"
+									  },
+									  "values": {
+										"description": "values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.",
+										"items": {
+										  "schema": {
+											"type": "string"
+										  }
+										},
+										"type": "array"
+									  }
+									},
+									"required": [
+									  "key",
+									  "operator"
+									],
+									"type": "object"
+								  }
+								},
+								"type": "array"
+							  },
+							  "matchLabels": {
+								"description": "matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is \"key\", the operator is \"In\", and the values array contains only \"value\". The requirements are ANDed.",
+								"type": "object"
+							  }
+							},
+							"type": "object"
+						  },
+						  "namespaces": {
+							"description": "Namespaces is a list of namespace names. The names support wildcard characters \"*\" (matches zero or many characters) and \"?\" (at least one character).",
+							"items": {
+							  "schema": {
+								"type": "string"
+							  }
+							},
+							"type": "array"
+						  }
+						},
+						"type": "object"
+					  }
+					},
+					"type": "array"
 				  }
 				},
 				"type": "object"
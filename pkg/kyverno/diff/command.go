@@ -0,0 +1,257 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	client "github.com/kyverno/kyverno/pkg/dclient"
+	"github.com/kyverno/kyverno/pkg/kyverno/common"
+	sanitizederror "github.com/kyverno/kyverno/pkg/kyverno/sanitizedError"
+	"github.com/kyverno/kyverno/pkg/kyverno/store"
+	"github.com/kyverno/kyverno/pkg/utils"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// output formats
+const (
+	outputFormatText = "text"
+	outputFormatJSON = "json"
+)
+
+var diffHelp = `
+Compares the results of two versions of policies over resources. The policies are paired by name,
+the resources whose validate or mutate rule results, patched resources or generated resources differ are reported.
+
+To compare the policies over the resources of files and directories:
+	kyverno diff --old /path/to/old/policies --new /path/to/new/policies --resources /path/to/resources
+
+To compare the policies over the resources of a cluster:
+	kyverno diff --old /path/to/old/policy.yaml --new /path/to/new/policy.yaml --cluster --namespace prod
+
+To print the changes as JSON:
+	kyverno diff --old /path/to/old/policy.yaml --new /path/to/new/policy.yaml --resources /path/to/resources --output-format json
+
+The values file and the user info file have the format of kyverno apply.
+`
+
+// Command returns the diff command
+func Command() *cobra.Command {
+	var oldPaths, newPaths, resourcePaths []string
+	var valuesFile, userInfoPath, namespace, outputFormat string
+	var cluster bool
+	cmd := &cobra.Command{
+		Use:     "diff",
+		Short:   "compares the results of two versions of policies over resources",
+		Example: diffHelp,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer func() {
+				if err != nil {
+					if !sanitizederror.IsErrorSanitized(err) {
+						log.Log.Error(err, "failed to sanitize")
+						err = fmt.Errorf("internal error")
+					}
+				}
+			}()
+
+			if outputFormat != outputFormatText && outputFormat != outputFormatJSON {
+				return sanitizederror.NewWithError(fmt.Sprintf("invalid output format %s, supported formats are text, json", outputFormat), nil)
+			}
+
+			if len(oldPaths) == 0 || len(newPaths) == 0 {
+				return sanitizederror.NewWithError("the old and new policies are required", nil)
+			}
+
+			if len(resourcePaths) == 0 && !cluster {
+				return sanitizederror.NewWithError("resources are required, use --resources or --cluster", nil)
+			}
+
+			// the messages printed while applying the policies are kept out of the results
+			result, err := diffCommandHelper(cmd.ErrOrStderr(), oldPaths, newPaths, resourcePaths, cluster, namespace, valuesFile, userInfoPath)
+			if err != nil {
+				return err
+			}
+
+			return printResult(cmd.OutOrStdout(), outputFormat, result)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&oldPaths, "old", []string{}, "Files and directories of the old policies")
+	cmd.Flags().StringSliceVar(&newPaths, "new", []string{}, "Files and directories of the new policies")
+	cmd.Flags().StringSliceVarP(&resourcePaths, "resources", "r", []string{}, "Files and directories of the resources, directories are read recursively")
+	cmd.Flags().BoolVarP(&cluster, "cluster", "c", false, "Compare the policies over the resources of the cluster")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace of the cluster resources")
+	cmd.Flags().StringVarP(&valuesFile, "values-file", "f", "", "File containing values for policy variables")
+	cmd.Flags().StringVarP(&userInfoPath, "userinfo", "u", "", "Admission request information file with the user info, roles, cluster roles and operation")
+	cmd.Flags().StringVar(&outputFormat, "output-format", outputFormatText, "Output format of the changes, one of: text, json")
+	return cmd
+}
+
+func diffCommandHelper(out io.Writer, oldPaths, newPaths, resourcePaths []string, cluster bool, namespace, valuesFile, userInfoPath string) (Result, error) {
+	store.SetMock(true)
+	fs := memfs.New()
+
+	_, valuesMap, namespaceSelectorMap, clusterResources, err := common.GetVariable("", valuesFile, fs, false, "")
+	if err != nil {
+		if !sanitizederror.IsErrorSanitized(err) {
+			return Result{}, sanitizederror.NewWithError("failed to decode yaml", err)
+		}
+		return Result{}, err
+	}
+
+	var userInfo *common.UserInfo
+	if userInfoPath != "" {
+		userInfo, err = common.GetUserInfoFromPath(fs, userInfoPath, false, "")
+		if err != nil {
+			return Result{}, err
+		}
+	}
+
+	var fixtureClient *client.Client
+	if len(clusterResources) > 0 {
+		fixtureClient, err = common.NewFixtureClient(clusterResources)
+		if err != nil {
+			return Result{}, sanitizederror.NewWithError("failed to serve the cluster resources", err)
+		}
+	}
+
+	oldPolicies, err := loadPolicies(fs, oldPaths)
+	if err != nil {
+		return Result{}, err
+	}
+
+	newPolicies, err := loadPolicies(fs, newPaths)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var skippedPolicies []string
+	if valuesFile == "" {
+		skippedPolicies = policiesWithVariables(append(append([]*v1.ClusterPolicy{}, oldPolicies...), newPolicies...))
+		oldPolicies = removePolicies(oldPolicies, skippedPolicies)
+		newPolicies = removePolicies(newPolicies, skippedPolicies)
+	}
+
+	var resources []*unstructured.Unstructured
+	if cluster {
+		restConfig, err := genericclioptions.NewConfigFlags(true).ToRESTConfig()
+		if err != nil {
+			return Result{}, err
+		}
+
+		dClient, err := client.NewClient(restConfig, 15*time.Minute, make(chan struct{}), log.Log)
+		if err != nil {
+			return Result{}, err
+		}
+
+		resources, err = common.GetResources(append(append([]*v1.ClusterPolicy{}, oldPolicies...), newPolicies...), resourcePaths, dClient, cluster, namespace, true, out)
+		if err != nil {
+			return Result{}, sanitizederror.NewWithError("failed to get the cluster resources", err)
+		}
+	} else {
+		resources, err = common.ReadResources(fs, resourcePaths, false, "")
+		if err != nil {
+			return Result{}, err
+		}
+	}
+
+	a := &applier{
+		valuesMap:            valuesMap,
+		namespaceSelectorMap: namespaceSelectorMap,
+		userInfo:             userInfo,
+		dClient:              fixtureClient,
+		cloneSources:         append(append([]*unstructured.Unstructured{}, resources...), clusterResources...),
+		out:                  out,
+	}
+
+	result := diffPolicies(oldPolicies, newPolicies, resources, a)
+	result.SkippedPolicies = skippedPolicies
+	return result, nil
+}
+
+func loadPolicies(fs billy.Filesystem, paths []string) ([]*v1.ClusterPolicy, error) {
+	policies, err := common.GetPoliciesFromPaths(fs, paths, false, "")
+	if err != nil {
+		return nil, sanitizederror.NewWithError(fmt.Sprintf("failed to load policies %s", strings.Join(paths, ", ")), err)
+	}
+
+	mutatedPolicies, err := common.MutatePolices(policies)
+	if err != nil {
+		if !sanitizederror.IsErrorSanitized(err) {
+			return nil, sanitizederror.NewWithError("failed to mutate policy", err)
+		}
+		return nil, err
+	}
+	return mutatedPolicies, nil
+}
+
+// policiesWithVariables returns the names of the policies with variables that need a values file,
+// both versions of such policies are skipped
+func policiesWithVariables(policies []*v1.ClusterPolicy) []string {
+	var names []string
+	for _, policy := range policies {
+		matches := common.RemoveUserInfoVariables(common.PolicyHasVariables(*policy))
+		if len(matches) > 0 && !utils.ContainsString(names, policy.GetName()) {
+			names = append(names, policy.GetName())
+		}
+	}
+	return names
+}
+
+func removePolicies(policies []*v1.ClusterPolicy, names []string) []*v1.ClusterPolicy {
+	var remaining []*v1.ClusterPolicy
+	for _, policy := range policies {
+		if !utils.ContainsString(names, policy.GetName()) {
+			remaining = append(remaining, policy)
+		}
+	}
+	return remaining
+}
+
+func printResult(out io.Writer, outputFormat string, result Result) error {
+	if outputFormat == outputFormatJSON {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return sanitizederror.NewWithError("failed to encode the changes", err)
+		}
+
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	}
+
+	resource := ""
+	for _, change := range result.Changes {
+		if change.Resource != resource {
+			resource = change.Resource
+			fmt.Fprintf(out, "\n%s\n", resource)
+		}
+
+		name := change.Policy
+		if change.Rule != "" {
+			name = change.Policy + "/" + change.Rule
+		}
+
+		fmt.Fprintf(out, "  %s (%s): %s -> %s\n", name, change.Type, change.Old, change.New)
+		if change.Diff != "" {
+			for _, line := range strings.Split(strings.TrimRight(change.Diff, "\n"), "\n") {
+				fmt.Fprintf(out, "    %s\n", line)
+			}
+		}
+	}
+
+	if len(result.SkippedPolicies) > 0 {
+		fmt.Fprintf(out, "\nskipped policies with variables, pass their values with --values-file: %s\n", strings.Join(result.SkippedPolicies, ", "))
+	}
+
+	s := result.Summary
+	fmt.Fprintf(out, "\nchanged resources: %d of %d, validate: %d, mutate: %d, generate: %d, error: %d\n", s.ChangedResources, s.Resources, s.Validate, s.Mutate, s.Generate, s.Error)
+	return nil
+}
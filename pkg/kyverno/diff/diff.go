@@ -0,0 +1,327 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	client "github.com/kyverno/kyverno/pkg/dclient"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"github.com/kyverno/kyverno/pkg/engine/utils"
+	"github.com/kyverno/kyverno/pkg/kyverno/common"
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// change types
+const (
+	changeValidate = "validate"
+	changeMutate   = "mutate"
+	changeGenerate = "generate"
+	changeError    = "error"
+)
+
+// statusNone is the status of a rule that is not applied to the resource, or a policy that does not exist
+const statusNone = "none"
+
+// Change is a difference of the results of the old and new version of a policy on a resource
+type Change struct {
+	Resource string `json:"resource"`
+	Policy   string `json:"policy"`
+	Rule     string `json:"rule,omitempty"`
+	Type     string `json:"type"`
+	Old      string `json:"old"`
+	New      string `json:"new"`
+	Diff     string `json:"diff,omitempty"`
+}
+
+// Summary counts the resources and the changes of each type
+type Summary struct {
+	Resources        int `json:"resources"`
+	ChangedResources int `json:"changedResources"`
+	Validate         int `json:"validate"`
+	Mutate           int `json:"mutate"`
+	Generate         int `json:"generate"`
+	Error            int `json:"error"`
+}
+
+// Result is the difference of the old and new policies over the resources
+type Result struct {
+	Changes         []Change `json:"changes"`
+	Summary         Summary  `json:"summary"`
+	SkippedPolicies []string `json:"skippedPolicies,omitempty"`
+}
+
+// outcome is the result of a policy on a resource
+type outcome struct {
+	// rules are the statuses of the rules keyed by type and name
+	rules     map[string]string
+	patched   *unstructured.Unstructured
+	generated map[string]*unstructured.Unstructured
+	err       string
+}
+
+// applier applies a policy to a resource
+type applier struct {
+	valuesMap            map[string]map[string]common.Resource
+	namespaceSelectorMap map[string]map[string]string
+	userInfo             *common.UserInfo
+	dClient              *client.Client
+	cloneSources         []*unstructured.Unstructured
+	// out receives the messages printed while applying the policies
+	out io.Writer
+}
+
+func (a *applier) apply(policy *v1.ClusterPolicy, resource *unstructured.Unstructured) outcome {
+	o := outcome{rules: make(map[string]string), generated: make(map[string]*unstructured.Unstructured)}
+	if policy == nil {
+		return o
+	}
+
+	values := make(map[string]string)
+	if r, ok := a.valuesMap[policy.GetName()][resource.GetName()]; ok && r.Values != nil {
+		values = r.Values
+	}
+
+	resps, validateResp, _, _, err := common.ApplyPolicyOnResource(policy.DeepCopy(), resource.DeepCopy(), "", false, values, true, a.namespaceSelectorMap, false, a.userInfo, a.dClient, a.out)
+	if err != nil {
+		o.err = err.Error()
+		return o
+	}
+
+	resps = append(resps, validateResp)
+	for _, resp := range resps {
+		for _, rule := range resp.PolicyResponse.Rules {
+			o.rules[ruleKey(rule)] = string(rule.GetStatus())
+
+			switch rule.Type {
+			case utils.Mutation.String():
+				patched := resp.PatchedResource
+				o.patched = &patched
+			case utils.Generation.String():
+				if rule.GetStatus() != response.RuleStatusPass {
+					continue
+				}
+
+				generated, err := common.GenerateResource(policy, rule.Name, resource, values, a.userInfo, a.cloneSources)
+				if err != nil {
+					o.generated[rule.Name] = &unstructured.Unstructured{Object: map[string]interface{}{"error": err.Error()}}
+					continue
+				}
+				o.generated[rule.Name] = generated
+			}
+		}
+	}
+
+	return o
+}
+
+func ruleKey(rule response.RuleResponse) string {
+	return rule.Type + "/" + rule.Name
+}
+
+// compare returns the changes between the outcomes of the old and new policy on a resource
+func compare(resource, policy string, old, new outcome) []Change {
+	var changes []Change
+	if old.err != "" || new.err != "" {
+		if old.err != new.err {
+			changes = append(changes, Change{Resource: resource, Policy: policy, Type: changeError, Old: orNone(old.err), New: orNone(new.err)})
+		}
+		return changes
+	}
+
+	for _, key := range unionKeys(old.rules, new.rules) {
+		oldStatus, newStatus := orNone(old.rules[key]), orNone(new.rules[key])
+		if oldStatus == newStatus {
+			continue
+		}
+
+		ruleType, ruleName := splitRuleKey(key)
+		changeType := changeValidate
+		switch ruleType {
+		case utils.Mutation.String():
+			changeType = changeMutate
+		case utils.Generation.String():
+			changeType = changeGenerate
+		}
+
+		changes = append(changes, Change{Resource: resource, Policy: policy, Rule: ruleName, Type: changeType, Old: oldStatus, New: newStatus})
+	}
+
+	if diff := resourceDiff(old.patched, new.patched); diff != "" {
+		changes = append(changes, Change{Resource: resource, Policy: policy, Type: changeMutate, Old: patchedStatus(old.patched), New: patchedStatus(new.patched), Diff: diff})
+	}
+
+	generatedRules := make(map[string]string)
+	for rule := range old.generated {
+		generatedRules[rule] = ""
+	}
+	for rule := range new.generated {
+		generatedRules[rule] = ""
+	}
+
+	for _, rule := range sortedKeys(generatedRules) {
+		if diff := resourceDiff(old.generated[rule], new.generated[rule]); diff != "" {
+			changes = append(changes, Change{Resource: resource, Policy: policy, Rule: rule, Type: changeGenerate, Old: generatedStatus(old.generated[rule]), New: generatedStatus(new.generated[rule]), Diff: diff})
+		}
+	}
+
+	return changes
+}
+
+// resourceDiff returns a unified diff of the YAML of the resources, it is empty when the resources are equal
+func resourceDiff(old, new *unstructured.Unstructured) string {
+	oldYAML, newYAML := toYAML(old), toYAML(new)
+	if oldYAML == newYAML {
+		return ""
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(oldYAML),
+		B:        difflib.SplitLines(newYAML),
+		FromFile: "old",
+		ToFile:   "new",
+		Context:  3,
+	})
+	if err != nil {
+		return err.Error()
+	}
+	return diff
+}
+
+func toYAML(resource *unstructured.Unstructured) string {
+	if resource == nil {
+		return ""
+	}
+
+	// marshal to JSON first so that numbers of different Go types are printed the same
+	data, err := json.Marshal(resource.Object)
+	if err != nil {
+		return err.Error()
+	}
+
+	var obj interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err.Error()
+	}
+
+	out, err := yaml.Marshal(obj)
+	if err != nil {
+		return err.Error()
+	}
+	return string(out)
+}
+
+func patchedStatus(resource *unstructured.Unstructured) string {
+	if resource == nil {
+		return statusNone
+	}
+	return "patched"
+}
+
+func generatedStatus(resource *unstructured.Unstructured) string {
+	if resource == nil {
+		return statusNone
+	}
+	return "generated"
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return statusNone
+	}
+	return s
+}
+
+func splitRuleKey(key string) (string, string) {
+	parts := strings.SplitN(key, "/", 2)
+	return parts[0], parts[1]
+}
+
+func unionKeys(a, b map[string]string) []string {
+	keys := make(map[string]string)
+	for k := range a {
+		keys[k] = ""
+	}
+	for k := range b {
+		keys[k] = ""
+	}
+	return sortedKeys(keys)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// diffPolicies applies the old and new versions of each policy, paired by name, to the resources
+func diffPolicies(oldPolicies, newPolicies []*v1.ClusterPolicy, resources []*unstructured.Unstructured, a *applier) Result {
+	oldByName := policiesByName(oldPolicies)
+	newByName := policiesByName(newPolicies)
+	names := make(map[string]string)
+	for name := range oldByName {
+		names[name] = ""
+	}
+	for name := range newByName {
+		names[name] = ""
+	}
+
+	result := Result{Changes: []Change{}, Summary: Summary{Resources: len(resources)}}
+	for _, resource := range resources {
+		resourceName := resourceKey(resource)
+		changed := false
+		for _, name := range sortedKeys(names) {
+			oldPolicy, newPolicy := oldByName[name], newByName[name]
+			if oldPolicy != nil && newPolicy != nil && reflect.DeepEqual(oldPolicy.Spec, newPolicy.Spec) {
+				continue
+			}
+
+			changes := compare(resourceName, name, a.apply(oldPolicy, resource), a.apply(newPolicy, resource))
+			for _, change := range changes {
+				switch change.Type {
+				case changeValidate:
+					result.Summary.Validate++
+				case changeMutate:
+					result.Summary.Mutate++
+				case changeGenerate:
+					result.Summary.Generate++
+				case changeError:
+					result.Summary.Error++
+				}
+			}
+
+			changed = changed || len(changes) > 0
+			result.Changes = append(result.Changes, changes...)
+		}
+
+		if changed {
+			result.Summary.ChangedResources++
+		}
+	}
+
+	return result
+}
+
+func policiesByName(policies []*v1.ClusterPolicy) map[string]*v1.ClusterPolicy {
+	byName := make(map[string]*v1.ClusterPolicy)
+	for _, policy := range policies {
+		byName[policy.GetName()] = policy
+	}
+	return byName
+}
+
+func resourceKey(resource *unstructured.Unstructured) string {
+	if resource.GetNamespace() == "" {
+		return fmt.Sprintf("%s/%s", resource.GetKind(), resource.GetName())
+	}
+	return fmt.Sprintf("%s/%s/%s", resource.GetKind(), resource.GetNamespace(), resource.GetName())
+}
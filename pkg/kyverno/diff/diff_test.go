@@ -0,0 +1,128 @@
+package diff
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/kyverno/common"
+	ut "github.com/kyverno/kyverno/pkg/utils"
+	"gotest.tools/assert"
+)
+
+var oldPolicy = []byte(`apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-team
+spec:
+  validationFailureAction: audit
+  rules:
+  - name: check-team
+    match:
+      resources:
+        kinds:
+        - Pod
+    validate:
+      message: "team label is required"
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+`)
+
+var newPolicy = []byte(`apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-team
+spec:
+  validationFailureAction: audit
+  rules:
+  - name: check-team
+    match:
+      resources:
+        kinds:
+        - Pod
+    validate:
+      message: "team label must start with a"
+      pattern:
+        metadata:
+          labels:
+            team: "a*"
+  - name: add-env
+    match:
+      resources:
+        kinds:
+        - Pod
+    mutate:
+      patchStrategicMerge:
+        metadata:
+          labels:
+            env: prod
+`)
+
+var diffResources = []byte(`apiVersion: v1
+kind: Pod
+metadata:
+  name: alpha
+  labels:
+    team: alpha
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: beta
+  labels:
+    team: beta
+`)
+
+func Test_DiffPolicies(t *testing.T) {
+	oldPolicies, err := ut.GetPolicy(oldPolicy)
+	assert.NilError(t, err)
+	newPolicies, err := ut.GetPolicy(newPolicy)
+	assert.NilError(t, err)
+	resources, err := common.GetResource(diffResources)
+	assert.NilError(t, err)
+
+	result := diffPolicies(oldPolicies, newPolicies, resources, &applier{out: ioutil.Discard})
+	assert.DeepEqual(t, result.Summary, Summary{Resources: 2, ChangedResources: 2, Validate: 1, Mutate: 4})
+
+	var validate []Change
+	for _, change := range result.Changes {
+		if change.Type == changeValidate {
+			validate = append(validate, change)
+		}
+	}
+	assert.DeepEqual(t, validate, []Change{
+		{Resource: "Pod/default/beta", Policy: "require-team", Rule: "check-team", Type: changeValidate, Old: "pass", New: "fail"},
+	})
+
+	// the same policies do not change any result
+	result = diffPolicies(oldPolicies, oldPolicies, resources, &applier{out: ioutil.Discard})
+	assert.Equal(t, len(result.Changes), 0)
+	assert.Equal(t, result.Summary.ChangedResources, 0)
+}
+
+func Test_PoliciesWithVariables(t *testing.T) {
+	policies, err := ut.GetPolicy([]byte(`apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: with-variables
+spec:
+  rules:
+  - name: check-owner
+    match:
+      resources:
+        kinds:
+        - Pod
+    validate:
+      message: "{{ request.userInfo.username }} is not the owner"
+      deny:
+        conditions:
+        - key: "{{ owner }}"
+          operator: NotEquals
+          value: "{{ request.userInfo.username }}"
+`))
+	assert.NilError(t, err)
+
+	assert.DeepEqual(t, policiesWithVariables(policies), []string{"with-variables"})
+	assert.Equal(t, len(removePolicies(policies, []string{"with-variables"})), 0)
+}
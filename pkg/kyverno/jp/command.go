@@ -0,0 +1,186 @@
+package jp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/kyverno/common"
+	sanitizederror "github.com/kyverno/kyverno/pkg/kyverno/sanitizedError"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var jpHelp = `
+Evaluates a JMESPath expression with the Kyverno functions against a JSON or YAML document.
+The document is read from the input file, or from stdin when no input file is given.
+
+To list the Kyverno functions:
+	kyverno jp --list-functions
+
+To evaluate an expression:
+	kyverno jp "spec.containers[?regex_match('^nginx', image)].name" --input /path/to/pod.yaml
+
+	cat /path/to/pod.yaml | kyverno jp "to_upper(metadata.name)"
+
+To substitute the variables of a string against a mock admission request, the input is the request.object:
+	kyverno jp --substitute "{{ request.object.metadata.name }} by {{ request.userInfo.username }}" --input /path/to/pod.yaml --userinfo /path/to/user_info.yaml
+
+More info: https://kyverno.io/docs/writing-policies/jmespath/
+`
+
+// Command returns the jp command
+func Command() *cobra.Command {
+	var inputPath, userInfoPath string
+	var listFunctions, substitute bool
+	cmd := &cobra.Command{
+		Use:     "jp <expression>",
+		Short:   "evaluates JMESPath expressions with the Kyverno functions",
+		Example: jpHelp,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer func() {
+				if err != nil {
+					if !sanitizederror.IsErrorSanitized(err) {
+						log.Log.Error(err, "failed to sanitize")
+						err = fmt.Errorf("internal error")
+					}
+				}
+			}()
+
+			if listFunctions {
+				printFunctions(cmd.OutOrStdout())
+				return nil
+			}
+
+			if len(args) != 1 {
+				return sanitizederror.NewWithError("an expression is required", nil)
+			}
+
+			input, err := readInput(inputPath, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+
+			var result interface{}
+			if substitute {
+				var userInfo *common.UserInfo
+				if userInfoPath != "" {
+					userInfo, err = common.GetUserInfoFromPath(memfs.New(), userInfoPath, false, "")
+					if err != nil {
+						return err
+					}
+				}
+
+				result, err = substituteVariables(args[0], input, userInfo)
+			} else {
+				result, err = evaluate(args[0], input)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			return printResult(cmd.OutOrStdout(), result)
+		},
+	}
+
+	cmd.Flags().StringVarP(&inputPath, "input", "i", "", "JSON or YAML file of the document, stdin is read when not set")
+	cmd.Flags().BoolVarP(&listFunctions, "list-functions", "l", false, "List the Kyverno functions with their signatures")
+	cmd.Flags().BoolVarP(&substitute, "substitute", "s", false, "Substitute the variables of a Kyverno string, e.g. {{ request.object.metadata.name }}, instead of evaluating an expression")
+	cmd.Flags().StringVarP(&userInfoPath, "userinfo", "u", "", "Admission request information file with the user info, roles, cluster roles and operation, used with --substitute")
+	return cmd
+}
+
+func printFunctions(out io.Writer) {
+	fmt.Fprintln(out, "Kyverno functions:")
+	for _, function := range jmespath.GetFunctions() {
+		fmt.Fprintf(out, "  %s\n", jmespath.FunctionSignature(function))
+	}
+	fmt.Fprintln(out, "\nThe functions of the JMESPath specification are available as well: https://jmespath.org/specification.html#built-in-functions")
+}
+
+// readInput reads the JSON or YAML document from the file, or from stdin when the path is empty or -
+func readInput(path string, stdin io.Reader) ([]byte, error) {
+	var data []byte
+	var err error
+	if path == "" || path == "-" {
+		data, err = ioutil.ReadAll(stdin)
+	} else {
+		data, err = ioutil.ReadFile(path)
+	}
+
+	if err != nil {
+		return nil, sanitizederror.NewWithError("failed to read the input", err)
+	}
+
+	jsonData, err := yaml.ToJSON(data)
+	if err != nil {
+		return nil, sanitizederror.NewWithError("failed to convert the input to JSON", err)
+	}
+	return jsonData, nil
+}
+
+// evaluate evaluates the JMESPath expression against the JSON document
+func evaluate(expression string, input []byte) (interface{}, error) {
+	jp, err := jmespath.New(expression)
+	if err != nil {
+		return nil, sanitizederror.NewWithError(fmt.Sprintf("failed to compile the expression %s", expression), err)
+	}
+
+	var data interface{}
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &data); err != nil {
+			return nil, sanitizederror.NewWithError("failed to decode the input", err)
+		}
+	}
+
+	result, err := jp.Search(data)
+	if err != nil {
+		return nil, sanitizederror.NewWithError(fmt.Sprintf("failed to evaluate the expression %s", expression), err)
+	}
+	return result, nil
+}
+
+// substituteVariables substitutes the variables of the string with the admission request
+// of the resource, the user info is added to the request when it is set
+func substituteVariables(document string, resource []byte, userInfo *common.UserInfo) (interface{}, error) {
+	ctx := context.NewContext()
+	if len(resource) > 0 && string(resource) != "null" {
+		if err := ctx.AddResource(resource); err != nil {
+			return nil, sanitizederror.NewWithError("failed to add the input to the context", err)
+		}
+	}
+
+	if userInfo != nil {
+		if err := userInfo.AddToContext(ctx); err != nil {
+			return nil, sanitizederror.NewWithError("failed to add the user info to the context", err)
+		}
+	}
+
+	result, err := variables.SubstituteAll(log.Log, ctx, document)
+	if err != nil {
+		return nil, sanitizederror.NewWithError(fmt.Sprintf("failed to substitute the variables of %s", document), err)
+	}
+	return result, nil
+}
+
+func printResult(out io.Writer, result interface{}) error {
+	if s, ok := result.(string); ok {
+		_, err := fmt.Fprintln(out, s)
+		return err
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return sanitizederror.NewWithError("failed to encode the result", err)
+	}
+
+	_, err = fmt.Fprintln(out, string(data))
+	return err
+}
@@ -0,0 +1,73 @@
+package jp
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/kyverno/common"
+	"gotest.tools/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+var pod = []byte(`apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx
+  labels:
+    app: web
+spec:
+  containers:
+  - name: nginx
+    image: nginx:1.21
+  - name: sidecar
+    image: busybox:1.34
+`)
+
+func Test_Evaluate(t *testing.T) {
+	input, err := readInput("", bytes.NewReader(pod))
+	assert.NilError(t, err)
+
+	result, err := evaluate("spec.containers[?regex_match('^nginx', image)].name", input)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, []interface{}{"nginx"})
+
+	result, err = evaluate("to_upper(metadata.name)", input)
+	assert.NilError(t, err)
+	assert.Equal(t, result, "NGINX")
+
+	_, err = evaluate("unknown_function(metadata.name)", input)
+	assert.ErrorContains(t, err, "failed to")
+}
+
+func Test_SubstituteVariables(t *testing.T) {
+	input, err := readInput("", bytes.NewReader(pod))
+	assert.NilError(t, err)
+
+	userInfo := &common.UserInfo{
+		RequestInfo: v1.RequestInfo{AdmissionUserInfo: authenticationv1.UserInfo{Username: "dev"}},
+		Operation:   "CREATE",
+	}
+
+	result, err := substituteVariables("{{ request.object.metadata.name }} created by {{ request.userInfo.username }} on {{ request.operation }}", input, userInfo)
+	assert.NilError(t, err)
+	assert.Equal(t, result, "nginx created by dev on CREATE")
+}
+
+func Test_Command(t *testing.T) {
+	var out bytes.Buffer
+	cmd := Command()
+	cmd.SetOut(&out)
+	cmd.SetIn(bytes.NewReader(pod))
+	cmd.SetArgs([]string{"metadata.labels"})
+	assert.NilError(t, cmd.Execute())
+	assert.Equal(t, out.String(), "{\n  \"app\": \"web\"\n}\n")
+
+	out.Reset()
+	cmd = Command()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--list-functions"})
+	assert.NilError(t, cmd.Execute())
+	assert.Assert(t, strings.Contains(out.String(), "label_match(object, object)"))
+}
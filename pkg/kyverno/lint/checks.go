@@ -0,0 +1,355 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/kyverno/common"
+	"github.com/kyverno/kyverno/pkg/policymutation"
+	"github.com/kyverno/kyverno/pkg/utils"
+	"github.com/minio/pkg/wildcard"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+)
+
+// systemNamespace is the namespace of the cluster components that rules are expected to exclude
+const systemNamespace = "kube-system"
+
+// admissionVariables are the variables that are only set in admission requests
+var admissionVariables = []string{
+	"request.userInfo", "request.roles", "request.clusterRoles", "request.operation", "request.oldObject",
+	"serviceAccountName", "serviceAccountNamespace",
+}
+
+// DefaultChecks returns the built-in checks
+func DefaultChecks() []Check {
+	return []Check{
+		{
+			Name:        "validate-message",
+			Severity:    SeverityWarning,
+			Description: "validate rules have a message that tells users how to fix the resource",
+			Lint:        lintValidateMessage,
+		},
+		{
+			Name:        "exclude-system-namespaces",
+			Severity:    SeverityWarning,
+			Description: "rules that match namespaced resources in all namespaces exclude the kube-system namespace",
+			Lint:        lintExcludeSystemNamespaces,
+		},
+		{
+			Name:        "background-admission-variables",
+			Severity:    SeverityError,
+			Description: "policies applied in background scans do not use variables or user info that are only set in admission requests",
+			Lint:        lintBackgroundAdmissionVariables,
+		},
+		{
+			Name:        "mutate-idempotent",
+			Severity:    SeverityWarning,
+			Description: "mutate rules give the same resource when they are applied again, e.g. they do not append to lists",
+			Lint:        lintMutateIdempotent,
+		},
+		{
+			Name:        "wildcard-kinds",
+			Severity:    SeverityWarning,
+			Description: "rules that match all kinds have preconditions",
+			Lint:        lintWildcardKinds,
+		},
+		{
+			Name:        "deprecated-fields",
+			Severity:    SeverityWarning,
+			Description: "mutate rules use patchesJson6902 and patchStrategicMerge instead of the deprecated patches and overlay",
+			Lint:        lintDeprecatedFields,
+		},
+		{
+			Name:        "unused-context",
+			Severity:    SeverityInfo,
+			Description: "context entries are used by the variables of their rule",
+			Lint:        lintUnusedContext,
+		},
+	}
+}
+
+func lintValidateMessage(policy *v1.ClusterPolicy) []Finding {
+	var findings []Finding
+	for _, rule := range policy.Spec.Rules {
+		if rule.HasValidate() && strings.TrimSpace(rule.Validation.Message) == "" {
+			findings = append(findings, Finding{Rule: rule.Name, Message: "validate.message is not set, users are not told why their resource is blocked"})
+		}
+	}
+	return findings
+}
+
+func lintExcludeSystemNamespaces(policy *v1.ClusterPolicy) []Finding {
+	var findings []Finding
+	for _, rule := range policy.Spec.Rules {
+		match := matchDescriptions(rule.MatchResources)
+		if !matchesNamespacedKinds(match) || namespacesRestricted(match) {
+			continue
+		}
+
+		if excludesNamespace(excludeDescriptions(rule.ExcludeResources), systemNamespace) {
+			continue
+		}
+
+		findings = append(findings, Finding{Rule: rule.Name, Message: fmt.Sprintf("the rule matches resources in all namespaces and does not exclude %s", systemNamespace)})
+	}
+	return findings
+}
+
+func lintBackgroundAdmissionVariables(policy *v1.ClusterPolicy) []Finding {
+	if !policy.BackgroundProcessingEnabled() {
+		return nil
+	}
+
+	var findings []Finding
+	for _, rule := range policy.Spec.Rules {
+		if userInfoDefined(rule) {
+			findings = append(findings, Finding{Rule: rule.Name, Message: "the rule matches or excludes roles, cluster roles or subjects which are only known in admission requests, set spec.background to false"})
+		}
+
+		var used []string
+		for _, variable := range ruleVariables(rule) {
+			for _, name := range admissionVariables {
+				if referencesName(variable, name) && !utils.ContainsString(used, name) {
+					used = append(used, name)
+				}
+			}
+		}
+
+		if len(used) > 0 {
+			findings = append(findings, Finding{Rule: rule.Name, Message: fmt.Sprintf("the rule uses variables that are only set in admission requests: %s, set spec.background to false", strings.Join(used, ", "))})
+		}
+	}
+	return findings
+}
+
+func lintMutateIdempotent(policy *v1.ClusterPolicy) []Finding {
+	var findings []Finding
+	for _, rule := range policy.Spec.Rules {
+		if !rule.HasMutate() {
+			continue
+		}
+
+		patches := rule.Mutation.Patches
+		if rule.Mutation.PatchesJSON6902 != "" {
+			var jsonPatches []v1.Patch
+			if err := yaml.Unmarshal([]byte(rule.Mutation.PatchesJSON6902), &jsonPatches); err != nil {
+				findings = append(findings, Finding{Rule: rule.Name, Message: fmt.Sprintf("failed to decode patchesJson6902: %v", err)})
+				continue
+			}
+			patches = append(patches, jsonPatches...)
+		}
+
+		for _, patch := range patches {
+			if strings.EqualFold(patch.Operation, "add") && strings.HasSuffix(patch.Path, "/-") {
+				findings = append(findings, Finding{Rule: rule.Name, Message: fmt.Sprintf("the patch appends to %s each time the rule is applied, match the existing items or use patchStrategicMerge", strings.TrimSuffix(patch.Path, "/-"))})
+			}
+		}
+	}
+	return findings
+}
+
+func lintWildcardKinds(policy *v1.ClusterPolicy) []Finding {
+	var findings []Finding
+	for _, rule := range policy.Spec.Rules {
+		if rule.AnyAllConditions != nil {
+			continue
+		}
+
+		for _, description := range matchDescriptions(rule.MatchResources) {
+			if containsWildcardKind(description.Kinds) {
+				findings = append(findings, Finding{Rule: rule.Name, Message: "the rule matches all kinds without preconditions, it is applied to every resource"})
+				break
+			}
+		}
+	}
+	return findings
+}
+
+func lintDeprecatedFields(policy *v1.ClusterPolicy) []Finding {
+	var deprecated []int
+	for i, rule := range policy.Spec.Rules {
+		if len(rule.Mutation.Patches) > 0 || rule.Mutation.Overlay != nil {
+			deprecated = append(deprecated, i)
+		}
+	}
+
+	if len(deprecated) == 0 {
+		return nil
+	}
+
+	// the conversions of the policy mutation give the replacement of the deprecated fields
+	conversions := make(map[int][]v1.Mutation)
+	patches, _ := policymutation.ConvertDeprecatedMutations(policy.DeepCopy(), log.Log)
+	for _, patch := range patches {
+		var p struct {
+			Path  string      `json:"path"`
+			Value v1.Mutation `json:"value"`
+		}
+		if err := json.Unmarshal(patch, &p); err != nil {
+			continue
+		}
+
+		index, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(p.Path, "/spec/rules/"), "/mutate"))
+		if err == nil {
+			conversions[index] = append(conversions[index], p.Value)
+		}
+	}
+
+	var findings []Finding
+	for _, i := range deprecated {
+		rule := policy.Spec.Rules[i]
+		fixed := rule.Mutation
+		var fields []string
+		if len(rule.Mutation.Patches) > 0 {
+			fields = append(fields, "patches is deprecated, use patchesJson6902")
+		}
+
+		if rule.Mutation.Overlay != nil {
+			fields = append(fields, "overlay is deprecated, use patchStrategicMerge")
+		}
+
+		for _, converted := range conversions[i] {
+			if len(rule.Mutation.Patches) > 0 && converted.PatchesJSON6902 != "" {
+				fixed.Patches = nil
+				fixed.PatchesJSON6902 = converted.PatchesJSON6902
+			}
+
+			if rule.Mutation.Overlay != nil && converted.PatchStrategicMerge != nil {
+				fixed.Overlay = nil
+				fixed.PatchStrategicMerge = converted.PatchStrategicMerge
+			}
+		}
+
+		finding := Finding{Rule: rule.Name, Message: strings.Join(fields, ", ")}
+		if fix, err := yaml.Marshal(map[string]interface{}{"mutate": fixed}); err == nil {
+			finding.Fix = string(fix)
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+func lintUnusedContext(policy *v1.ClusterPolicy) []Finding {
+	var findings []Finding
+	for _, rule := range policy.Spec.Rules {
+		for i, entry := range rule.Context {
+			// the entries can be used by the variables of the other entries
+			others := rule.DeepCopy()
+			others.Context = append(append([]v1.ContextEntry{}, rule.Context[:i]...), rule.Context[i+1:]...)
+
+			used := false
+			for _, variable := range ruleVariables(*others) {
+				if referencesName(variable, entry.Name) {
+					used = true
+					break
+				}
+			}
+
+			if !used {
+				findings = append(findings, Finding{Rule: rule.Name, Message: fmt.Sprintf("the context entry %s is not used by the variables of the rule", entry.Name)})
+			}
+		}
+	}
+	return findings
+}
+
+// ruleVariables returns the variables of the rule, e.g. {{ request.object.metadata.name }}
+func ruleVariables(rule v1.Rule) []string {
+	data, err := json.Marshal(rule)
+	if err != nil {
+		return nil
+	}
+	return variables.RegexVariables.FindAllString(string(data), -1)
+}
+
+// referencesName checks if the variable uses the name as the root of a path, e.g. request.object in {{ request.object.metadata.name }}
+func referencesName(variable, name string) bool {
+	return regexp.MustCompile(`(^|[^\w.$-])` + regexp.QuoteMeta(name) + `($|[^\w-])`).MatchString(variable)
+}
+
+func matchDescriptions(match v1.MatchResources) []v1.ResourceDescription {
+	descriptions := []v1.ResourceDescription{match.ResourceDescription}
+	for _, filter := range append(append(v1.ResourceFilters{}, match.Any...), match.All...) {
+		descriptions = append(descriptions, filter.ResourceDescription)
+	}
+	return withKinds(descriptions)
+}
+
+func excludeDescriptions(exclude v1.ExcludeResources) []v1.ResourceDescription {
+	descriptions := []v1.ResourceDescription{exclude.ResourceDescription}
+	for _, filter := range append(append(v1.ResourceFilters{}, exclude.Any...), exclude.All...) {
+		descriptions = append(descriptions, filter.ResourceDescription)
+	}
+	return descriptions
+}
+
+func withKinds(descriptions []v1.ResourceDescription) []v1.ResourceDescription {
+	var result []v1.ResourceDescription
+	for _, description := range descriptions {
+		if len(description.Kinds) > 0 {
+			result = append(result, description)
+		}
+	}
+	return result
+}
+
+func matchesNamespacedKinds(descriptions []v1.ResourceDescription) bool {
+	for _, description := range descriptions {
+		for _, kind := range description.Kinds {
+			if strings.Contains(kind, "*") || !common.IsClusterScopedKind(kind[strings.LastIndex(kind, "/")+1:]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// namespacesRestricted checks if every resource description selects namespaces
+func namespacesRestricted(descriptions []v1.ResourceDescription) bool {
+	for _, description := range descriptions {
+		if len(description.Namespaces) == 0 && description.NamespaceSelector == nil {
+			return false
+		}
+	}
+	return len(descriptions) > 0
+}
+
+func excludesNamespace(descriptions []v1.ResourceDescription, namespace string) bool {
+	for _, description := range descriptions {
+		for _, pattern := range description.Namespaces {
+			if wildcard.Match(pattern, namespace) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsWildcardKind(kinds []string) bool {
+	for _, kind := range kinds {
+		if kind == "*" || strings.HasSuffix(kind, "/*") {
+			return true
+		}
+	}
+	return false
+}
+
+func userInfoDefined(rule v1.Rule) bool {
+	infos := []v1.UserInfo{rule.MatchResources.UserInfo, rule.ExcludeResources.UserInfo}
+	for _, filter := range append(append(append(append(v1.ResourceFilters{}, rule.MatchResources.Any...), rule.MatchResources.All...), rule.ExcludeResources.Any...), rule.ExcludeResources.All...) {
+		infos = append(infos, filter.UserInfo)
+	}
+
+	for _, info := range infos {
+		if len(info.Roles) > 0 || len(info.ClusterRoles) > 0 || len(info.Subjects) > 0 {
+			return true
+		}
+	}
+	return false
+}
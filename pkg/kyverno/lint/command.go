@@ -0,0 +1,158 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/kyverno/kyverno/pkg/kyverno/common"
+	sanitizederror "github.com/kyverno/kyverno/pkg/kyverno/sanitizedError"
+	"github.com/spf13/cobra"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// output formats
+const (
+	outputFormatText = "text"
+	outputFormatJSON = "json"
+)
+
+var lintHelp = `
+Checks policies for best practices. The findings have a severity of info, warning or error,
+the command fails when a finding has the --fail-on severity or a higher one.
+
+To lint the policies of files and directories:
+	kyverno lint /path/to/policy.yaml /path/to/folderOfPolicies
+
+To list the checks:
+	kyverno lint --list-checks
+
+To print the errors and warnings as JSON:
+	kyverno lint /path/to/policies --severity warning --output-format json
+
+To suppress checks for a policy, or for a rule of a policy, annotate the policy:
+	metadata:
+	  annotations:
+	    policies.kyverno.io/lint-ignore: "unused-context,validate-message:check-labels"
+`
+
+// Command returns the lint command
+func Command() *cobra.Command {
+	var outputFormat, severity, failOn string
+	var disabled []string
+	var listChecks bool
+	cmd := &cobra.Command{
+		Use:     "lint",
+		Short:   "checks policies for best practices",
+		Example: lintHelp,
+		RunE: func(cmd *cobra.Command, policyPaths []string) (err error) {
+			defer func() {
+				if err != nil {
+					if !sanitizederror.IsErrorSanitized(err) {
+						log.Log.Error(err, "failed to sanitize")
+						err = fmt.Errorf("internal error")
+					}
+				}
+			}()
+
+			if listChecks {
+				printChecks(cmd.OutOrStdout(), DefaultChecks())
+				return nil
+			}
+
+			if outputFormat != outputFormatText && outputFormat != outputFormatJSON {
+				return sanitizederror.NewWithError(fmt.Sprintf("invalid output format %s, supported formats are text, json", outputFormat), nil)
+			}
+
+			minSeverity, err := ParseSeverity(severity)
+			if err != nil {
+				return sanitizederror.NewWithError("invalid --severity", err)
+			}
+
+			failSeverity, err := ParseSeverity(failOn)
+			if err != nil {
+				return sanitizederror.NewWithError("invalid --fail-on", err)
+			}
+
+			if len(policyPaths) == 0 {
+				return sanitizederror.NewWithError("policy file(s) required", nil)
+			}
+
+			checks, err := SelectChecks(DefaultChecks(), disabled)
+			if err != nil {
+				return sanitizederror.NewWithError("invalid --disable", err)
+			}
+
+			policies, err := common.GetPoliciesFromPaths(memfs.New(), policyPaths, false, "")
+			if err != nil {
+				return sanitizederror.NewWithError("failed to load policies", err)
+			}
+
+			result := Lint(policies, checks, minSeverity)
+			if err := printResult(cmd.OutOrStdout(), outputFormat, result); err != nil {
+				return err
+			}
+
+			for _, finding := range result.Findings {
+				if finding.Severity.AtLeast(failSeverity) {
+					os.Exit(1)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&outputFormat, "output-format", outputFormatText, "Output format of the findings, one of: text, json")
+	cmd.Flags().StringVar(&severity, "severity", string(SeverityInfo), "Minimum severity of the reported findings, one of: info, warning, error")
+	cmd.Flags().StringVar(&failOn, "fail-on", string(SeverityError), "Minimum severity of the findings that fail the command, one of: info, warning, error")
+	cmd.Flags().StringSliceVar(&disabled, "disable", []string{}, "Checks that are not run")
+	cmd.Flags().BoolVarP(&listChecks, "list-checks", "l", false, "List the checks with their severities")
+	return cmd
+}
+
+func printChecks(out io.Writer, checks []Check) {
+	fmt.Fprintln(out, "Checks:")
+	for _, check := range checks {
+		fmt.Fprintf(out, "  %s (%s): %s\n", check.Name, check.Severity, check.Description)
+	}
+}
+
+func printResult(out io.Writer, outputFormat string, result Result) error {
+	if outputFormat == outputFormatJSON {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return sanitizederror.NewWithError("failed to encode the findings", err)
+		}
+
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	}
+
+	name := ""
+	for _, finding := range result.Findings {
+		findingName := finding.Policy
+		if finding.Rule != "" {
+			findingName = finding.Policy + "/" + finding.Rule
+		}
+
+		if findingName != name {
+			name = findingName
+			fmt.Fprintf(out, "\n%s\n", name)
+		}
+
+		fmt.Fprintf(out, "  [%s] %s: %s\n", finding.Severity, finding.Check, finding.Message)
+		if finding.Fix != "" {
+			fmt.Fprintln(out, "    suggested fix:")
+			for _, line := range strings.Split(strings.TrimRight(finding.Fix, "\n"), "\n") {
+				fmt.Fprintf(out, "      %s\n", line)
+			}
+		}
+	}
+
+	s := result.Summary
+	fmt.Fprintf(out, "\npolicies: %d, errors: %d, warnings: %d, info: %d, suppressed: %d\n", s.Policies, s.Error, s.Warning, s.Info, s.Suppressed)
+	return nil
+}
@@ -0,0 +1,152 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+)
+
+// IgnoreAnnotation is the policy annotation with the comma separated checks that are suppressed for the policy,
+// a check is suppressed for a single rule with <check>:<rule>
+const IgnoreAnnotation = "policies.kyverno.io/lint-ignore"
+
+// Severity is the severity of a finding
+type Severity string
+
+// severities
+const (
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+var severityLevels = map[Severity]int{SeverityInfo: 0, SeverityWarning: 1, SeverityError: 2}
+
+// ParseSeverity returns the severity of the name
+func ParseSeverity(name string) (Severity, error) {
+	severity := Severity(strings.ToLower(name))
+	if _, ok := severityLevels[severity]; !ok {
+		return "", fmt.Errorf("invalid severity %s, supported severities are info, warning, error", name)
+	}
+	return severity, nil
+}
+
+// AtLeast checks if the severity is the same as or higher than the other severity
+func (s Severity) AtLeast(other Severity) bool {
+	return severityLevels[s] >= severityLevels[other]
+}
+
+// Finding is an issue a check found in a policy
+type Finding struct {
+	Policy   string   `json:"policy"`
+	Rule     string   `json:"rule,omitempty"`
+	Check    string   `json:"check"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	// Fix is the suggested replacement of the field the finding is about
+	Fix string `json:"fix,omitempty"`
+}
+
+// Check is a best-practice check of policies
+type Check struct {
+	Name        string
+	Severity    Severity
+	Description string
+	// Lint returns the findings of the policy, the policy, check and severity of the findings are set by the linter
+	Lint func(policy *v1.ClusterPolicy) []Finding
+}
+
+// Summary counts the policies and the findings of each severity
+type Summary struct {
+	Policies   int `json:"policies"`
+	Error      int `json:"error"`
+	Warning    int `json:"warning"`
+	Info       int `json:"info"`
+	Suppressed int `json:"suppressed"`
+}
+
+// Result is the findings of the checks in the policies
+type Result struct {
+	Findings []Finding `json:"findings"`
+	Summary  Summary   `json:"summary"`
+}
+
+// Lint runs the checks on the policies, the findings below the minimum severity are left out
+func Lint(policies []*v1.ClusterPolicy, checks []Check, minSeverity Severity) Result {
+	result := Result{Findings: []Finding{}, Summary: Summary{Policies: len(policies)}}
+	for _, policy := range policies {
+		ignored := ignoredChecks(policy)
+		for _, check := range checks {
+			if !check.Severity.AtLeast(minSeverity) {
+				continue
+			}
+
+			for _, finding := range check.Lint(policy) {
+				if ignored[check.Name] || (finding.Rule != "" && ignored[check.Name+":"+finding.Rule]) {
+					result.Summary.Suppressed++
+					continue
+				}
+
+				finding.Policy = policy.GetName()
+				finding.Check = check.Name
+				finding.Severity = check.Severity
+				result.Findings = append(result.Findings, finding)
+
+				switch check.Severity {
+				case SeverityError:
+					result.Summary.Error++
+				case SeverityWarning:
+					result.Summary.Warning++
+				case SeverityInfo:
+					result.Summary.Info++
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(result.Findings, func(i, j int) bool {
+		a, b := result.Findings[i], result.Findings[j]
+		if a.Policy != b.Policy {
+			return a.Policy < b.Policy
+		}
+		return a.Rule < b.Rule
+	})
+	return result
+}
+
+// ignoredChecks returns the checks suppressed by the ignore annotation of the policy
+func ignoredChecks(policy *v1.ClusterPolicy) map[string]bool {
+	ignored := make(map[string]bool)
+	for _, name := range strings.Split(policy.GetAnnotations()[IgnoreAnnotation], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			ignored[name] = true
+		}
+	}
+	return ignored
+}
+
+// SelectChecks returns the checks without the disabled checks
+func SelectChecks(checks []Check, disabled []string) ([]Check, error) {
+	names := make(map[string]bool)
+	for _, check := range checks {
+		names[check.Name] = true
+	}
+
+	skip := make(map[string]bool)
+	for _, name := range disabled {
+		if !names[name] {
+			return nil, fmt.Errorf("unknown check %s", name)
+		}
+		skip[name] = true
+	}
+
+	var selected []Check
+	for _, check := range checks {
+		if !skip[check.Name] {
+			selected = append(selected, check)
+		}
+	}
+	return selected, nil
+}
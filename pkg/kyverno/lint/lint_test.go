@@ -0,0 +1,121 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	ut "github.com/kyverno/kyverno/pkg/utils"
+	"gotest.tools/assert"
+)
+
+var lintPolicies = []byte(`apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: add-sidecar
+spec:
+  rules:
+  - name: append-container
+    match:
+      resources:
+        kinds:
+        - Pod
+    exclude:
+      resources:
+        namespaces:
+        - kube-*
+    mutate:
+      patchesJson6902: |-
+        - op: add
+          path: /spec/containers/-
+          value:
+            name: sidecar
+            image: sidecar:1.0
+  - name: add-label
+    match:
+      resources:
+        kinds:
+        - Pod
+        namespaces:
+        - prod
+    mutate:
+      overlay:
+        metadata:
+          labels:
+            team: platform
+---
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: check-operator
+  annotations:
+    policies.kyverno.io/lint-ignore: "validate-message:check-all"
+spec:
+  rules:
+  - name: check-all
+    context:
+    - name: quota
+      configMap:
+        name: quota
+        namespace: default
+    - name: limits
+      configMap:
+        name: limits
+        namespace: default
+    match:
+      resources:
+        kinds:
+        - "*"
+    validate:
+      deny:
+        conditions:
+        - key: "{{ request.userInfo.username }}"
+          operator: Equals
+          value: "{{ limits.data.user }}"
+`)
+
+func Test_Lint(t *testing.T) {
+	policies, err := ut.GetPolicy(lintPolicies)
+	assert.NilError(t, err)
+
+	result := Lint(policies, DefaultChecks(), SeverityInfo)
+	var found []string
+	for _, finding := range result.Findings {
+		found = append(found, finding.Policy+"/"+finding.Rule+" "+finding.Check)
+	}
+
+	assert.DeepEqual(t, found, []string{
+		"add-sidecar/add-label deprecated-fields",
+		"add-sidecar/append-container mutate-idempotent",
+		"check-operator/check-all exclude-system-namespaces",
+		"check-operator/check-all background-admission-variables",
+		"check-operator/check-all wildcard-kinds",
+		"check-operator/check-all unused-context",
+	})
+	assert.DeepEqual(t, result.Summary, Summary{Policies: 2, Error: 1, Warning: 4, Info: 1, Suppressed: 1})
+
+	fix := result.Findings[0].Fix
+	assert.Assert(t, strings.Contains(fix, "patchStrategicMerge"), fix)
+	assert.Assert(t, !strings.Contains(fix, "overlay"), fix)
+	assert.Assert(t, strings.Contains(result.Findings[5].Message, "quota"))
+
+	// the findings below the minimum severity are left out
+	result = Lint(policies, DefaultChecks(), SeverityError)
+	assert.Equal(t, len(result.Findings), 1)
+	assert.Equal(t, result.Findings[0].Check, "background-admission-variables")
+}
+
+func Test_SelectChecks(t *testing.T) {
+	checks, err := SelectChecks(DefaultChecks(), []string{"unused-context"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(checks), len(DefaultChecks())-1)
+
+	_, err = SelectChecks(DefaultChecks(), []string{"unknown"})
+	assert.ErrorContains(t, err, "unknown check")
+}
+
+func Test_ReferencesName(t *testing.T) {
+	assert.Assert(t, referencesName("{{ request.userInfo.username }}", "request.userInfo"))
+	assert.Assert(t, referencesName("{{ length(podcount) }}", "podcount"))
+	assert.Assert(t, !referencesName("{{ request.object.metadata.podcount }}", "podcount"))
+	assert.Assert(t, !referencesName("{{ podcounts }}", "podcount"))
+}
@@ -5,6 +5,10 @@ import (
 	"os"
 
 	"github.com/kyverno/kyverno/pkg/kyverno/apply"
+	"github.com/kyverno/kyverno/pkg/kyverno/convert"
+	"github.com/kyverno/kyverno/pkg/kyverno/diff"
+	"github.com/kyverno/kyverno/pkg/kyverno/jp"
+	"github.com/kyverno/kyverno/pkg/kyverno/lint"
 	"github.com/kyverno/kyverno/pkg/kyverno/test"
 	"github.com/kyverno/kyverno/pkg/kyverno/validate"
 	"github.com/kyverno/kyverno/pkg/kyverno/version"
@@ -28,6 +32,10 @@ func CLI() {
 		apply.Command(),
 		validate.Command(),
 		test.Command(),
+		jp.Command(),
+		diff.Command(),
+		lint.Command(),
+		convert.Command(),
 	}
 
 	cli.AddCommand(commands...)
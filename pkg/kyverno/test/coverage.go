@@ -0,0 +1,195 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	report "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha2"
+	"github.com/lensesio/tableprinter"
+)
+
+// coverage formats
+const (
+	coverageFormatText = "text"
+	coverageFormatJSON = "json"
+)
+
+// coverageOptions are the options of the coverage report, the coverage is not collected when the format is empty
+type coverageOptions struct {
+	format string
+	// file is the file the report is written to, the report is printed when it is empty
+	file string
+	// threshold is the minimum percentage of rules with a passing and a failing test case
+	threshold float64
+	// out is the stream the report is printed to when no file is given, apart from the test results
+	out io.Writer
+}
+
+// RuleCoverage counts the actual results of the test cases of a rule
+type RuleCoverage struct {
+	Policy string `json:"policy" header:"policy"`
+	Rule   string `json:"rule" header:"rule"`
+	Pass   int    `json:"pass" header:"pass"`
+	Fail   int    `json:"fail" header:"fail"`
+	Skip   int    `json:"skip" header:"skip"`
+	Other  int    `json:"other" header:"warn/error"`
+	// Covered is set when the rule has a passing and a failing test case
+	Covered bool `json:"covered" header:"covered"`
+}
+
+// CoverageSummary counts the rules, the rules with a test case and the rules with a passing and a failing test case
+type CoverageSummary struct {
+	Rules   int     `json:"rules"`
+	Tested  int     `json:"tested"`
+	Covered int     `json:"covered"`
+	Percent float64 `json:"percent"`
+}
+
+// CoverageReport is the rule coverage of the tests
+type CoverageReport struct {
+	Rules   []RuleCoverage  `json:"rules"`
+	Summary CoverageSummary `json:"summary"`
+}
+
+// coverage collects the results of the test cases of the rules of the policies across the test files
+type coverage struct {
+	rules map[string]*RuleCoverage
+}
+
+func newCoverage() *coverage {
+	return &coverage{rules: make(map[string]*RuleCoverage)}
+}
+
+func coverageKey(policy, rule string) string {
+	return policy + "/" + rule
+}
+
+// addPolicies adds the rules of the policies, the rules generated for pod controllers are counted as their pod rule
+func (c *coverage) addPolicies(policies []*v1.ClusterPolicy) {
+	if c == nil {
+		return
+	}
+
+	for _, policy := range policies {
+		for _, rule := range policy.Spec.Rules {
+			name := trimAutogenPrefix(rule.Name)
+			key := coverageKey(policy.GetName(), name)
+			if _, ok := c.rules[key]; !ok {
+				c.rules[key] = &RuleCoverage{Policy: policy.GetName(), Rule: name}
+			}
+		}
+	}
+}
+
+// addResults adds the actual results of the test cases
+func (c *coverage) addResults(testResults []TestResults, results map[string]report.PolicyReportResult) {
+	if c == nil {
+		return
+	}
+
+	for _, test := range testResults {
+		result, ok := results[fmt.Sprintf("%s-%s-%s", test.Policy, test.Rule, test.Resource)]
+		if !ok {
+			continue
+		}
+
+		key := coverageKey(test.Policy, trimAutogenPrefix(test.Rule))
+		rule, ok := c.rules[key]
+		if !ok {
+			continue
+		}
+
+		switch result.Result {
+		case report.StatusPass:
+			rule.Pass++
+		case report.StatusFail:
+			rule.Fail++
+		case report.StatusSkip:
+			rule.Skip++
+		default:
+			rule.Other++
+		}
+	}
+}
+
+func (c *coverage) report() CoverageReport {
+	r := CoverageReport{Rules: []RuleCoverage{}}
+	for _, rule := range c.rules {
+		rule.Covered = rule.Pass > 0 && rule.Fail > 0
+		r.Rules = append(r.Rules, *rule)
+		if rule.Pass+rule.Fail+rule.Skip+rule.Other > 0 {
+			r.Summary.Tested++
+		}
+
+		if rule.Covered {
+			r.Summary.Covered++
+		}
+	}
+
+	sort.Slice(r.Rules, func(i, j int) bool {
+		return coverageKey(r.Rules[i].Policy, r.Rules[i].Rule) < coverageKey(r.Rules[j].Policy, r.Rules[j].Rule)
+	})
+
+	r.Summary.Rules = len(r.Rules)
+	if r.Summary.Rules > 0 {
+		r.Summary.Percent = float64(r.Summary.Covered) * 100 / float64(r.Summary.Rules)
+	}
+	return r
+}
+
+func printCoverage(out io.Writer, format string, r CoverageReport) error {
+	if format == coverageFormatJSON {
+		data, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	}
+
+	fmt.Fprintf(out, "\nRule coverage:\n")
+	printer := tableprinter.New(out)
+	printer.BorderTop, printer.BorderBottom, printer.BorderLeft, printer.BorderRight = true, true, true, true
+	printer.CenterSeparator = "│"
+	printer.ColumnSeparator = "│"
+	printer.RowSeparator = "─"
+	printer.Print(r.Rules)
+
+	s := r.Summary
+	_, err := fmt.Fprintf(out, "\nrules: %d, tested: %d, covered with a passing and a failing test case: %d (%.1f%%)\n", s.Rules, s.Tested, s.Covered, s.Percent)
+	return err
+}
+
+// reportCoverage prints or writes the coverage report, it returns false when the coverage is below the threshold
+// and lists the rules which are not covered to out
+func reportCoverage(out io.Writer, r CoverageReport, opts coverageOptions) (bool, error) {
+	reportOut := opts.out
+	if opts.file != "" {
+		file, err := os.Create(opts.file)
+		if err != nil {
+			return false, err
+		}
+		defer file.Close()
+		reportOut = file
+	}
+
+	if err := printCoverage(reportOut, opts.format, r); err != nil {
+		return false, err
+	}
+
+	if opts.threshold > 0 && r.Summary.Percent < opts.threshold {
+		fmt.Fprintf(out, "\nrule coverage %.1f%% is below the threshold %.1f%%, rules without a passing and a failing test case:\n", r.Summary.Percent, opts.threshold)
+		for _, rule := range r.Rules {
+			if !rule.Covered {
+				fmt.Fprintf(out, "    %s/%s\n", rule.Policy, rule.Rule)
+			}
+		}
+		return false, nil
+	}
+	return true, nil
+}
@@ -0,0 +1,129 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	report "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha2"
+	"gotest.tools/assert"
+)
+
+func newCoveragePolicy(name string, rules ...string) *v1.ClusterPolicy {
+	policy := &v1.ClusterPolicy{}
+	policy.SetName(name)
+	for _, rule := range rules {
+		policy.Spec.Rules = append(policy.Spec.Rules, v1.Rule{Name: rule})
+	}
+	return policy
+}
+
+func newTestCoverage() *coverage {
+	c := newCoverage()
+	c.addPolicies([]*v1.ClusterPolicy{
+		newCoveragePolicy("require-labels", "check-team", "autogen-check-team", "autogen-cronjob-check-team", "check-app"),
+		newCoveragePolicy("disallow-latest", "validate-image-tag"),
+	})
+
+	testResults := []TestResults{
+		{Policy: "require-labels", Rule: "check-team", Resource: "pod-pass"},
+		{Policy: "require-labels", Rule: "autogen-check-team", Resource: "deployment-fail"},
+		{Policy: "require-labels", Rule: "autogen-cronjob-check-team", Resource: "cronjob-skip"},
+		{Policy: "require-labels", Rule: "check-app", Resource: "pod-pass"},
+		{Policy: "require-labels", Rule: "check-app", Resource: "pod-error"},
+		{Policy: "require-labels", Rule: "check-app", Resource: "pod-missing"},
+		{Policy: "require-labels", Rule: "unknown", Resource: "pod-pass"},
+	}
+	results := map[string]report.PolicyReportResult{
+		"require-labels-check-team-pod-pass":                         {Result: report.StatusPass},
+		"require-labels-autogen-check-team-deployment-fail":          {Result: report.StatusFail},
+		"require-labels-autogen-cronjob-check-team-cronjob-skip":     {Result: report.StatusSkip},
+		"require-labels-check-app-pod-pass":                          {Result: report.StatusPass},
+		"require-labels-check-app-pod-error":                         {Result: report.StatusError},
+		"require-labels-unknown-pod-pass":                            {Result: report.StatusPass},
+		"disallow-latest-validate-image-tag-not-in-the-test-results": {Result: report.StatusFail},
+	}
+	c.addResults(testResults, results)
+	return c
+}
+
+func Test_coverage_addPolicies(t *testing.T) {
+	c := newTestCoverage()
+
+	// the rules generated for pod controllers are counted as their pod rule
+	assert.Equal(t, len(c.rules), 3)
+	assert.DeepEqual(t, *c.rules["require-labels/check-team"], RuleCoverage{Policy: "require-labels", Rule: "check-team", Pass: 1, Fail: 1, Skip: 1})
+	assert.DeepEqual(t, *c.rules["require-labels/check-app"], RuleCoverage{Policy: "require-labels", Rule: "check-app", Pass: 1, Other: 1})
+	assert.DeepEqual(t, *c.rules["disallow-latest/validate-image-tag"], RuleCoverage{Policy: "disallow-latest", Rule: "validate-image-tag"})
+
+	// the coverage is not collected when it is not reported
+	var disabled *coverage
+	disabled.addPolicies([]*v1.ClusterPolicy{newCoveragePolicy("require-labels", "check-team")})
+	disabled.addResults([]TestResults{{Policy: "require-labels", Rule: "check-team", Resource: "pod-pass"}}, nil)
+}
+
+func Test_coverage_report(t *testing.T) {
+	r := newTestCoverage().report()
+
+	assert.DeepEqual(t, r.Rules, []RuleCoverage{
+		{Policy: "disallow-latest", Rule: "validate-image-tag"},
+		{Policy: "require-labels", Rule: "check-app", Pass: 1, Other: 1},
+		{Policy: "require-labels", Rule: "check-team", Pass: 1, Fail: 1, Skip: 1, Covered: true},
+	})
+	assert.Equal(t, r.Summary.Rules, 3)
+	assert.Equal(t, r.Summary.Tested, 2)
+	assert.Equal(t, r.Summary.Covered, 1)
+	assert.Equal(t, r.Summary.Percent, float64(100)/3)
+
+	empty := newCoverage().report()
+	assert.Equal(t, len(empty.Rules), 0)
+	assert.Equal(t, empty.Summary.Percent, float64(0))
+}
+
+func Test_reportCoverage(t *testing.T) {
+	r := newTestCoverage().report()
+
+	// the JSON report is printed on its own stream
+	var stdout, messages bytes.Buffer
+	covered, err := reportCoverage(&messages, r, coverageOptions{format: coverageFormatJSON, out: &stdout})
+	assert.NilError(t, err)
+	assert.Assert(t, covered)
+	assert.Equal(t, messages.String(), "")
+
+	var printed CoverageReport
+	assert.NilError(t, json.Unmarshal(stdout.Bytes(), &printed))
+	assert.DeepEqual(t, printed, r)
+
+	// the report is written to the file instead
+	stdout.Reset()
+	file := filepath.Join(t.TempDir(), "coverage.txt")
+	covered, err = reportCoverage(&messages, r, coverageOptions{format: coverageFormatText, file: file, threshold: 30, out: &stdout})
+	assert.NilError(t, err)
+	assert.Assert(t, covered)
+	assert.Equal(t, stdout.String(), "")
+
+	data, err := ioutil.ReadFile(file)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(data), "covered with a passing and a failing test case: 1 (33.3%)"), string(data))
+}
+
+func Test_reportCoverage_threshold(t *testing.T) {
+	r := newTestCoverage().report()
+
+	var stdout, messages bytes.Buffer
+	covered, err := reportCoverage(&messages, r, coverageOptions{format: coverageFormatJSON, threshold: 50, out: &stdout})
+	assert.NilError(t, err)
+	assert.Assert(t, !covered, "the test command exits with an error when the coverage is below the threshold")
+	assert.Equal(t, messages.String(), `
+rule coverage 33.3% is below the threshold 50.0%, rules without a passing and a failing test case:
+    disallow-latest/validate-image-tag
+    require-labels/check-app
+`)
+
+	var printed CoverageReport
+	assert.NilError(t, json.Unmarshal(stdout.Bytes(), &printed))
+}
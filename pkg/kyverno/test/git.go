@@ -2,7 +2,7 @@ package test
 
 import (
 	"fmt"
-	"os"
+	"io"
 	"path/filepath"
 
 	"github.com/go-git/go-billy/v5"
@@ -11,11 +11,11 @@ import (
 	"github.com/go-git/go-git/v5/storage/memory"
 )
 
-func clone(path string, fs billy.Filesystem, branch string) (*git.Repository, error) {
+func clone(path string, fs billy.Filesystem, branch string, progress io.Writer) (*git.Repository, error) {
 	return git.Clone(memory.NewStorage(), fs, &git.CloneOptions{
 		URL:           path,
 		ReferenceName: plumbing.ReferenceName(fmt.Sprintf("refs/heads/%s", branch)),
-		Progress:      os.Stdout,
+		Progress:      progress,
 		SingleBranch:  true,
 	})
 }
@@ -0,0 +1,239 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/go-git/go-billy/v5"
+	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	report "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"github.com/kyverno/kyverno/pkg/engine/utils"
+	"github.com/kyverno/kyverno/pkg/kyverno/common"
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+	yaml1 "sigs.k8s.io/yaml"
+)
+
+// testResultKey returns the key of the results of a policy rule on a resource
+func testResultKey(policy, rule, resource string) string {
+	return fmt.Sprintf("%s-%s-%s", policy, trimAutogenPrefix(rule), resource)
+}
+
+func trimAutogenPrefix(rule string) string {
+	if strings.HasPrefix(rule, "autogen-cronjob-") {
+		return strings.TrimPrefix(rule, "autogen-cronjob-")
+	}
+	return strings.TrimPrefix(rule, "autogen-")
+}
+
+// buildMutateGenerateResults sets the status of the test results of mutate and generate rules
+func buildMutateGenerateResults(results map[string]report.PolicyReportResult, resps []*response.EngineResponse, testResults []TestResults) {
+	for _, resp := range resps {
+		for _, rule := range resp.PolicyResponse.Rules {
+			key := testResultKey(resp.PolicyResponse.Policy.Name, rule.Name, resp.PolicyResponse.Resource.Name)
+			for _, test := range testResults {
+				if testResultKey(test.Policy, test.Rule, test.Resource) != key {
+					continue
+				}
+
+				resultsKey := fmt.Sprintf("%s-%s-%s", test.Policy, test.Rule, test.Resource)
+				result := results[resultsKey]
+				result.Policy = test.Policy
+				result.Rule = test.Rule
+				result.Result = report.PolicyResult(rule.GetStatus())
+				results[resultsKey] = result
+			}
+		}
+	}
+}
+
+// patchedResources returns the resources mutated by each mutate rule, keyed by policy, rule and resource
+func patchedResources(resps []*response.EngineResponse) map[string]unstructured.Unstructured {
+	patched := make(map[string]unstructured.Unstructured)
+	for _, resp := range resps {
+		for _, rule := range resp.PolicyResponse.Rules {
+			if rule.Type != utils.Mutation.String() {
+				continue
+			}
+
+			patched[testResultKey(resp.PolicyResponse.Policy.Name, rule.Name, resp.PatchedResource.GetName())] = resp.PatchedResource
+		}
+	}
+	return patched
+}
+
+// loadExpectedResource reads the resource a test result is asserted against
+func loadExpectedResource(fs billy.Filesystem, path string, isGit bool, policyResourcePath string) (*unstructured.Unstructured, error) {
+	var data []byte
+	var err error
+	if isGit {
+		file, err := fs.Open(filepath.Join(policyResourcePath, path))
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		data, err = ioutil.ReadAll(file)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		data, err = ioutil.ReadFile(filepath.Join(policyResourcePath, path))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resourceJSON, err := yaml.ToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	resource := &unstructured.Unstructured{}
+	if err := resource.UnmarshalJSON(resourceJSON); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// compareResources compares the resources semantically, ignoring the order of map keys,
+// and returns a unified diff of their YAML if they differ; the namespace is only
+// compared when the expected resource sets it
+func compareResources(expected, actual *unstructured.Unstructured) (string, error) {
+	expected = expected.DeepCopy()
+	if expected.GetNamespace() == "" {
+		expected.SetNamespace(actual.GetNamespace())
+	}
+
+	expectedObj, err := normalize(expected.Object)
+	if err != nil {
+		return "", err
+	}
+
+	actualObj, err := normalize(actual.Object)
+	if err != nil {
+		return "", err
+	}
+
+	if reflect.DeepEqual(expectedObj, actualObj) {
+		return "", nil
+	}
+
+	expectedYAML, err := yaml1.Marshal(expectedObj)
+	if err != nil {
+		return "", err
+	}
+
+	actualYAML, err := yaml1.Marshal(actualObj)
+	if err != nil {
+		return "", err
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(expectedYAML)),
+		B:        difflib.SplitLines(string(actualYAML)),
+		FromFile: "expected",
+		ToFile:   "actual",
+		Context:  3,
+	})
+	if err != nil {
+		return "", err
+	}
+	return diff, nil
+}
+
+// normalize converts the object to its generic JSON representation so that numbers compare equal regardless of their Go type
+func normalize(obj map[string]interface{}) (interface{}, error) {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized interface{}
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// addGeneratedResources adds the resources generated for the trigger resource by the generate rules
+// that the test results assert, keyed by policy, rule and resource
+func addGeneratedResources(generated map[string]unstructured.Unstructured, testResults []TestResults, policy *v1.ClusterPolicy, trigger *unstructured.Unstructured,
+	resps []*response.EngineResponse, vars map[string]string, userInfo *common.UserInfo, resources []*unstructured.Unstructured) {
+	for _, test := range testResults {
+		if test.GeneratedResource == "" || test.Policy != policy.GetName() || test.Resource != trigger.GetName() {
+			continue
+		}
+
+		for _, resp := range resps {
+			for _, rule := range resp.PolicyResponse.Rules {
+				if rule.Type != utils.Generation.String() || rule.GetStatus() != response.RuleStatusPass || trimAutogenPrefix(rule.Name) != trimAutogenPrefix(test.Rule) {
+					continue
+				}
+
+				resource, err := common.GenerateResource(policy, rule.Name, trigger, vars, userInfo, resources)
+				if err != nil {
+					log.Log.Error(err, "failed to generate resource", "policy", policy.GetName(), "rule", rule.Name, "resource", trigger.GetName())
+					continue
+				}
+
+				generated[testResultKey(test.Policy, test.Rule, test.Resource)] = *resource
+			}
+		}
+	}
+}
+
+// checkResourceAssertions compares the patched and generated resources with the files referenced by the test results,
+// it returns the failure message of each test result that does not match, keyed by its index
+func checkResourceAssertions(testResults []TestResults, patched, generated map[string]unstructured.Unstructured, load func(path string) (*unstructured.Unstructured, error)) map[int]string {
+	failures := make(map[int]string)
+	for i, test := range testResults {
+		key := testResultKey(test.Policy, test.Rule, test.Resource)
+		var messages []string
+		if test.PatchedResource != "" {
+			if msg := checkResource("patched", test.PatchedResource, patched, key, load); msg != "" {
+				messages = append(messages, msg)
+			}
+		}
+
+		if test.GeneratedResource != "" {
+			if msg := checkResource("generated", test.GeneratedResource, generated, key, load); msg != "" {
+				messages = append(messages, msg)
+			}
+		}
+
+		if len(messages) > 0 {
+			failures[i] = strings.Join(messages, "\n")
+		}
+	}
+	return failures
+}
+
+func checkResource(kind, path string, actualResources map[string]unstructured.Unstructured, key string, load func(path string) (*unstructured.Unstructured, error)) string {
+	actual, ok := actualResources[key]
+	if !ok {
+		return fmt.Sprintf("no %s resource found to compare with %s", kind, path)
+	}
+
+	expected, err := load(path)
+	if err != nil {
+		return fmt.Sprintf("failed to load the %s resource %s: %v", kind, path, err)
+	}
+
+	diff, err := compareResources(expected, &actual)
+	if err != nil {
+		return fmt.Sprintf("failed to compare the %s resource with %s: %v", kind, path, err)
+	}
+
+	if diff != "" {
+		return fmt.Sprintf("%s resource does not match %s:\n%s", kind, path, diff)
+	}
+	return ""
+}
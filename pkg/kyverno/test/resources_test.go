@@ -0,0 +1,122 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/kyverno/common"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func loadResource(t *testing.T, raw string) *unstructured.Unstructured {
+	resources, err := common.GetResource([]byte(raw))
+	assert.NilError(t, err)
+	assert.Equal(t, len(resources), 1)
+	return resources[0]
+}
+
+func Test_compareResources(t *testing.T) {
+	expected := loadResource(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+  labels:
+    tier: web
+    app: nginx
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: nginx
+        image: nginx:1.21
+`)
+
+	// the keys are ordered differently and the replicas are a float64 like the engine returns them
+	actual := &unstructured.Unstructured{Object: map[string]interface{}{
+		"kind":       "Deployment",
+		"apiVersion": "apps/v1",
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"image": "nginx:1.21", "name": "nginx"},
+					},
+				},
+			},
+			"replicas": float64(2),
+		},
+		"metadata": map[string]interface{}{
+			"namespace": "default",
+			"name":      "nginx",
+			"labels":    map[string]interface{}{"app": "nginx", "tier": "web"},
+		},
+	}}
+
+	diff, err := compareResources(expected, actual)
+	assert.NilError(t, err)
+	assert.Equal(t, diff, "")
+
+	assert.NilError(t, unstructured.SetNestedField(actual.Object, int64(3), "spec", "replicas"))
+	diff, err = compareResources(expected, actual)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(diff, "-  replicas: 2"), diff)
+	assert.Assert(t, strings.Contains(diff, "+  replicas: 3"), diff)
+}
+
+func Test_checkResourceAssertions(t *testing.T) {
+	files := map[string]string{
+		"patched.yaml": `apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx
+  labels:
+    team: a
+`,
+		"generated.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: defaults
+  namespace: team-a
+data:
+  owner: alice
+`,
+	}
+
+	load := func(path string) (*unstructured.Unstructured, error) {
+		raw, ok := files[path]
+		if !ok {
+			return nil, fmt.Errorf("file %s not found", path)
+		}
+		return loadResource(t, raw), nil
+	}
+
+	patched := map[string]unstructured.Unstructured{
+		testResultKey("add-labels", "autogen-add-team", "nginx"): *loadResource(t, files["patched.yaml"]),
+	}
+
+	configMap := loadResource(t, files["generated.yaml"])
+	assert.NilError(t, unstructured.SetNestedField(configMap.Object, "bob", "data", "owner"))
+	generated := map[string]unstructured.Unstructured{
+		testResultKey("add-defaults", "generate-configmap", "team-a"): *configMap,
+	}
+
+	testResults := []TestResults{
+		{Policy: "add-labels", Rule: "add-team", Resource: "nginx", PatchedResource: "patched.yaml"},
+		{Policy: "add-defaults", Rule: "generate-configmap", Resource: "team-a", GeneratedResource: "generated.yaml"},
+		{Policy: "add-defaults", Rule: "generate-configmap", Resource: "team-b", GeneratedResource: "generated.yaml"},
+		{Policy: "add-labels", Rule: "add-team", Resource: "nginx", PatchedResource: "missing.yaml"},
+	}
+
+	failures := checkResourceAssertions(testResults, patched, generated, load)
+	assert.Equal(t, len(failures), 3)
+
+	_, ok := failures[0]
+	assert.Assert(t, !ok, "the patched resource matches regardless of the autogen prefix of the rule")
+	assert.Assert(t, strings.HasPrefix(failures[1], "generated resource does not match generated.yaml"), failures[1])
+	assert.Assert(t, strings.Contains(failures[1], "+  owner: bob"), failures[1])
+	assert.Equal(t, failures[2], "no generated resource found to compare with generated.yaml")
+	assert.Assert(t, strings.HasPrefix(failures[3], "failed to load the patched resource missing.yaml"), failures[3])
+}
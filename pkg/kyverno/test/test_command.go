@@ -3,6 +3,7 @@ package test
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/url"
 	"os"
@@ -16,7 +17,7 @@ import (
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/kataras/tablewriter"
 	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
-	report "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha1"
+	report "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha2"
 	client "github.com/kyverno/kyverno/pkg/dclient"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/engine/utils"
@@ -30,6 +31,7 @@ import (
 	"github.com/lensesio/tableprinter"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	log "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -38,6 +40,9 @@ import (
 func Command() *cobra.Command {
 	var cmd *cobra.Command
 	var valuesFile, fileName string
+	var update, addMissing, failOnly bool
+	var coverageFormat, coverageFile string
+	var coverageThreshold float64
 	cmd = &cobra.Command{
 		Use:   "test",
 		Short: "run tests from directory",
@@ -50,7 +55,30 @@ func Command() *cobra.Command {
 					}
 				}
 			}()
-			_, err = testCommandExecute(dirPath, valuesFile, fileName)
+			if coverageFormat != "" && coverageFormat != coverageFormatText && coverageFormat != coverageFormatJSON {
+				return sanitizederror.NewWithError(fmt.Sprintf("invalid coverage format %s, supported formats are text, json", coverageFormat), nil)
+			}
+
+			if coverageThreshold < 0 || coverageThreshold > 100 {
+				return sanitizederror.NewWithError("the coverage threshold must be a percentage between 0 and 100", nil)
+			}
+
+			if addMissing && !update {
+				return sanitizederror.NewWithError("the add-missing flag can only be used with the update flag", nil)
+			}
+
+			if coverageFormat == "" && (coverageFile != "" || coverageThreshold > 0) {
+				coverageFormat = coverageFormatText
+			}
+
+			// the test results go to stderr when the coverage is printed as JSON, to keep it parsable
+			out := cmd.OutOrStdout()
+			if coverageFormat == coverageFormatJSON && coverageFile == "" {
+				out = cmd.ErrOrStderr()
+			}
+
+			_, err = testCommandExecute(out, dirPath, valuesFile, fileName, update, addMissing, failOnly,
+				coverageOptions{format: coverageFormat, file: coverageFile, threshold: coverageThreshold, out: cmd.OutOrStdout()})
 			if err != nil {
 				log.Log.V(3).Info("a directory is required")
 				return err
@@ -59,6 +87,12 @@ func Command() *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&fileName, "file-name", "f", "test.yaml", "test filename")
+	cmd.Flags().BoolVar(&update, "update", false, "Rewrite the results of the test files and the patched and generated resource files to the actual results")
+	cmd.Flags().BoolVar(&addMissing, "add-missing", false, "With --update, also add the results of the rules applied to resources that the test files do not list")
+	cmd.Flags().BoolVar(&failOnly, "fail-only", false, "Only print the test results that fail")
+	cmd.Flags().StringVar(&coverageFormat, "coverage", "", "Print the rule coverage of the tests, one of: text, json")
+	cmd.Flags().StringVar(&coverageFile, "coverage-file", "", "Write the rule coverage to the file instead of the standard output")
+	cmd.Flags().Float64Var(&coverageThreshold, "coverage-threshold", 0, "Minimum percentage of rules with a passing and a failing test case, the run fails below it")
 	return cmd
 }
 
@@ -67,6 +101,7 @@ type Test struct {
 	Policies  []string      `json:"policies"`
 	Resources []string      `json:"resources"`
 	Variables string        `json:"variables"`
+	UserInfo  string        `json:"userinfo"`
 	Results   []TestResults `json:"results"`
 }
 
@@ -79,8 +114,12 @@ type SkippedPolicy struct {
 type TestResults struct {
 	Policy   string              `json:"policy"`
 	Rule     string              `json:"rule"`
-	Status   report.PolicyStatus `json:"status"`
+	Status   report.PolicyResult `json:"status"`
 	Resource string              `json:"resource"`
+	// PatchedResource is the path of the file the mutated resource is compared with
+	PatchedResource string `json:"patchedResource,omitempty"`
+	// GeneratedResource is the path of the file the resource created by the generate rule is compared with
+	GeneratedResource string `json:"generatedResource,omitempty"`
 }
 
 type ReportResult struct {
@@ -111,17 +150,26 @@ type resultCounts struct {
 	skip int
 	pass int
 	fail int
+	// coverage collects the rule coverage across the test files, it is nil when the coverage is not reported
+	coverage *coverage
 }
 
-func testCommandExecute(dirPath []string, valuesFile string, fileName string) (rc *resultCounts, err error) {
+func testCommandExecute(out io.Writer, dirPath []string, valuesFile string, fileName string, update bool, addMissing bool, failOnly bool, coverageOpts coverageOptions) (rc *resultCounts, err error) {
 	var errors []error
 	fs := memfs.New()
 	rc = &resultCounts{}
+	if coverageOpts.format != "" {
+		rc.coverage = newCoverage()
+	}
 	var testYamlCount int
 	if len(dirPath) == 0 {
 		return rc, sanitizederror.NewWithError(fmt.Sprintf("a directory is required"), err)
 	}
 	if strings.Contains(string(dirPath[0]), "https://") {
+		if update {
+			return rc, sanitizederror.NewWithError("the test files of a git repository cannot be updated, use a local directory", nil)
+		}
+
 		gitURL, err := url.Parse(dirPath[0])
 		if err != nil {
 			return rc, sanitizederror.NewWithError("failed to parse URL", err)
@@ -129,15 +177,15 @@ func testCommandExecute(dirPath []string, valuesFile string, fileName string) (r
 		pathElems := strings.Split(gitURL.Path[1:], "/")
 		if len(pathElems) <= 2 {
 			err := fmt.Errorf("invalid URL path %s - expected https://github.com/:owner/:repository/:branch", gitURL.Path)
-			fmt.Printf("Error: failed to parse URL \nCause: %s\n", err)
+			fmt.Fprintf(out, "Error: failed to parse URL \nCause: %s\n", err)
 			os.Exit(1)
 		}
 		gitURL.Path = strings.Join([]string{pathElems[0], pathElems[1]}, "/")
 		repoURL := gitURL.String()
 		branch := strings.ReplaceAll(dirPath[0], repoURL+"/", "")
-		_, cloneErr := clone(repoURL, fs, branch)
+		_, cloneErr := clone(repoURL, fs, branch, out)
 		if cloneErr != nil {
-			fmt.Printf("Error: failed to clone repository \nCause: %s\n", cloneErr)
+			fmt.Fprintf(out, "Error: failed to clone repository \nCause: %s\n", cloneErr)
 			log.Log.V(3).Info(fmt.Sprintf("failed to clone repository  %v as it is not valid", repoURL), "error", cloneErr)
 			os.Exit(1)
 		}
@@ -165,22 +213,32 @@ func testCommandExecute(dirPath []string, valuesFile string, fileName string) (r
 					errors = append(errors, sanitizederror.NewWithError("failed to convert to JSON", err))
 					continue
 				}
-				if err := applyPoliciesFromPath(fs, policyBytes, valuesFile, true, policyresoucePath, rc); err != nil {
+				if err := applyPoliciesFromPath(out, fs, policyBytes, valuesFile, true, policyresoucePath, rc, "", false, false, failOnly); err != nil {
 					return rc, sanitizederror.NewWithError("failed to apply test command", err)
 				}
 			}
 		}
 		if testYamlCount == 0 {
-			fmt.Printf("\n No test yamls available \n")
+			fmt.Fprintf(out, "\n No test yamls available \n")
 		}
 	} else {
 		path := filepath.Clean(dirPath[0])
-		errors = getLocalDirTestFiles(fs, path, fileName, valuesFile, rc)
+		errors = getLocalDirTestFiles(out, fs, path, fileName, valuesFile, rc, update, addMissing, failOnly)
 	}
 	if len(errors) > 0 && log.Log.V(1).Enabled() {
-		fmt.Printf("ignoring errors: \n")
+		fmt.Fprintf(out, "ignoring errors: \n")
 		for _, e := range errors {
-			fmt.Printf("    %v \n", e.Error())
+			fmt.Fprintf(out, "    %v \n", e.Error())
+		}
+	}
+	if rc.coverage != nil {
+		covered, err := reportCoverage(out, rc.coverage.report(), coverageOpts)
+		if err != nil {
+			return rc, sanitizederror.NewWithError("failed to report the rule coverage", err)
+		}
+
+		if !covered {
+			os.Exit(1)
 		}
 	}
 	if rc.fail > 0 {
@@ -190,7 +248,7 @@ func testCommandExecute(dirPath []string, valuesFile string, fileName string) (r
 	return rc, nil
 }
 
-func getLocalDirTestFiles(fs billy.Filesystem, path, fileName, valuesFile string, rc *resultCounts) []error {
+func getLocalDirTestFiles(out io.Writer, fs billy.Filesystem, path, fileName, valuesFile string, rc *resultCounts, update bool, addMissing bool, failOnly bool) []error {
 	var errors []error
 	files, err := ioutil.ReadDir(path)
 	if err != nil {
@@ -198,7 +256,7 @@ func getLocalDirTestFiles(fs billy.Filesystem, path, fileName, valuesFile string
 	}
 	for _, file := range files {
 		if file.IsDir() {
-			getLocalDirTestFiles(fs, filepath.Join(path, file.Name()), fileName, valuesFile, rc)
+			getLocalDirTestFiles(out, fs, filepath.Join(path, file.Name()), fileName, valuesFile, rc, update, addMissing, failOnly)
 			continue
 		}
 		if strings.Contains(file.Name(), fileName) {
@@ -212,7 +270,7 @@ func getLocalDirTestFiles(fs billy.Filesystem, path, fileName, valuesFile string
 				errors = append(errors, sanitizederror.NewWithError("failed to convert json", err))
 				continue
 			}
-			if err := applyPoliciesFromPath(fs, valuesBytes, valuesFile, false, path, rc); err != nil {
+			if err := applyPoliciesFromPath(out, fs, valuesBytes, valuesFile, false, path, rc, filepath.Join(path, file.Name()), update, addMissing, failOnly); err != nil {
 				errors = append(errors, sanitizederror.NewWithError(fmt.Sprintf("failed to apply test command from file %s", file.Name()), err))
 				continue
 			}
@@ -243,7 +301,7 @@ func buildPolicyResults(resps []*response.EngineResponse, testResults []TestResu
 		for _, test := range testResults {
 			if test.Policy == policyName && test.Resource == resourceName {
 				if !util.ContainsString(rules, test.Rule) {
-					result.Status = report.StatusSkip
+					result.Result = report.StatusSkip
 				}
 				resultsKey := fmt.Sprintf("%s-%s-%s", test.Policy, test.Rule, test.Resource)
 				if _, ok := results[resultsKey]; !ok {
@@ -270,7 +328,7 @@ func buildPolicyResults(resps []*response.EngineResponse, testResults []TestResu
 					continue
 				}
 				result.Rule = ruleName
-				result.Status = report.PolicyStatus(rule.Check)
+				result.Result = report.PolicyResult(rule.Check)
 				results[resultsKey] = result
 			}
 		}
@@ -289,11 +347,14 @@ func getPolicyResourceFullPath(path []string, policyResourcePath string, isGit b
 	return path
 }
 
-func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, valuesFile string, isGit bool, policyResourcePath string, rc *resultCounts) (err error) {
+// applyPoliciesFromPath runs the test of the test file, in update mode the test file and the resource
+// files it references are rewritten to the actual results before they are compared
+func applyPoliciesFromPath(out io.Writer, fs billy.Filesystem, policyBytes []byte, valuesFile string, isGit bool, policyResourcePath string, rc *resultCounts, testFile string, update bool, addMissing bool, failOnly bool) (err error) {
 	openAPIController, err := openapi.NewOpenAPIController()
 	engineResponses := make([]*response.EngineResponse, 0)
 	validateEngineResponses := make([]*response.EngineResponse, 0)
 	skippedPolicies := make([]SkippedPolicy, 0)
+	generatedResources := make(map[string]unstructured.Unstructured)
 	var dClient *client.Client
 	values := &Test{}
 	var variablesString string
@@ -303,9 +364,9 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, valuesFile s
 		return sanitizederror.NewWithError("failed to decode yaml", err)
 	}
 
-	fmt.Printf("\nExecuting %s...", values.Name)
+	fmt.Fprintf(out, "\nExecuting %s...", values.Name)
 
-	_, valuesMap, namespaceSelectorMap, err := common.GetVariable(variablesString, values.Variables, fs, isGit, policyResourcePath)
+	_, valuesMap, namespaceSelectorMap, clusterResources, err := common.GetVariable(variablesString, values.Variables, fs, isGit, policyResourcePath)
 	if err != nil {
 		if !sanitizederror.IsErrorSanitized(err) {
 			return sanitizederror.NewWithError("failed to decode yaml", err)
@@ -313,12 +374,29 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, valuesFile s
 		return err
 	}
 
+	// the context entries are loaded from the cluster resources of the values file
+	var fixtureClient *client.Client
+	if len(clusterResources) > 0 {
+		fixtureClient, err = common.NewFixtureClient(clusterResources)
+		if err != nil {
+			return sanitizederror.NewWithError("failed to serve the cluster resources", err)
+		}
+	}
+
+	var userInfo *common.UserInfo
+	if values.UserInfo != "" {
+		userInfo, err = common.GetUserInfoFromPath(fs, values.UserInfo, isGit, policyResourcePath)
+		if err != nil {
+			return err
+		}
+	}
+
 	fullPolicyPath := getPolicyResourceFullPath(values.Policies, policyResourcePath, isGit)
 	fullResourcePath := getPolicyResourceFullPath(values.Resources, policyResourcePath, isGit)
 
 	policies, err := common.GetPoliciesFromPaths(fs, fullPolicyPath, isGit, policyResourcePath)
 	if err != nil {
-		fmt.Printf("Error: failed to load policies\nCause: %s\n", err)
+		fmt.Fprintf(out, "Error: failed to load policies\nCause: %s\n", err)
 		os.Exit(1)
 	}
 
@@ -329,12 +407,16 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, valuesFile s
 		}
 	}
 
-	resources, err := common.GetResourceAccordingToResourcePath(fs, fullResourcePath, false, mutatedPolicies, dClient, "", false, isGit, policyResourcePath)
+	resources, err := common.GetResourceAccordingToResourcePath(fs, fullResourcePath, false, mutatedPolicies, dClient, "", false, isGit, policyResourcePath, out)
 	if err != nil {
-		fmt.Printf("Error: failed to load resources\nCause: %s\n", err)
+		fmt.Fprintf(out, "Error: failed to load resources\nCause: %s\n", err)
 		os.Exit(1)
 	}
 
+	// the sources of clone rules are looked up in the test and the cluster resources
+	cloneSources := make([]*unstructured.Unstructured, 0, len(resources)+len(clusterResources))
+	cloneSources = append(append(cloneSources, resources...), clusterResources...)
+
 	msgPolicies := "1 policy"
 	if len(mutatedPolicies) > 1 {
 		msgPolicies = fmt.Sprintf("%d policies", len(policies))
@@ -346,9 +428,11 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, valuesFile s
 	}
 
 	if len(mutatedPolicies) > 0 && len(resources) > 0 {
-		fmt.Printf("\napplying %s to %s... \n", msgPolicies, msgResources)
+		fmt.Fprintf(out, "\napplying %s to %s... \n", msgPolicies, msgResources)
 	}
 
+	rc.coverage.addPolicies(policies)
+
 	for _, policy := range mutatedPolicies {
 		err := policy2.Validate(policy, nil, true, openAPIController)
 		if err != nil {
@@ -357,6 +441,9 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, valuesFile s
 		}
 
 		matches := common.PolicyHasVariables(*policy)
+		if userInfo != nil {
+			matches = common.RemoveUserInfoVariables(matches)
+		}
 		variable := common.RemoveDuplicateVariables(matches)
 		if len(matches) > 0 && variablesString == "" && values.Variables == "" {
 			skipPolicy := SkippedPolicy{
@@ -385,29 +472,49 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, valuesFile s
 			if len(valuesMap[policy.GetName()]) != 0 && !reflect.DeepEqual(valuesMap[policy.GetName()][resource.GetName()], Resource{}) {
 				thisPolicyResourceValues = valuesMap[policy.GetName()][resource.GetName()].Values
 			}
-			if len(common.PolicyHasVariables(*policy)) > 0 && len(thisPolicyResourceValues) == 0 {
+			if len(matches) > 0 && len(thisPolicyResourceValues) == 0 && fixtureClient == nil {
 				return sanitizederror.NewWithError(fmt.Sprintf("policy %s have variables. pass the values for the variables using set/values_file flag", policy.Name), err)
 			}
 
-			ers, validateErs, _, _, err := common.ApplyPolicyOnResource(policy, resource, "", false, thisPolicyResourceValues, true, namespaceSelectorMap, false)
+			ers, validateErs, _, _, err := common.ApplyPolicyOnResource(policy, resource, "", false, thisPolicyResourceValues, true, namespaceSelectorMap, false, userInfo, fixtureClient, out)
 			if err != nil {
 				return sanitizederror.NewWithError(fmt.Errorf("failed to apply policy %v on resource %v", policy.Name, resource.GetName()).Error(), err)
 			}
 			engineResponses = append(engineResponses, ers...)
 			validateEngineResponses = append(validateEngineResponses, validateErs)
+			addGeneratedResources(generatedResources, values.Results, policy, resource, ers, thisPolicyResourceValues, userInfo, cloneSources)
 		}
 	}
+	if addMissing {
+		values.Results = addMissingResults(values.Results, append(engineResponses, validateEngineResponses...))
+	}
+
 	resultsMap := buildPolicyResults(validateEngineResponses, values.Results)
-	resultErr := printTestResult(resultsMap, values.Results, rc)
+	buildMutateGenerateResults(resultsMap, engineResponses, values.Results)
+	patched := patchedResources(engineResponses)
+	loadResource := func(path string) (*unstructured.Unstructured, error) {
+		return loadExpectedResource(fs, path, isGit, policyResourcePath)
+	}
+
+	if update {
+		if err := updateTest(out, testFile, values.Results, resultsMap, patched, generatedResources, policyResourcePath, loadResource); err != nil {
+			return sanitizederror.NewWithError(fmt.Sprintf("failed to update the test file %s", testFile), err)
+		}
+	}
+
+	resourceFailures := checkResourceAssertions(values.Results, patched, generatedResources, loadResource)
+	rc.coverage.addResults(values.Results, resultsMap)
+	resultErr := printTestResult(out, resultsMap, values.Results, resourceFailures, rc, failOnly)
 	if resultErr != nil {
 		return sanitizederror.NewWithError("Unable to genrate result. Error:", resultErr)
 	}
 	return
 }
 
-func printTestResult(resps map[string]report.PolicyReportResult, testResults []TestResults, rc *resultCounts) error {
-	printer := tableprinter.New(os.Stdout)
+func printTestResult(out io.Writer, resps map[string]report.PolicyReportResult, testResults []TestResults, resourceFailures map[int]string, rc *resultCounts, failOnly bool) error {
+	printer := tableprinter.New(out)
 	table := []*Table{}
+	var failures []string
 	boldGreen := color.New(color.FgGreen).Add(color.Bold)
 	boldRed := color.New(color.FgRed).Add(color.Bold)
 	boldYellow := color.New(color.FgYellow).Add(color.Bold)
@@ -426,14 +533,25 @@ func printTestResult(resps map[string]report.PolicyReportResult, testResults []T
 			table = append(table, res)
 			continue
 		}
-		if testRes.Status == v.Status {
-			if testRes.Status == report.StatusSkip {
+		if failure, ok := resourceFailures[i]; ok {
+			res.Result = boldRed.Sprintf("Fail")
+			rc.fail++
+			table = append(table, res)
+			failures = append(failures, fmt.Sprintf("%d. %s\n%s", res.ID, v.Resource+" with "+v.Policy+"/"+v.Rule, failure))
+			continue
+		}
+		if testRes.Result == v.Status || (v.Status == "" && (v.PatchedResource != "" || v.GeneratedResource != "")) {
+			if testRes.Result == report.StatusSkip {
 				res.Result = boldGreen.Sprintf("Skip")
 				rc.skip++
 			} else {
 				res.Result = boldGreen.Sprintf("Pass")
 				rc.pass++
 			}
+
+			if failOnly {
+				continue
+			}
 		} else {
 			res.Result = boldRed.Sprintf("Fail")
 			rc.fail++
@@ -450,6 +568,14 @@ func printTestResult(resps map[string]report.PolicyReportResult, testResults []T
 	}
 	printer.HeaderBgColor = tablewriter.BgBlackColor
 	printer.HeaderFgColor = tablewriter.FgGreenColor
+	if failOnly && len(table) == 0 {
+		fmt.Fprintf(out, "\nAll %d tests passed\n", len(testResults))
+		return nil
+	}
+
 	printer.Print(table)
+	if len(failures) > 0 {
+		fmt.Fprintf(out, "\n%s\n", strings.Join(failures, "\n"))
+	}
 	return nil
 }
@@ -0,0 +1,233 @@
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+
+	report "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	yamlv3 "gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	yaml1 "sigs.k8s.io/yaml"
+)
+
+// addMissingResults adds a test result for each rule applied to a resource that the test does not list yet,
+// it is only used with the add-missing flag and the status of the added results is set by updateTestResults
+func addMissingResults(testResults []TestResults, resps []*response.EngineResponse) []TestResults {
+	listed := make(map[string]bool)
+	for _, test := range testResults {
+		listed[testResultKey(test.Policy, test.Rule, test.Resource)] = true
+	}
+
+	for _, resp := range resps {
+		for _, rule := range resp.PolicyResponse.Rules {
+			key := testResultKey(resp.PolicyResponse.Policy.Name, rule.Name, resp.PolicyResponse.Resource.Name)
+			if listed[key] {
+				continue
+			}
+
+			listed[key] = true
+			testResults = append(testResults, TestResults{
+				Policy:   resp.PolicyResponse.Policy.Name,
+				Rule:     trimAutogenPrefix(rule.Name),
+				Resource: resp.PolicyResponse.Resource.Name,
+			})
+		}
+	}
+	return testResults
+}
+
+// updateTestResults sets the status of the test results to the actual results, the status of results that only
+// assert a patched or generated resource is left empty; it returns the test results that have no actual result
+func updateTestResults(testResults []TestResults, results map[string]report.PolicyReportResult) []TestResults {
+	var notFound []TestResults
+	for i, test := range testResults {
+		result, ok := results[fmt.Sprintf("%s-%s-%s", test.Policy, test.Rule, test.Resource)]
+		if !ok {
+			notFound = append(notFound, test)
+			continue
+		}
+
+		if test.Status == "" && (test.PatchedResource != "" || test.GeneratedResource != "") {
+			continue
+		}
+
+		testResults[i].Status = result.Result
+	}
+	return notFound
+}
+
+// writeResourceFiles writes the actual patched and generated resources to the files the test results compare them with,
+// a file is only written when it cannot be loaded or differs from the actual resource; it returns the written files
+func writeResourceFiles(testResults []TestResults, patched, generated map[string]unstructured.Unstructured, policyResourcePath string,
+	load func(path string) (*unstructured.Unstructured, error)) ([]string, error) {
+	var written []string
+	write := func(path string, resources map[string]unstructured.Unstructured, key string) error {
+		resource, ok := resources[key]
+		if path == "" || !ok {
+			return nil
+		}
+
+		if expected, err := load(path); err == nil {
+			if diff, err := compareResources(expected, &resource); err == nil && diff == "" {
+				return nil
+			}
+		}
+
+		if err := writeResourceFile(filepath.Join(policyResourcePath, path), resource); err != nil {
+			return err
+		}
+
+		written = append(written, path)
+		return nil
+	}
+
+	for _, test := range testResults {
+		key := testResultKey(test.Policy, test.Rule, test.Resource)
+		if err := write(test.PatchedResource, patched, key); err != nil {
+			return written, err
+		}
+
+		if err := write(test.GeneratedResource, generated, key); err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+func writeResourceFile(path string, resource unstructured.Unstructured) error {
+	data, err := yaml1.Marshal(resource.Object)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// updateTestFile rewrites the results of the test file, the comments and the order of the
+// existing results are preserved and the results added with the add-missing flag are appended
+func updateTestFile(path string, testResults []TestResults) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	if doc.Kind != yamlv3.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yamlv3.MappingNode {
+		return fmt.Errorf("the test file %s is not a YAML mapping", path)
+	}
+
+	results := mappingValue(doc.Content[0], "results")
+	if results == nil {
+		results = &yamlv3.Node{Kind: yamlv3.SequenceNode, Tag: "!!seq"}
+		doc.Content[0].Content = append(doc.Content[0].Content, scalarNode("results"), results)
+	}
+
+	updated := make(map[int]bool)
+	for _, item := range results.Content {
+		if item.Kind != yamlv3.MappingNode {
+			continue
+		}
+
+		for i, test := range testResults {
+			if updated[i] || !matchesResultNode(item, test) {
+				continue
+			}
+
+			updated[i] = true
+			if test.Status != "" {
+				setMappingValue(item, "status", string(test.Status))
+			}
+			break
+		}
+	}
+
+	for i, test := range testResults {
+		if updated[i] {
+			continue
+		}
+
+		item := &yamlv3.Node{Kind: yamlv3.MappingNode, Tag: "!!map"}
+		setMappingValue(item, "policy", test.Policy)
+		setMappingValue(item, "rule", test.Rule)
+		setMappingValue(item, "resource", test.Resource)
+		if test.Status != "" {
+			setMappingValue(item, "status", string(test.Status))
+		}
+		results.Content = append(results.Content, item)
+	}
+
+	var buf bytes.Buffer
+	encoder := yamlv3.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, buf.Bytes(), 0644)
+}
+
+func matchesResultNode(node *yamlv3.Node, test TestResults) bool {
+	for key, value := range map[string]string{"policy": test.Policy, "rule": test.Rule, "resource": test.Resource} {
+		v := mappingValue(node, key)
+		if v == nil || v.Value != value {
+			return false
+		}
+	}
+	return true
+}
+
+func mappingValue(node *yamlv3.Node, key string) *yamlv3.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func setMappingValue(node *yamlv3.Node, key, value string) {
+	if v := mappingValue(node, key); v != nil {
+		v.Kind, v.Tag, v.Value, v.Style = yamlv3.ScalarNode, "!!str", value, 0
+		return
+	}
+	node.Content = append(node.Content, scalarNode(key), scalarNode(value))
+}
+
+func scalarNode(value string) *yamlv3.Node {
+	return &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// updateTest updates the test results to the actual results and rewrites the test file and the resource files,
+// the updated files are reported to out
+func updateTest(out io.Writer, testFile string, testResults []TestResults, results map[string]report.PolicyReportResult,
+	patched, generated map[string]unstructured.Unstructured, policyResourcePath string, load func(path string) (*unstructured.Unstructured, error)) error {
+	notFound := updateTestResults(testResults, results)
+	written, err := writeResourceFiles(testResults, patched, generated, policyResourcePath, load)
+	if err != nil {
+		return err
+	}
+
+	if err := updateTestFile(testFile, testResults); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nupdated %s\n", testFile)
+	for _, path := range written {
+		fmt.Fprintf(out, "updated %s\n", filepath.Join(policyResourcePath, path))
+	}
+	for _, test := range notFound {
+		fmt.Fprintf(out, "no result found for %s with %s/%s, it is left unchanged\n", test.Resource, test.Policy, test.Rule)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	report "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	yamlv3 "gopkg.in/yaml.v3"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_updateTestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.yaml")
+	assert.NilError(t, ioutil.WriteFile(path, []byte(`# tests of the pod policies
+name: pod-tests
+policies:
+- policy.yaml
+results:
+# the latest tag is not allowed
+- policy: disallow-latest-tag
+  rule: validate-image-tag
+  resource: nginx
+  status: "pass"
+- policy: require-labels
+  rule: check-team
+  resource: nginx
+  status: fail # a team label is required
+`), 0644))
+
+	testResults := []TestResults{
+		{Policy: "disallow-latest-tag", Rule: "validate-image-tag", Resource: "nginx", Status: report.StatusFail},
+		{Policy: "require-labels", Rule: "check-team", Resource: "nginx", Status: report.StatusPass},
+		{Policy: "require-labels", Rule: "check-team", Resource: "redis", Status: report.StatusSkip},
+	}
+	assert.NilError(t, updateTestFile(path, testResults))
+
+	data, err := ioutil.ReadFile(path)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), `# tests of the pod policies
+name: pod-tests
+policies:
+  - policy.yaml
+results:
+  # the latest tag is not allowed
+  - policy: disallow-latest-tag
+    rule: validate-image-tag
+    resource: nginx
+    status: fail
+  - policy: require-labels
+    rule: check-team
+    resource: nginx
+    status: pass # a team label is required
+  - policy: require-labels
+    rule: check-team
+    resource: redis
+    status: skip
+`)
+}
+
+func Test_setMappingValue(t *testing.T) {
+	var doc yamlv3.Node
+	assert.NilError(t, yamlv3.Unmarshal([]byte(`policy: require-labels
+status: "fail" # expected
+`), &doc))
+	node := doc.Content[0]
+
+	setMappingValue(node, "status", "pass")
+	setMappingValue(node, "rule", "check-team")
+	assert.Equal(t, len(node.Content), 6)
+
+	status := mappingValue(node, "status")
+	assert.Equal(t, status.Value, "pass")
+	assert.Equal(t, status.Style, yamlv3.Style(0))
+	assert.Equal(t, status.LineComment, "# expected")
+	assert.Equal(t, node.Content[4].Value, "rule")
+	assert.Equal(t, mappingValue(node, "rule").Value, "check-team")
+}
+
+func Test_writeResourceFiles(t *testing.T) {
+	dir := t.TempDir()
+	unchanged := `apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx
+  labels:
+    team: a
+`
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "unchanged.yaml"), []byte(unchanged), 0644))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "changed.yaml"), []byte(unchanged), 0644))
+
+	load := func(path string) (*unstructured.Unstructured, error) {
+		return loadExpectedResource(nil, path, false, dir)
+	}
+
+	pod := *loadResource(t, unchanged)
+	changedPod := *pod.DeepCopy()
+	changedPod.SetLabels(map[string]string{"team": "b"})
+	patched := map[string]unstructured.Unstructured{
+		testResultKey("add-labels", "add-team", "nginx"): pod,
+		testResultKey("add-labels", "add-team", "redis"): changedPod,
+		testResultKey("add-labels", "add-team", "mysql"): pod,
+	}
+
+	testResults := []TestResults{
+		{Policy: "add-labels", Rule: "add-team", Resource: "nginx", PatchedResource: "unchanged.yaml"},
+		{Policy: "add-labels", Rule: "add-team", Resource: "redis", PatchedResource: "changed.yaml"},
+		{Policy: "add-labels", Rule: "add-team", Resource: "mysql", PatchedResource: "missing.yaml"},
+		{Policy: "add-labels", Rule: "add-team", Resource: "busybox", PatchedResource: "no-result.yaml"},
+	}
+
+	written, err := writeResourceFiles(testResults, patched, nil, dir, load)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, written, []string{"changed.yaml", "missing.yaml"})
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "unchanged.yaml"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), unchanged)
+
+	changed, err := load("changed.yaml")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, changed.GetLabels(), map[string]string{"team": "b"})
+
+	_, err = os.Stat(filepath.Join(dir, "no-result.yaml"))
+	assert.Assert(t, os.IsNotExist(err))
+}
+
+func Test_addMissingResults(t *testing.T) {
+	resp := &response.EngineResponse{}
+	resp.PolicyResponse.Policy.Name = "require-labels"
+	resp.PolicyResponse.Resource.Name = "nginx"
+	resp.PolicyResponse.Rules = []response.RuleResponse{{Name: "check-team"}, {Name: "autogen-check-owner"}}
+
+	testResults := addMissingResults([]TestResults{{Policy: "require-labels", Rule: "check-team", Resource: "nginx"}}, []*response.EngineResponse{resp, resp})
+	assert.DeepEqual(t, testResults, []TestResults{
+		{Policy: "require-labels", Rule: "check-team", Resource: "nginx"},
+		{Policy: "require-labels", Rule: "check-owner", Resource: "nginx"},
+	})
+}
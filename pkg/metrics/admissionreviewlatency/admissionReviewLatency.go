@@ -28,7 +28,7 @@ func (pm PromMetrics) registerAdmissionReviewLatencyMetric(
 		"resource_namespace":          resourceNamespace,
 		"resource_request_operation":  string(resourceRequestOperation),
 		"admission_request_timestamp": fmt.Sprintf("%+v", time.Unix(admissionRequestTimestamp, 0)),
-	}).Set(admissionRequestLatency)
+	}).Observe(admissionRequestLatency)
 	return nil
 }
 
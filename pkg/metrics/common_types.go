@@ -55,3 +55,10 @@ const (
 	ResourceDeleted   ResourceRequestOperation = "delete"
 	ResourceConnected ResourceRequestOperation = "connect"
 )
+
+type Outcome string
+
+const (
+	Success Outcome = "success"
+	Failure Outcome = "failure"
+)
@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"github.com/minio/pkg/wildcard"
+)
+
+// MetricsConfig controls the cardinality of the metrics, it is read from the Kyverno ConfigMap at startup
+type MetricsConfig struct {
+	// Namespaces filters the samples by the namespace of the resource, or of the policy for the metrics without resource labels
+	Namespaces NamespacesConfig `json:"namespaces,omitempty"`
+
+	// DropLabels lists per metric name the labels which are not recorded
+	DropLabels map[string][]string `json:"dropLabels,omitempty"`
+
+	// Buckets overrides per histogram name the bucket boundaries
+	Buckets map[string][]float64 `json:"buckets,omitempty"`
+
+	// AggregatePerPolicy drops the resource, message and timestamp labels of all the metrics
+	AggregatePerPolicy bool `json:"aggregatePerPolicy,omitempty"`
+}
+
+// NamespacesConfig selects the namespaces recorded in the metrics, wildcards are supported;
+// when Include is empty all the namespaces which are not excluded are recorded
+type NamespacesConfig struct {
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// perResourceLabels are dropped when the metrics are aggregated per policy
+var perResourceLabels = map[string]bool{
+	"resource_name":                  true,
+	"resource_kind":                  true,
+	"resource_namespace":             true,
+	"resource_request_operation":     true,
+	"rule_response":                  true,
+	"rule_execution_cause":           true,
+	"main_request_trigger_timestamp": true,
+	"policy_execution_timestamp":     true,
+	"rule_execution_timestamp":       true,
+	"admission_request_timestamp":    true,
+	"timestamp":                      true,
+}
+
+// labelNames returns the labels recorded for the metric
+func (c MetricsConfig) labelNames(metric string, labels []string) []string {
+	dropped := make(map[string]bool, len(c.DropLabels[metric]))
+	for _, label := range c.DropLabels[metric] {
+		dropped[label] = true
+	}
+
+	var names []string
+	for _, label := range labels {
+		if dropped[label] || (c.AggregatePerPolicy && perResourceLabels[label]) {
+			continue
+		}
+
+		names = append(names, label)
+	}
+
+	return names
+}
+
+// buckets returns the bucket boundaries of the histogram
+func (c MetricsConfig) buckets(metric string, defaultBuckets []float64) []float64 {
+	if buckets, ok := c.Buckets[metric]; ok && len(buckets) > 0 {
+		return buckets
+	}
+
+	return defaultBuckets
+}
+
+// namespaceLabel returns the label the namespace filter applies to, or an empty string
+func namespaceLabel(labels []string) string {
+	label := ""
+	for _, name := range labels {
+		if name == "resource_namespace" {
+			return name
+		}
+
+		if name == "policy_namespace" {
+			label = name
+		}
+	}
+
+	return label
+}
+
+// recordNamespace returns true if the samples of the namespace are recorded,
+// cluster-wide samples are always recorded
+func (c NamespacesConfig) recordNamespace(namespace string) bool {
+	if namespace == "" || namespace == "-" {
+		return true
+	}
+
+	for _, pattern := range c.Exclude {
+		if wildcard.Match(pattern, namespace) {
+			return false
+		}
+	}
+
+	if len(c.Include) == 0 {
+		return true
+	}
+
+	for _, pattern := range c.Include {
+		if wildcard.Match(pattern, namespace) {
+			return true
+		}
+	}
+
+	return false
+}
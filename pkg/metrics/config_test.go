@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"testing"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"gotest.tools/assert"
+)
+
+func Test_NamespacesConfig_recordNamespace(t *testing.T) {
+	config := NamespacesConfig{Include: []string{"team-*", "default"}, Exclude: []string{"team-sandbox"}}
+
+	assert.Assert(t, config.recordNamespace("team-a"))
+	assert.Assert(t, config.recordNamespace("default"))
+	assert.Assert(t, config.recordNamespace("-"))
+	assert.Assert(t, !config.recordNamespace("team-sandbox"))
+	assert.Assert(t, !config.recordNamespace("kube-system"))
+	assert.Assert(t, NamespacesConfig{}.recordNamespace("kube-system"))
+}
+
+func Test_MetricsConfig_labelNames(t *testing.T) {
+	labels := []string{"policy_name", "resource_name", "resource_namespace", "rule_name", "rule_response"}
+
+	config := MetricsConfig{DropLabels: map[string][]string{"metric": {"rule_response"}}}
+	assert.DeepEqual(t, config.labelNames("metric", labels), []string{"policy_name", "resource_name", "resource_namespace", "rule_name"})
+	assert.DeepEqual(t, config.labelNames("other", labels), labels)
+
+	config = MetricsConfig{AggregatePerPolicy: true}
+	assert.DeepEqual(t, config.labelNames("metric", labels), []string{"policy_name", "rule_name"})
+}
+
+func Test_GaugeVec_With(t *testing.T) {
+	config := MetricsConfig{
+		Namespaces:         NamespacesConfig{Exclude: []string{"kube-system"}},
+		AggregatePerPolicy: true,
+	}
+	vec := newGaugeVec(config, prom.GaugeOpts{Name: "test_metric"}, []string{"policy_name", "resource_namespace"})
+
+	vec.With(prom.Labels{"policy_name": "pol", "resource_namespace": "default"}).Set(1)
+	vec.With(prom.Labels{"policy_name": "pol", "resource_namespace": "test"}).Set(1)
+	vec.With(prom.Labels{"policy_name": "pol", "resource_namespace": "kube-system"}).Set(1)
+
+	registry := prom.NewRegistry()
+	registry.MustRegister(vec)
+	families, err := registry.Gather()
+	assert.NilError(t, err)
+	assert.Equal(t, len(families), 1)
+	assert.Equal(t, len(families[0].GetMetric()), 1)
+	assert.Equal(t, len(families[0].GetMetric()[0].GetLabel()), 1)
+}
+
+func Test_HistogramVec_buckets(t *testing.T) {
+	config := MetricsConfig{Buckets: map[string][]float64{"test_histogram": {1, 2}}}
+	vec := newHistogramVec(config, prom.HistogramOpts{Name: "test_histogram", Buckets: prom.DefBuckets}, []string{"outcome"})
+	vec.With(prom.Labels{"outcome": "success"}).Observe(1.5)
+
+	registry := prom.NewRegistry()
+	registry.MustRegister(vec)
+	families, err := registry.Gather()
+	assert.NilError(t, err)
+	assert.Equal(t, len(families[0].GetMetric()[0].GetHistogram().GetBucket()), 2)
+}
+
+func Test_NewPromConfig_latencyHistograms(t *testing.T) {
+	config := MetricsConfig{
+		Buckets:            map[string][]float64{"kyverno_policy_rule_execution_latency_milliseconds": {10, 100}},
+		AggregatePerPolicy: true,
+	}
+	pc := NewPromConfig(config)
+
+	// the latencies of a rule are aggregated instead of holding the last observed latency
+	labels := prom.Labels{"policy_name": "pol", "rule_name": "rule", "resource_name": "pod1", "rule_execution_timestamp": "1"}
+	pc.Metrics.PolicyRuleExecutionLatency.With(labels).Observe(5)
+	labels["resource_name"], labels["rule_execution_timestamp"] = "pod2", "2"
+	pc.Metrics.PolicyRuleExecutionLatency.With(labels).Observe(50)
+
+	families, err := pc.MetricsRegistry.Gather()
+	assert.NilError(t, err)
+	for _, family := range families {
+		if family.GetName() != "kyverno_policy_rule_execution_latency_milliseconds" {
+			continue
+		}
+
+		assert.Equal(t, len(family.GetMetric()), 1)
+		histogram := family.GetMetric()[0].GetHistogram()
+		assert.Equal(t, histogram.GetSampleCount(), uint64(2))
+		assert.Equal(t, histogram.GetSampleSum(), float64(55))
+		assert.Equal(t, len(histogram.GetBucket()), 2)
+		return
+	}
+
+	t.Fatal("kyverno_policy_rule_execution_latency_milliseconds not found")
+}
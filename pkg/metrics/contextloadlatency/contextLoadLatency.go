@@ -0,0 +1,17 @@
+package contextloadlatency
+
+import (
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/metrics"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// RegisterLoad records the time taken to load a context entry
+func (pm PromMetrics) RegisterLoad(entryType EntryType, outcome metrics.Outcome, duration time.Duration) error {
+	pm.ContextEntryLoadLatency.With(prom.Labels{
+		"entry_type": string(entryType),
+		"outcome":    string(outcome),
+	}).Observe(duration.Seconds())
+	return nil
+}
@@ -0,0 +1,14 @@
+package contextloadlatency
+
+import (
+	"github.com/kyverno/kyverno/pkg/metrics"
+)
+
+type EntryType string
+
+const (
+	ConfigMap EntryType = "configMap"
+	APICall   EntryType = "apiCall"
+)
+
+type PromMetrics metrics.PromMetrics
@@ -0,0 +1,14 @@
+package eventsdropped
+
+import (
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// RegisterDropped counts the events dropped by the rate limiter of an event source
+func (pm PromMetrics) RegisterDropped(source, reason string) error {
+	pm.EventsDropped.With(prom.Labels{
+		"event_source": source,
+		"event_reason": reason,
+	}).Inc()
+	return nil
+}
@@ -0,0 +1,7 @@
+package eventsdropped
+
+import (
+	"github.com/kyverno/kyverno/pkg/metrics"
+)
+
+type PromMetrics metrics.PromMetrics
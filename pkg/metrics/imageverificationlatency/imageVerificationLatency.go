@@ -0,0 +1,15 @@
+package imageverificationlatency
+
+import (
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// RegisterVerification records the time taken to verify the signature of an image
+func (pm PromMetrics) RegisterVerification(result VerificationResult, duration time.Duration) error {
+	pm.ImageVerificationLatency.With(prom.Labels{
+		"result": string(result),
+	}).Observe(duration.Seconds())
+	return nil
+}
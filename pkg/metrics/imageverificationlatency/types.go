@@ -0,0 +1,14 @@
+package imageverificationlatency
+
+import (
+	"github.com/kyverno/kyverno/pkg/metrics"
+)
+
+type VerificationResult string
+
+const (
+	Verified VerificationResult = "verified"
+	Failed   VerificationResult = "failed"
+)
+
+type PromMetrics metrics.PromMetrics
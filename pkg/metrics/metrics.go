@@ -10,14 +10,29 @@ type PromConfig struct {
 }
 
 type PromMetrics struct {
-	PolicyRuleResults          *prom.GaugeVec
-	PolicyRuleInfo             *prom.GaugeVec
-	PolicyChanges              *prom.GaugeVec
-	PolicyRuleExecutionLatency *prom.GaugeVec
-	AdmissionReviewLatency     *prom.GaugeVec
+	PolicyRuleResults          *GaugeVec
+	PolicyRuleInfo             *GaugeVec
+	PolicyChanges              *GaugeVec
+	PolicyRuleExecutionLatency *HistogramVec
+	AdmissionReviewLatency     *HistogramVec
+	ResultSinkRecordsDropped   *CounterVec
+	EventsDropped              *CounterVec
+	ContextEntryLoadLatency    *HistogramVec
+	RoleRefLatency             *HistogramVec
+	ImageVerificationLatency   *HistogramVec
+	PolicyCacheLookupSize      *HistogramVec
+	WorkqueueDepth             *GaugeVec
+	WorkqueueAdds              *CounterVec
+	WorkqueueLatency           *HistogramVec
+	WorkqueueWorkDuration      *HistogramVec
+	WorkqueueUnfinishedWork    *GaugeVec
+	WorkqueueLongestRunning    *GaugeVec
+	WorkqueueRetries           *CounterVec
 }
 
-func NewPromConfig() *PromConfig {
+// NewPromConfig creates the metrics, the configuration controls the labels and
+// the bucket boundaries of the series and the namespaces they are recorded for
+func NewPromConfig(config MetricsConfig) *PromConfig {
 	pc := new(PromConfig)
 
 	pc.MetricsRegistry = prom.NewRegistry()
@@ -28,7 +43,8 @@ func NewPromConfig() *PromConfig {
 		"rule_name", "rule_result", "rule_type", "rule_execution_cause", "rule_response",
 		"main_request_trigger_timestamp", "policy_execution_timestamp", "rule_execution_timestamp",
 	}
-	policyRuleResultsMetric := prom.NewGaugeVec(
+	policyRuleResultsMetric := newGaugeVec(
+		config,
 		prom.GaugeOpts{
 			Name: "kyverno_policy_rule_results_info",
 			Help: "can be used to track the results associated with the policies applied in the user’s cluster, at the level from rule to policy to admission requests.",
@@ -39,7 +55,8 @@ func NewPromConfig() *PromConfig {
 	policyRuleInfoLabels := []string{
 		"policy_validation_mode", "policy_type", "policy_background_mode", "policy_namespace", "policy_name", "rule_name", "rule_type",
 	}
-	policyRuleInfoMetric := prom.NewGaugeVec(
+	policyRuleInfoMetric := newGaugeVec(
+		config,
 		prom.GaugeOpts{
 			Name: "kyverno_policy_rule_info_total",
 			Help: "can be used to track the info of the rules or/and policies present in the cluster. 0 means the rule doesn't exist and has been deleted, 1 means the rule is currently existent in the cluster.",
@@ -50,7 +67,8 @@ func NewPromConfig() *PromConfig {
 	policyChangesLabels := []string{
 		"policy_validation_mode", "policy_type", "policy_background_mode", "policy_namespace", "policy_name", "policy_change_type", "timestamp",
 	}
-	policyChangesMetric := prom.NewGaugeVec(
+	policyChangesMetric := newGaugeVec(
+		config,
 		prom.GaugeOpts{
 			Name: "kyverno_policy_changes_info",
 			Help: "can be used to track all the Kyverno policies which have been created, updated or deleted.",
@@ -64,10 +82,12 @@ func NewPromConfig() *PromConfig {
 		"rule_name", "rule_result", "rule_type", "rule_execution_cause", "rule_response", "generate_rule_latency_type",
 		"main_request_trigger_timestamp", "policy_execution_timestamp", "rule_execution_timestamp",
 	}
-	policyRuleExecutionLatencyMetric := prom.NewGaugeVec(
-		prom.GaugeOpts{
-			Name: "kyverno_policy_rule_execution_latency_milliseconds",
-			Help: "can be used to track the latencies (in milliseconds) associated with the execution/processing of the individual rules under Kyverno policies whenever they evaluate incoming resource requests.",
+	policyRuleExecutionLatencyMetric := newHistogramVec(
+		config,
+		prom.HistogramOpts{
+			Name:    "kyverno_policy_rule_execution_latency_milliseconds",
+			Help:    "can be used to track the latencies (in milliseconds) associated with the execution/processing of the individual rules under Kyverno policies whenever they evaluate incoming resource requests.",
+			Buckets: []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000},
 		},
 		policyRuleExecutionLatencyLabels,
 	)
@@ -78,20 +98,159 @@ func NewPromConfig() *PromConfig {
 		"resource_name", "resource_kind", "resource_namespace", "resource_request_operation",
 		"admission_request_timestamp",
 	}
-	admissionReviewLatencyMetric := prom.NewGaugeVec(
-		prom.GaugeOpts{
-			Name: "kyverno_admission_review_latency_milliseconds",
-			Help: "can be used to track the latencies associated with the entire individual admission review. For example, if an incoming request trigger, say, five policies, this metric will track the e2e latency associated with the execution of all those policies.",
+	admissionReviewLatencyMetric := newHistogramVec(
+		config,
+		prom.HistogramOpts{
+			Name:    "kyverno_admission_review_latency_milliseconds",
+			Help:    "can be used to track the latencies associated with the entire individual admission review. For example, if an incoming request trigger, say, five policies, this metric will track the e2e latency associated with the execution of all those policies.",
+			Buckets: []float64{10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000},
 		},
 		admissionReviewLatency,
 	)
 
+	resultSinkRecordsDroppedLabels := []string{
+		"sink_name", "sink_type", "drop_reason",
+	}
+	resultSinkRecordsDroppedMetric := newCounterVec(
+		config,
+		prom.CounterOpts{
+			Name: "kyverno_result_sink_records_dropped_total",
+			Help: "can be used to track the policy results that could not be published to the configured result sinks, either because the sink buffer was full or because the delivery failed.",
+		},
+		resultSinkRecordsDroppedLabels,
+	)
+
+	eventsDroppedLabels := []string{
+		"event_source", "event_reason",
+	}
+	eventsDroppedMetric := newCounterVec(
+		config,
+		prom.CounterOpts{
+			Name: "kyverno_events_dropped_total",
+			Help: "can be used to track the events that were not emitted because the event rate limit of their source was exceeded.",
+		},
+		eventsDroppedLabels,
+	)
+
+	contextEntryLoadLatencyMetric := newHistogramVec(
+		config,
+		prom.HistogramOpts{
+			Name:    "kyverno_context_entry_load_duration_seconds",
+			Help:    "can be used to track the time taken to load the context entries of the rules, by entry type (configMap or apiCall) and outcome.",
+			Buckets: prom.DefBuckets,
+		},
+		[]string{"entry_type", "outcome"},
+	)
+
+	roleRefLatencyMetric := newHistogramVec(
+		config,
+		prom.HistogramOpts{
+			Name:    "kyverno_role_ref_duration_seconds",
+			Help:    "can be used to track the time taken to resolve the roles and cluster roles bound to the user of an admission request.",
+			Buckets: prom.DefBuckets,
+		},
+		[]string{"outcome"},
+	)
+
+	imageVerificationLatencyMetric := newHistogramVec(
+		config,
+		prom.HistogramOpts{
+			Name:    "kyverno_image_verification_duration_seconds",
+			Help:    "can be used to track the time taken to verify the signature of a container image, by verification result.",
+			Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{"result"},
+	)
+
+	policyCacheLookupSizeMetric := newHistogramVec(
+		config,
+		prom.HistogramOpts{
+			Name:    "kyverno_policy_cache_lookup_policies",
+			Help:    "can be used to track the number of policies returned by the policy cache for a resource, by policy type.",
+			Buckets: []float64{0, 1, 2, 5, 10, 20, 50, 100},
+		},
+		[]string{"policy_type"},
+	)
+
+	workqueueLabels := []string{"name"}
+	workqueueDepthMetric := newGaugeVec(
+		config,
+		prom.GaugeOpts{
+			Name: "kyverno_workqueue_depth",
+			Help: "can be used to track the number of items waiting in the workqueues of the Kyverno controllers.",
+		},
+		workqueueLabels,
+	)
+	workqueueAddsMetric := newCounterVec(
+		config,
+		prom.CounterOpts{
+			Name: "kyverno_workqueue_adds_total",
+			Help: "can be used to track the number of items added to the workqueues of the Kyverno controllers.",
+		},
+		workqueueLabels,
+	)
+	workqueueLatencyMetric := newHistogramVec(
+		config,
+		prom.HistogramOpts{
+			Name:    "kyverno_workqueue_queue_duration_seconds",
+			Help:    "can be used to track how long the items stay in the workqueues of the Kyverno controllers before being processed.",
+			Buckets: prom.ExponentialBuckets(0.001, 4, 10),
+		},
+		workqueueLabels,
+	)
+	workqueueWorkDurationMetric := newHistogramVec(
+		config,
+		prom.HistogramOpts{
+			Name:    "kyverno_workqueue_work_duration_seconds",
+			Help:    "can be used to track how long the Kyverno controllers take to process an item of their workqueues.",
+			Buckets: prom.ExponentialBuckets(0.001, 4, 10),
+		},
+		workqueueLabels,
+	)
+	workqueueUnfinishedWorkMetric := newGaugeVec(
+		config,
+		prom.GaugeOpts{
+			Name: "kyverno_workqueue_unfinished_work_seconds",
+			Help: "can be used to detect stuck workers, it is the time the items being processed have been in progress.",
+		},
+		workqueueLabels,
+	)
+	workqueueLongestRunningMetric := newGaugeVec(
+		config,
+		prom.GaugeOpts{
+			Name: "kyverno_workqueue_longest_running_processor_seconds",
+			Help: "can be used to detect stuck workers, it is the time the longest running item of a workqueue has been in progress.",
+		},
+		workqueueLabels,
+	)
+	workqueueRetriesMetric := newCounterVec(
+		config,
+		prom.CounterOpts{
+			Name: "kyverno_workqueue_retries_total",
+			Help: "can be used to track the number of items requeued after a failure in the workqueues of the Kyverno controllers.",
+		},
+		workqueueLabels,
+	)
+
 	pc.Metrics = &PromMetrics{
 		PolicyRuleResults:          policyRuleResultsMetric,
 		PolicyRuleInfo:             policyRuleInfoMetric,
 		PolicyChanges:              policyChangesMetric,
 		PolicyRuleExecutionLatency: policyRuleExecutionLatencyMetric,
 		AdmissionReviewLatency:     admissionReviewLatencyMetric,
+		ResultSinkRecordsDropped:   resultSinkRecordsDroppedMetric,
+		EventsDropped:              eventsDroppedMetric,
+		ContextEntryLoadLatency:    contextEntryLoadLatencyMetric,
+		RoleRefLatency:             roleRefLatencyMetric,
+		ImageVerificationLatency:   imageVerificationLatencyMetric,
+		PolicyCacheLookupSize:      policyCacheLookupSizeMetric,
+		WorkqueueDepth:             workqueueDepthMetric,
+		WorkqueueAdds:              workqueueAddsMetric,
+		WorkqueueLatency:           workqueueLatencyMetric,
+		WorkqueueWorkDuration:      workqueueWorkDurationMetric,
+		WorkqueueUnfinishedWork:    workqueueUnfinishedWorkMetric,
+		WorkqueueLongestRunning:    workqueueLongestRunningMetric,
+		WorkqueueRetries:           workqueueRetriesMetric,
 	}
 
 	pc.MetricsRegistry.MustRegister(pc.Metrics.PolicyRuleResults)
@@ -99,6 +258,19 @@ func NewPromConfig() *PromConfig {
 	pc.MetricsRegistry.MustRegister(pc.Metrics.PolicyChanges)
 	pc.MetricsRegistry.MustRegister(pc.Metrics.PolicyRuleExecutionLatency)
 	pc.MetricsRegistry.MustRegister(pc.Metrics.AdmissionReviewLatency)
+	pc.MetricsRegistry.MustRegister(pc.Metrics.ResultSinkRecordsDropped)
+	pc.MetricsRegistry.MustRegister(pc.Metrics.EventsDropped)
+	pc.MetricsRegistry.MustRegister(pc.Metrics.ContextEntryLoadLatency)
+	pc.MetricsRegistry.MustRegister(pc.Metrics.RoleRefLatency)
+	pc.MetricsRegistry.MustRegister(pc.Metrics.ImageVerificationLatency)
+	pc.MetricsRegistry.MustRegister(pc.Metrics.PolicyCacheLookupSize)
+	pc.MetricsRegistry.MustRegister(pc.Metrics.WorkqueueDepth)
+	pc.MetricsRegistry.MustRegister(pc.Metrics.WorkqueueAdds)
+	pc.MetricsRegistry.MustRegister(pc.Metrics.WorkqueueLatency)
+	pc.MetricsRegistry.MustRegister(pc.Metrics.WorkqueueWorkDuration)
+	pc.MetricsRegistry.MustRegister(pc.Metrics.WorkqueueUnfinishedWork)
+	pc.MetricsRegistry.MustRegister(pc.Metrics.WorkqueueLongestRunning)
+	pc.MetricsRegistry.MustRegister(pc.Metrics.WorkqueueRetries)
 
 	return pc
 }
@@ -38,3 +38,11 @@ func ParseRuleType(rule kyverno.Rule) RuleType {
 	}
 	return EmptyRuleType
 }
+
+func ParseOutcome(err error) Outcome {
+	if err != nil {
+		return Failure
+	}
+
+	return Success
+}
@@ -0,0 +1,13 @@
+package policycachelookup
+
+import (
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// RegisterLookup records the number of policies returned by a policy cache lookup
+func (pm PromMetrics) RegisterLookup(policyType string, policies int) error {
+	pm.PolicyCacheLookupSize.With(prom.Labels{
+		"policy_type": policyType,
+	}).Observe(float64(policies))
+	return nil
+}
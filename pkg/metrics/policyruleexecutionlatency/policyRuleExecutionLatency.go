@@ -51,7 +51,7 @@ func (pm PromMetrics) registerPolicyRuleResultsMetric(
 		"policy_execution_timestamp":     fmt.Sprintf("%+v", time.Unix(policyExecutionTimestamp, 0)),
 		"rule_execution_timestamp":       fmt.Sprintf("%+v", time.Unix(ruleExecutionTimestamp, 0)),
 		"generate_rule_latency_type":     generateRuleLatencyType,
-	}).Set(ruleExecutionLatencyInMs)
+	}).Observe(ruleExecutionLatencyInMs)
 	return nil
 }
 
@@ -86,16 +86,21 @@ func (pm PromMetrics) ProcessEngineResponse(policy kyverno.ClusterPolicy, engine
 		ruleName := rule.Name
 		ruleType := ParseRuleTypeFromEngineRuleResponse(rule)
 		ruleResponse := rule.Message
-		ruleResult := metrics.Fail
-		if rule.Success {
-			ruleResult = metrics.Pass
-		}
+		ruleResult := metrics.RuleResult(rule.GetStatus())
 
 		ruleExecutionTimestamp := rule.RuleStats.RuleExecutionTimestamp
+
+		// validate rules can override the validation failure action of the policy
+		ruleValidationMode := policyValidationMode
+		if rule.ValidationFailureAction != "" {
+			if ruleValidationMode, err = metrics.ParsePolicyValidationMode(rule.ValidationFailureAction); err != nil {
+				return err
+			}
+		}
 		ruleExecutionLatencyInMs := float64(rule.RuleStats.ProcessingTime) / float64(1000*1000)
 
 		if err := pm.registerPolicyRuleResultsMetric(
-			policyValidationMode,
+			ruleValidationMode,
 			policyType,
 			policyBackgroundMode,
 			policyNamespace, policyName,
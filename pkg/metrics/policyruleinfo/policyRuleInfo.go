@@ -43,6 +43,14 @@ func (pm PromMetrics) registerPolicyRuleInfoMetric(
 	return nil
 }
 
+// ruleValidationMode returns the validation mode of the rule, the mode of the policy unless the rule sets its own validationFailureAction
+func ruleValidationMode(rule kyverno.Rule, policyMode metrics.PolicyValidationMode) (metrics.PolicyValidationMode, error) {
+	if rule.Validation.ValidationFailureAction == "" {
+		return policyMode, nil
+	}
+	return metrics.ParsePolicyValidationMode(rule.Validation.ValidationFailureAction)
+}
+
 func (pm PromMetrics) AddPolicy(policy interface{}) error {
 	switch inputPolicy := policy.(type) {
 	case *kyverno.ClusterPolicy:
@@ -58,8 +66,12 @@ func (pm PromMetrics) AddPolicy(policy interface{}) error {
 		for _, rule := range inputPolicy.Spec.Rules {
 			ruleName := rule.Name
 			ruleType := metrics.ParseRuleType(rule)
+			validationMode, err := ruleValidationMode(rule, policyValidationMode)
+			if err != nil {
+				return err
+			}
 
-			if err = pm.registerPolicyRuleInfoMetric(policyValidationMode, policyType, policyBackgroundMode, policyNamespace, policyName, ruleName, ruleType, PolicyRuleCreated); err != nil {
+			if err = pm.registerPolicyRuleInfoMetric(validationMode, policyType, policyBackgroundMode, policyNamespace, policyName, ruleName, ruleType, PolicyRuleCreated); err != nil {
 				return err
 			}
 		}
@@ -77,8 +89,12 @@ func (pm PromMetrics) AddPolicy(policy interface{}) error {
 		for _, rule := range inputPolicy.Spec.Rules {
 			ruleName := rule.Name
 			ruleType := metrics.ParseRuleType(rule)
+			validationMode, err := ruleValidationMode(rule, policyValidationMode)
+			if err != nil {
+				return err
+			}
 
-			if err = pm.registerPolicyRuleInfoMetric(policyValidationMode, policyType, policyBackgroundMode, policyNamespace, policyName, ruleName, ruleType, PolicyRuleCreated); err != nil {
+			if err = pm.registerPolicyRuleInfoMetric(validationMode, policyType, policyBackgroundMode, policyNamespace, policyName, ruleName, ruleType, PolicyRuleCreated); err != nil {
 				return err
 			}
 		}
@@ -102,8 +118,12 @@ func (pm PromMetrics) RemovePolicy(policy interface{}) error {
 			policyName := inputPolicy.ObjectMeta.Name
 			ruleName := rule.Name
 			ruleType := metrics.ParseRuleType(rule)
+			validationMode, err := ruleValidationMode(rule, policyValidationMode)
+			if err != nil {
+				return err
+			}
 
-			if err = pm.registerPolicyRuleInfoMetric(policyValidationMode, policyType, policyBackgroundMode, policyNamespace, policyName, ruleName, ruleType, PolicyRuleDeleted); err != nil {
+			if err = pm.registerPolicyRuleInfoMetric(validationMode, policyType, policyBackgroundMode, policyNamespace, policyName, ruleName, ruleType, PolicyRuleDeleted); err != nil {
 				return err
 			}
 		}
@@ -120,8 +140,12 @@ func (pm PromMetrics) RemovePolicy(policy interface{}) error {
 			policyName := inputPolicy.ObjectMeta.Name
 			ruleName := rule.Name
 			ruleType := metrics.ParseRuleType(rule)
+			validationMode, err := ruleValidationMode(rule, policyValidationMode)
+			if err != nil {
+				return err
+			}
 
-			if err = pm.registerPolicyRuleInfoMetric(policyValidationMode, policyType, policyBackgroundMode, policyNamespace, policyName, ruleName, ruleType, PolicyRuleDeleted); err != nil {
+			if err = pm.registerPolicyRuleInfoMetric(validationMode, policyType, policyBackgroundMode, policyNamespace, policyName, ruleName, ruleType, PolicyRuleDeleted); err != nil {
 				return err
 			}
 		}
@@ -80,15 +80,20 @@ func (pm PromMetrics) ProcessEngineResponse(policy kyverno.ClusterPolicy, engine
 		ruleName := rule.Name
 		ruleType := ParseRuleTypeFromEngineRuleResponse(rule)
 		ruleResponse := rule.Message
-		ruleResult := metrics.Fail
-		if rule.Success {
-			ruleResult = metrics.Pass
-		}
+		ruleResult := metrics.RuleResult(rule.GetStatus())
 
 		ruleExecutionTimestamp := rule.RuleStats.RuleExecutionTimestamp
 
+		// validate rules can override the validation failure action of the policy
+		ruleValidationMode := policyValidationMode
+		if rule.ValidationFailureAction != "" {
+			if ruleValidationMode, err = metrics.ParsePolicyValidationMode(rule.ValidationFailureAction); err != nil {
+				return err
+			}
+		}
+
 		if err := pm.registerPolicyRuleResultsMetric(
-			policyValidationMode,
+			ruleValidationMode,
 			policyType,
 			policyBackgroundMode,
 			policyNamespace, policyName,
@@ -0,0 +1,15 @@
+package resultsinkrecords
+
+import (
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// RegisterDropped counts the results dropped by a result sink
+func (pm PromMetrics) RegisterDropped(sinkName, sinkType string, reason DropReason, count int) error {
+	pm.ResultSinkRecordsDropped.With(prom.Labels{
+		"sink_name":   sinkName,
+		"sink_type":   sinkType,
+		"drop_reason": string(reason),
+	}).Add(float64(count))
+	return nil
+}
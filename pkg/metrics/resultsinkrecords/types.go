@@ -0,0 +1,14 @@
+package resultsinkrecords
+
+import (
+	"github.com/kyverno/kyverno/pkg/metrics"
+)
+
+type DropReason string
+
+const (
+	BufferFull     DropReason = "buffer_full"
+	DeliveryFailed DropReason = "delivery_failed"
+)
+
+type PromMetrics metrics.PromMetrics
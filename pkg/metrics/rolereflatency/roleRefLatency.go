@@ -0,0 +1,16 @@
+package rolereflatency
+
+import (
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/metrics"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// RegisterRoleRef records the time taken to resolve the roles of the user of an admission request
+func (pm PromMetrics) RegisterRoleRef(outcome metrics.Outcome, duration time.Duration) error {
+	pm.RoleRefLatency.With(prom.Labels{
+		"outcome": string(outcome),
+	}).Observe(duration.Seconds())
+	return nil
+}
@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// labelFilter applies the metrics configuration to the labels of a sample
+type labelFilter struct {
+	names          []string
+	namespaceLabel string
+	namespaces     NamespacesConfig
+}
+
+func newLabelFilter(config MetricsConfig, metric string, labels []string) labelFilter {
+	return labelFilter{
+		names:          config.labelNames(metric, labels),
+		namespaceLabel: namespaceLabel(labels),
+		namespaces:     config.Namespaces,
+	}
+}
+
+// filter returns the recorded labels of the sample, or false if the sample is not recorded
+func (f labelFilter) filter(labels prom.Labels) (prom.Labels, bool) {
+	if f.namespaceLabel != "" && !f.namespaces.recordNamespace(labels[f.namespaceLabel]) {
+		return nil, false
+	}
+
+	filtered := make(prom.Labels, len(f.names))
+	for _, name := range f.names {
+		filtered[name] = labels[name]
+	}
+
+	return filtered, true
+}
+
+// GaugeVec is a gauge vector recording the samples according to the metrics configuration
+type GaugeVec struct {
+	vec     *prom.GaugeVec
+	filter  labelFilter
+	discard prom.Gauge
+}
+
+func newGaugeVec(config MetricsConfig, opts prom.GaugeOpts, labels []string) *GaugeVec {
+	return &GaugeVec{
+		vec:     prom.NewGaugeVec(opts, config.labelNames(opts.Name, labels)),
+		filter:  newLabelFilter(config, opts.Name, labels),
+		discard: prom.NewGauge(opts),
+	}
+}
+
+// With returns the gauge of the labels, the gauge is not exported if the sample is filtered out
+func (v *GaugeVec) With(labels prom.Labels) prom.Gauge {
+	filtered, ok := v.filter.filter(labels)
+	if !ok {
+		return v.discard
+	}
+
+	return v.vec.With(filtered)
+}
+
+func (v *GaugeVec) Describe(ch chan<- *prom.Desc) { v.vec.Describe(ch) }
+
+func (v *GaugeVec) Collect(ch chan<- prom.Metric) { v.vec.Collect(ch) }
+
+// CounterVec is a counter vector recording the samples according to the metrics configuration
+type CounterVec struct {
+	vec     *prom.CounterVec
+	filter  labelFilter
+	discard prom.Counter
+}
+
+func newCounterVec(config MetricsConfig, opts prom.CounterOpts, labels []string) *CounterVec {
+	return &CounterVec{
+		vec:     prom.NewCounterVec(opts, config.labelNames(opts.Name, labels)),
+		filter:  newLabelFilter(config, opts.Name, labels),
+		discard: prom.NewCounter(opts),
+	}
+}
+
+// With returns the counter of the labels, the counter is not exported if the sample is filtered out
+func (v *CounterVec) With(labels prom.Labels) prom.Counter {
+	filtered, ok := v.filter.filter(labels)
+	if !ok {
+		return v.discard
+	}
+
+	return v.vec.With(filtered)
+}
+
+func (v *CounterVec) Describe(ch chan<- *prom.Desc) { v.vec.Describe(ch) }
+
+func (v *CounterVec) Collect(ch chan<- prom.Metric) { v.vec.Collect(ch) }
+
+// HistogramVec is a histogram vector recording the samples according to the metrics configuration
+type HistogramVec struct {
+	vec     *prom.HistogramVec
+	filter  labelFilter
+	discard prom.Observer
+}
+
+func newHistogramVec(config MetricsConfig, opts prom.HistogramOpts, labels []string) *HistogramVec {
+	opts.Buckets = config.buckets(opts.Name, opts.Buckets)
+	return &HistogramVec{
+		vec:     prom.NewHistogramVec(opts, config.labelNames(opts.Name, labels)),
+		filter:  newLabelFilter(config, opts.Name, labels),
+		discard: prom.NewHistogram(opts),
+	}
+}
+
+// With returns the histogram of the labels, the histogram is not exported if the sample is filtered out
+func (v *HistogramVec) With(labels prom.Labels) prom.Observer {
+	filtered, ok := v.filter.filter(labels)
+	if !ok {
+		return v.discard
+	}
+
+	return v.vec.With(filtered)
+}
+
+func (v *HistogramVec) Describe(ch chan<- *prom.Desc) { v.vec.Describe(ch) }
+
+func (v *HistogramVec) Collect(ch chan<- prom.Metric) { v.vec.Collect(ch) }
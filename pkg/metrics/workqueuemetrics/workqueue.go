@@ -0,0 +1,40 @@
+package workqueuemetrics
+
+import (
+	prom "github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// Register exposes the depth and the processing latency of the named workqueues
+// created afterwards, e.g. the audit handler, generate request and report queues
+func (pm PromMetrics) Register() {
+	workqueue.SetProvider(pm)
+}
+
+func (pm PromMetrics) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return pm.WorkqueueDepth.With(prom.Labels{"name": name})
+}
+
+func (pm PromMetrics) NewAddsMetric(name string) workqueue.CounterMetric {
+	return pm.WorkqueueAdds.With(prom.Labels{"name": name})
+}
+
+func (pm PromMetrics) NewLatencyMetric(name string) workqueue.HistogramMetric {
+	return pm.WorkqueueLatency.With(prom.Labels{"name": name})
+}
+
+func (pm PromMetrics) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
+	return pm.WorkqueueWorkDuration.With(prom.Labels{"name": name})
+}
+
+func (pm PromMetrics) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return pm.WorkqueueUnfinishedWork.With(prom.Labels{"name": name})
+}
+
+func (pm PromMetrics) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return pm.WorkqueueLongestRunning.With(prom.Labels{"name": name})
+}
+
+func (pm PromMetrics) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return pm.WorkqueueRetries.With(prom.Labels{"name": name})
+}
@@ -143,24 +143,30 @@ func (o *Controller) ValidatePolicyMutation(policy v1.ClusterPolicy) error {
 	var kindToRules = make(map[string][]v1.Rule)
 	for _, rule := range policy.Spec.Rules {
 		if rule.HasMutate() {
-			for _, kind := range rule.MatchResources.Kinds {
+			for _, kind := range rule.MatchResources.GetKinds() {
 				kindToRules[kind] = append(kindToRules[kind], rule)
 			}
 		}
 	}
 
 	for kind, rules := range kindToRules {
+		if _, subresource := utils.SplitSubresource(kind); kind == "*" || subresource != "" {
+			log.Log.V(4).Info("skip validating mutation for wildcard and subresource kinds", "kind", kind)
+			continue
+		}
+
+		kind = o.resolveKind(kind)
 		newPolicy := *policy.DeepCopy()
 		newPolicy.Spec.Rules = rules
 		k := o.gvkToDefinitionName.GetKind(kind)
 		resource, _ := o.generateEmptyResource(o.definitions.GetSchema(k)).(map[string]interface{})
 		if resource == nil || len(resource) == 0 {
 			log.Log.V(2).Info("unable to validate resource. OpenApi definition not found", "kind", kind)
-			return nil
+			continue
 		}
 
 		newResource := unstructured.Unstructured{Object: resource}
-		newResource.SetKind(kind)
+		newResource.SetKind(kind[strings.LastIndex(kind, "/")+1:])
 
 		patchedResource, err := engine.ForceMutate(nil, newPolicy, newResource)
 		if err != nil {
@@ -176,6 +182,37 @@ func (o *Controller) ValidatePolicyMutation(policy v1.ClusterPolicy) error {
 	return nil
 }
 
+// resolveKind resolves a kind of the form group/*/Kind to the server preferred
+// version of the group, or to the first available version of the group
+func (o *Controller) resolveKind(kind string) string {
+	parts := strings.Split(kind, "/")
+	if len(parts) != 3 || parts[1] != "*" {
+		return kind
+	}
+
+	versions, ok := o.kindToAPIVersions.Get(parts[2])
+	if !ok {
+		return kind
+	}
+
+	versionsTyped, ok := versions.(apiVersions)
+	if !ok {
+		return kind
+	}
+
+	if strings.HasPrefix(versionsTyped.serverPreferredGVK, parts[0]+"/") {
+		return versionsTyped.serverPreferredGVK
+	}
+
+	for _, gvk := range versionsTyped.gvks {
+		if strings.HasPrefix(gvk, parts[0]+"/") {
+			return gvk
+		}
+	}
+
+	return kind
+}
+
 func (o *Controller) useOpenAPIDocument(doc *openapiv2.Document) error {
 	for _, definition := range doc.GetDefinitions().AdditionalProperties {
 		definitionName := definition.GetName()
@@ -1,6 +1,7 @@
 package policy
 
 import (
+	contextdefault "context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -14,7 +15,9 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/response"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/resourcecache"
+	"github.com/kyverno/kyverno/pkg/tracing"
 	"github.com/kyverno/kyverno/pkg/utils"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -22,7 +25,7 @@ import (
 // applyPolicy applies policy on a resource
 func applyPolicy(policy kyverno.ClusterPolicy, resource unstructured.Unstructured,
 	logger logr.Logger, excludeGroupRole []string, resCache resourcecache.ResourceCache,
-	client *client.Client, namespaceLabels map[string]string) (responses []*response.EngineResponse) {
+	client *client.Client, namespaceLabels map[string]string, promConfig *metrics.PromConfig) (responses []*response.EngineResponse) {
 
 	startTime := time.Now()
 	defer func() {
@@ -35,6 +38,15 @@ func applyPolicy(policy kyverno.ClusterPolicy, resource unstructured.Unstructure
 		logger.V(3).Info("applyPolicy", "resource", name, "processingTime", time.Since(startTime).String())
 	}()
 
+	traceCtx, span := tracing.StartSpan(contextdefault.Background(), "background-scan",
+		tracing.PolicyNameKey.String(policy.GetName()),
+		tracing.PolicyNamespaceKey.String(policy.GetNamespace()),
+		tracing.ResourceKindKey.String(resource.GetKind()),
+		tracing.ResourceNamespaceKey.String(resource.GetNamespace()),
+		tracing.ResourceNameKey.String(resource.GetName()),
+	)
+	defer span.End()
+
 	var engineResponses []*response.EngineResponse
 	var engineResponseMutation, engineResponseValidation *response.EngineResponse
 	var err error
@@ -54,7 +66,7 @@ func applyPolicy(policy kyverno.ClusterPolicy, resource unstructured.Unstructure
 		logger.Error(err, "unable to add image info to variables context")
 	}
 
-	engineResponseMutation, err = mutation(policy, resource, logger, resCache, ctx, namespaceLabels)
+	engineResponseMutation, err = mutation(traceCtx, policy, resource, logger, resCache, ctx, namespaceLabels, promConfig)
 	if err != nil {
 		logger.Error(err, "failed to process mutation rule")
 	}
@@ -67,6 +79,8 @@ func applyPolicy(policy kyverno.ClusterPolicy, resource unstructured.Unstructure
 		JSONContext:      ctx,
 		Client:           client,
 		NamespaceLabels:  namespaceLabels,
+		PromConfig:       promConfig,
+		TraceContext:     traceCtx,
 	}
 
 	engineResponseValidation = engine.Validate(policyCtx)
@@ -75,7 +89,7 @@ func applyPolicy(policy kyverno.ClusterPolicy, resource unstructured.Unstructure
 	return engineResponses
 }
 
-func mutation(policy kyverno.ClusterPolicy, resource unstructured.Unstructured, log logr.Logger, resCache resourcecache.ResourceCache, jsonContext *context.Context, namespaceLabels map[string]string) (*response.EngineResponse, error) {
+func mutation(traceCtx contextdefault.Context, policy kyverno.ClusterPolicy, resource unstructured.Unstructured, log logr.Logger, resCache resourcecache.ResourceCache, jsonContext *context.Context, namespaceLabels map[string]string, promConfig *metrics.PromConfig) (*response.EngineResponse, error) {
 
 	policyContext := &engine.PolicyContext{
 		Policy:          policy,
@@ -83,6 +97,8 @@ func mutation(policy kyverno.ClusterPolicy, resource unstructured.Unstructured,
 		ResourceCache:   resCache,
 		JSONContext:     jsonContext,
 		NamespaceLabels: namespaceLabels,
+		PromConfig:      promConfig,
+		TraceContext:    traceCtx,
 	}
 
 	engineResponse := engine.Mutate(policyContext)
@@ -109,6 +125,10 @@ func getFailedOverallRuleInfo(resource unstructured.Unstructured, engineResponse
 
 	// resource does not match so there was a mutation rule violated
 	for index, rule := range engineResponse.PolicyResponse.Rules {
+		if rule.GetStatus() != response.RuleStatusPass {
+			continue
+		}
+
 		log.V(4).Info("verifying if policy rule was applied before", "rule", rule.Name)
 
 		patches := rule.Patches
@@ -25,6 +25,14 @@ func ContainsVariablesOtherThanObject(policy kyverno.ClusterPolicy) error {
 			return fmt.Errorf("invalid variable used at path: spec/rules[%d]/exclude/%s", idx, path)
 		}
 
+		if path := resourceFiltersUserInfoDefined(rule.MatchResources.Any, rule.MatchResources.All); path != "" {
+			return fmt.Errorf("invalid variable used at path: spec/rules[%d]/match/%s", idx, path)
+		}
+
+		if path := resourceFiltersUserInfoDefined(rule.ExcludeResources.Any, rule.ExcludeResources.All); path != "" {
+			return fmt.Errorf("invalid variable used at path: spec/rules[%d]/exclude/%s", idx, path)
+		}
+
 		filterVars := []string{"request.object", "request.namespace", "images"}
 		ctx := context.NewContext(filterVars...)
 
@@ -122,6 +130,21 @@ func userInfoDefined(ui kyverno.UserInfo) string {
 	return ""
 }
 
+// resourceFiltersUserInfoDefined returns the path of the first user info defined in the any/all resource filters
+func resourceFiltersUserInfoDefined(anyFilters, allFilters kyverno.ResourceFilters) string {
+	for i, rf := range anyFilters {
+		if path := userInfoDefined(rf.UserInfo); path != "" {
+			return fmt.Sprintf("any[%d]/%s", i, path)
+		}
+	}
+	for i, rf := range allFilters {
+		if path := userInfoDefined(rf.UserInfo); path != "" {
+			return fmt.Sprintf("all[%d]/%s", i, path)
+		}
+	}
+	return ""
+}
+
 func validateBackgroundModeVars(ctx context.EvalInterface, document apiextensions.JSON) error {
 	jsonByte, err := json.Marshal(document)
 	if err != nil {
@@ -13,6 +13,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	listerv1 "k8s.io/client-go/listers/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -290,22 +291,15 @@ func excludeResources(included map[string]unstructured.Unstructured, exclude kyv
 		return Process
 	}
 
-	findKind := func(kind string, kinds []string) bool {
-		for _, k := range kinds {
-			if k == kind {
-				return true
-			}
-		}
-		return false
-	}
-
-	excludeKind := func(kind string) Condition {
+	excludeKind := func(gvk schema.GroupVersionKind) Condition {
 		if len(exclude.Kinds) == 0 {
 			return NotEvaluate
 		}
 
-		if findKind(kind, exclude.Kinds) {
-			return Skip
+		for _, kind := range exclude.Kinds {
+			if utils.MatchesGVK(kind, gvk) {
+				return Skip
+			}
 		}
 
 		return Process
@@ -327,7 +321,7 @@ func excludeResources(included map[string]unstructured.Unstructured, exclude kyv
 		if ret := excludeSelector(resource.GetLabels()); ret != NotEvaluate {
 			excludeEval = append(excludeEval, ret)
 		}
-		if ret := excludeKind(resource.GetKind()); ret != NotEvaluate {
+		if ret := excludeKind(resource.GroupVersionKind()); ret != NotEvaluate {
 			excludeEval = append(excludeEval, ret)
 		}
 		// exclude the filtered resources
@@ -0,0 +1,64 @@
+package policy
+
+import (
+	"strings"
+
+	kyverno "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/policystatus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// updatePolicyStatus reports the auto-generated rules of the policy
+// and resets its BackgroundScanCompleted condition until the background scan is done
+func (pc *PolicyController) updatePolicyStatus(policy *kyverno.ClusterPolicy) {
+	key := policyKey(policy)
+	pc.statusListener.Update(policystatus.AutogenUpdate{Policy: key, Autogen: autogenStatus(policy)})
+
+	if !pc.canBackgroundProcess(policy) {
+		pc.updateBackgroundScanCondition(policy, metav1.ConditionFalse, policystatus.ReasonBackgroundScanDisabled, "background processing is disabled for the policy")
+		return
+	}
+
+	pc.updateBackgroundScanCondition(policy, metav1.ConditionFalse, policystatus.ReasonBackgroundScanPending, "waiting for the background scan")
+}
+
+func (pc *PolicyController) updateBackgroundScanCondition(policy *kyverno.ClusterPolicy, status metav1.ConditionStatus, reason, message string) {
+	pc.statusListener.Update(policystatus.ConditionUpdate{
+		Policy: policyKey(policy),
+		Condition: metav1.Condition{
+			Type:               kyverno.PolicyConditionBackgroundScanCompleted,
+			Status:             status,
+			ObservedGeneration: policy.Generation,
+			Reason:             reason,
+			Message:            message,
+		},
+	})
+}
+
+// autogenStatus returns the pod controllers and the names of the rules auto-generated for the policy,
+// nil if no rules are auto-generated
+func autogenStatus(policy *kyverno.ClusterPolicy) *kyverno.AutogenStatus {
+	var status kyverno.AutogenStatus
+	if controllers, ok := policy.GetAnnotations()[engine.PodControllersAnnotation]; ok && controllers != "none" {
+		status.Controllers = strings.Split(controllers, ",")
+	}
+
+	for _, rule := range policy.Spec.Rules {
+		if strings.HasPrefix(rule.Name, "autogen-") {
+			status.Rules = append(status.Rules, rule.Name)
+		}
+	}
+
+	if len(status.Controllers) == 0 && len(status.Rules) == 0 {
+		return nil
+	}
+	return &status
+}
+
+func policyKey(policy *kyverno.ClusterPolicy) string {
+	if policy.Namespace == "" {
+		return policy.Name
+	}
+	return policy.Namespace + "/" + policy.Name
+}
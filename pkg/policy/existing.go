@@ -14,6 +14,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/metrics"
 	policyRuleExecutionLatency "github.com/kyverno/kyverno/pkg/metrics/policyruleexecutionlatency"
 	policyRuleResults "github.com/kyverno/kyverno/pkg/metrics/policyruleresults"
+	"github.com/kyverno/kyverno/pkg/utils"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -29,8 +30,17 @@ func (pc *PolicyController) processExistingResources(policy *kyverno.ClusterPoli
 			continue
 		}
 
-		for _, k := range rule.MatchResources.Kinds {
+		for _, k := range rule.MatchResources.GetKinds() {
 			logger = logger.WithValues("rule", rule.Name, "kind", k)
+			if _, subresource := utils.SplitSubresource(k); subresource != "" {
+				logger.V(4).Info("skipping subresource kind, subresources are only evaluated in admission requests")
+				continue
+			}
+			if k == "*" {
+				logger.V(4).Info("skipping wildcard kind, it is only evaluated in admission requests")
+				continue
+			}
+
 			namespaced, err := pc.rm.GetScope(k)
 			if err != nil {
 				if err := pc.registerResource(k); err != nil {
@@ -117,7 +127,7 @@ func (pc *PolicyController) applyPolicy(policy *kyverno.ClusterPolicy, resource
 	}
 
 	namespaceLabels := common.GetNamespaceSelectorsFromNamespaceLister(resource.GetKind(), resource.GetNamespace(), pc.nsLister, logger)
-	engineResponse := applyPolicy(*policy, resource, logger, pc.configHandler.GetExcludeGroupRole(), pc.resCache, pc.client, namespaceLabels)
+	engineResponse := applyPolicy(*policy, resource, logger, pc.configHandler.GetExcludeGroupRole(), pc.resCache, pc.client, namespaceLabels, pc.promConfig)
 	engineResponses = append(engineResponses, engineResponse...)
 
 	// post-processing, register the resource as processed
@@ -7,9 +7,9 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
-	v1alpha1 "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha2"
 	kyvernoclient "github.com/kyverno/kyverno/pkg/client/clientset/versioned"
-	policyreportlister "github.com/kyverno/kyverno/pkg/client/listers/policyreport/v1alpha1"
+	policyreportlister "github.com/kyverno/kyverno/pkg/client/listers/policyreport/v1alpha2"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/policyreport"
@@ -73,9 +73,9 @@ func eraseResultsEntries(pclient *kyvernoclient.Clientset, reportLister policyre
 		errors = append(errors, err.Error())
 	} else {
 		for _, polr := range polrs {
-			polr.Results = []*v1alpha1.PolicyReportResult{}
-			polr.Summary = v1alpha1.PolicyReportSummary{}
-			if _, err = pclient.Wgpolicyk8sV1alpha1().PolicyReports(polr.GetNamespace()).Update(context.TODO(), polr, metav1.UpdateOptions{}); err != nil {
+			polr.Results = []*v1alpha2.PolicyReportResult{}
+			polr.Summary = v1alpha2.PolicyReportSummary{}
+			if _, err = pclient.Wgpolicyk8sV1alpha2().PolicyReports(polr.GetNamespace()).Update(context.TODO(), polr, metav1.UpdateOptions{}); err != nil {
 				errors = append(errors, fmt.Sprintf("%s/%s/%s: %v", polr.Kind, polr.Namespace, polr.Name, err))
 			}
 		}
@@ -85,9 +85,9 @@ func eraseResultsEntries(pclient *kyvernoclient.Clientset, reportLister policyre
 		errors = append(errors, err.Error())
 	} else {
 		for _, cpolr := range cpolrs {
-			cpolr.Results = []*v1alpha1.PolicyReportResult{}
-			cpolr.Summary = v1alpha1.PolicyReportSummary{}
-			if _, err = pclient.Wgpolicyk8sV1alpha1().ClusterPolicyReports().Update(context.TODO(), cpolr, metav1.UpdateOptions{}); err != nil {
+			cpolr.Results = []*v1alpha2.PolicyReportResult{}
+			cpolr.Summary = v1alpha2.PolicyReportSummary{}
+			if _, err = pclient.Wgpolicyk8sV1alpha2().ClusterPolicyReports().Update(context.TODO(), cpolr, metav1.UpdateOptions{}); err != nil {
 				errors = append(errors, fmt.Sprintf("%s/%s: %v", cpolr.Kind, cpolr.Name, err))
 			}
 		}
@@ -154,6 +154,8 @@ func generateSuccessEvents(log logr.Logger, ers []*response.EngineResponse) (eve
 			e.Name = er.PolicyResponse.Policy.Name
 			e.Reason = event.PolicyApplied.String()
 			e.Source = event.PolicyController
+			e.Policy = event.PolicyKey(er.PolicyResponse.Policy.Namespace, er.PolicyResponse.Policy.Name)
+			e.Rule = strings.Join(er.GetSuccessRules(), ";")
 			e.Message = fmt.Sprintf("rules '%v' successfully applied on resource '%s/%s/%s'", er.GetSuccessRules(), er.PolicyResponse.Resource.Kind, er.PolicyResponse.Resource.Namespace, er.PolicyResponse.Resource.Name)
 			eventInfos = append(eventInfos, e)
 		}
@@ -186,6 +188,8 @@ func generateFailEventsPerEr(log logr.Logger, er *response.EngineResponse) []eve
 		e.Name = er.PolicyResponse.Resource.Name
 		e.Reason = event.PolicyViolation.String()
 		e.Source = event.PolicyController
+		e.Policy = event.PolicyKey(er.PolicyResponse.Policy.Namespace, er.PolicyResponse.Policy.Name)
+		e.Rule = rule.Name
 		e.Message = fmt.Sprintf("policy '%s' (%s) rule '%s' failed. %v", er.PolicyResponse.Policy.Name, rule.Type, rule.Name, rule.Message)
 		eventInfos = append(eventInfos, e)
 	}
@@ -203,6 +207,8 @@ func generateFailEventsPerEr(log logr.Logger, er *response.EngineResponse) []eve
 		e.Name = er.PolicyResponse.Policy.Name
 		e.Reason = event.PolicyApplied.String()
 		e.Source = event.PolicyController
+		e.Policy = event.PolicyKey(er.PolicyResponse.Policy.Namespace, er.PolicyResponse.Policy.Name)
+		e.Rule = strings.Join(er.GetSuccessRules(), ";")
 		e.Message = fmt.Sprintf("rules '%v' successfully applied on resource '%s/%s/%s'", er.GetSuccessRules(), er.PolicyResponse.Resource.Kind, er.PolicyResponse.Resource.Namespace, er.PolicyResponse.Resource.Name)
 		eventInfos = append(eventInfos, e)
 	}
@@ -220,6 +226,8 @@ func generateFailEventsPerEr(log logr.Logger, er *response.EngineResponse) []eve
 		e.Namespace = er.PolicyResponse.Policy.Namespace
 		e.Reason = event.PolicyViolation.String()
 		e.Source = event.PolicyController
+		e.Policy = event.PolicyKey(er.PolicyResponse.Policy.Namespace, er.PolicyResponse.Policy.Name)
+		e.Rule = strings.Join(er.GetFailedRules(), ";")
 		e.Message = fmt.Sprintf("rules '%v' not satisfied on resource '%s/%s/%s'", er.GetFailedRules(), er.PolicyResponse.Resource.Kind, er.PolicyResponse.Resource.Namespace, er.PolicyResponse.Resource.Name)
 		eventInfos = append(eventInfos, e)
 	}
@@ -43,6 +43,10 @@ func Validate(policy *kyverno.ClusterPolicy, client *dclient.Client, mock bool,
 	if path, err := validateUniqueRuleName(p); err != nil {
 		return fmt.Errorf("path: spec.%s: %v", path, err)
 	}
+
+	if path, err := validateValidationFailureActionOverrides(p.Spec.ValidationFailureActionOverrides); err != nil {
+		return fmt.Errorf("path: spec.%s: %v", path, err)
+	}
 	if p.Spec.Background == nil || *p.Spec.Background == true {
 		if err := ContainsVariablesOtherThanObject(p); err != nil {
 			return fmt.Errorf("only select variables are allowed in background mode. Set spec.background=false to disable background mode for this policy rule: %s ", err)
@@ -111,7 +115,7 @@ func Validate(policy *kyverno.ClusterPolicy, client *dclient.Client, mock bool,
 
 		// If a rules match block does not match any kind,
 		// we should only allow such rules to have metadata in its overlay
-		if len(rule.MatchResources.Kinds) == 0 {
+		if len(rule.MatchResources.GetKinds()) == 0 {
 			if !ruleOnlyDealsWithResourceMetaData(rule) {
 				return fmt.Errorf("policy can only deal with the metadata field of the resource if" +
 					" the rule does not match an kind")
@@ -187,6 +191,11 @@ func doMatchAndExcludeConflict(rule kyverno.Rule) bool {
 		return false
 	}
 
+	// conflicts are only detected for the resources and user info fields
+	if rule.MatchResources.HasAnyAll() || rule.ExcludeResources.HasAnyAll() {
+		return false
+	}
+
 	excludeRoles := make(map[string]bool)
 	for _, role := range rule.ExcludeResources.UserInfo.Roles {
 		excludeRoles[role] = true
@@ -503,11 +512,19 @@ func validateResources(rule kyverno.Rule) (string, error) {
 	}
 
 	// matched resources
-	if path, err := validateMatchedResourceDescription(rule.MatchResources.ResourceDescription); err != nil {
+	if rule.MatchResources.HasAnyAll() {
+		if path, err := validateResourceFilters("match", rule.MatchResources.Any, rule.MatchResources.All, rule.MatchResources.UserInfo, rule.MatchResources.ResourceDescription); err != nil {
+			return path, err
+		}
+	} else if path, err := validateMatchedResourceDescription(rule.MatchResources.ResourceDescription); err != nil {
 		return fmt.Sprintf("match.resources.%s", path), err
 	}
 	// exclude resources
-	if path, err := validateExcludeResourceDescription(rule.ExcludeResources.ResourceDescription); err != nil {
+	if rule.ExcludeResources.HasAnyAll() {
+		if path, err := validateResourceFilters("exclude", rule.ExcludeResources.Any, rule.ExcludeResources.All, rule.ExcludeResources.UserInfo, rule.ExcludeResources.ResourceDescription); err != nil {
+			return path, err
+		}
+	} else if path, err := validateExcludeResourceDescription(rule.ExcludeResources.ResourceDescription); err != nil {
 		return fmt.Sprintf("exclude.resources.%s", path), err
 	}
 
@@ -752,6 +769,74 @@ func validateMatchedResourceDescription(rd kyverno.ResourceDescription) (string,
 	return "", nil
 }
 
+// validateValidationFailureActionOverrides checks that each override has a valid action
+// and selects namespaces by name or by a valid label selector
+func validateValidationFailureActionOverrides(overrides []kyverno.ValidationFailureActionOverride) (string, error) {
+	for i, override := range overrides {
+		path := fmt.Sprintf("validationFailureActionOverrides[%d]", i)
+		if override.Action != "enforce" && override.Action != "audit" {
+			return path + ".action", fmt.Errorf("invalid action '%s', must be 'enforce' or 'audit'", override.Action)
+		}
+
+		if len(override.Namespaces) == 0 && override.NamespaceSelector == nil {
+			return path, fmt.Errorf("namespaces or namespaceSelector must be specified")
+		}
+
+		if override.NamespaceSelector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(override.NamespaceSelector); err != nil {
+				return path + ".namespaceSelector", err
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// validateResourceFilters validates the any/all resource filters of a match or exclude block,
+// the resources and user info fields cannot be specified together with any/all
+func validateResourceFilters(block string, anyFilters, allFilters kyverno.ResourceFilters, userInfo kyverno.UserInfo, rd kyverno.ResourceDescription) (string, error) {
+	if len(anyFilters) > 0 && len(allFilters) > 0 {
+		return block, fmt.Errorf("any and all can not be specified together")
+	}
+
+	if !reflect.DeepEqual(userInfo, kyverno.UserInfo{}) || !reflect.DeepEqual(rd, kyverno.ResourceDescription{}) {
+		return block, fmt.Errorf("any/all can not be specified together with resources, roles, clusterRoles or subjects")
+	}
+
+	filterType, filters := "any", anyFilters
+	if len(allFilters) > 0 {
+		filterType, filters = "all", allFilters
+	}
+
+	for i, rf := range filters {
+		path := fmt.Sprintf("%s.%s[%d]", block, filterType, i)
+		if err := validateRoles(rf.Roles); err != nil {
+			return path + ".roles", err
+		}
+
+		if err := validateSubjects(rf.Subjects); err != nil {
+			return path + ".subjects", err
+		}
+
+		if block == "exclude" {
+			if _, err := validateExcludeResourceDescription(rf.ResourceDescription); err != nil {
+				return path + ".resources", err
+			}
+			continue
+		}
+
+		if _, err := validateMatchedResourceDescription(rf.ResourceDescription); err != nil {
+			return path + ".resources", err
+		}
+
+		if len(rf.Kinds) == 0 {
+			return path + ".resources.kinds", fmt.Errorf("at least one kind must be specified in each resource filter")
+		}
+	}
+
+	return "", nil
+}
+
 func validateUserInfo(rule kyverno.Rule) (string, error) {
 	if err := validateRoles(rule.MatchResources.Roles); err != nil {
 		return "match.roles", err
@@ -832,47 +917,92 @@ func validateResourceDescription(rd kyverno.ResourceDescription) error {
 			return errors.New("the requirements are not specified in selector")
 		}
 	}
+
+	for _, kind := range rd.Kinds {
+		if err := validateKind(kind); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateKind checks that the kind is one of "*", Kind, version/Kind, group/version/Kind or group/*/Kind,
+// optionally followed by a subresource (e.g. Pod/exec)
+func validateKind(kind string) error {
+	if kind == "*" {
+		return nil
+	}
+
+	gvk, _ := utils.SplitSubresource(kind)
+	parts := strings.Split(gvk, "/")
+	if len(parts) > 3 {
+		return fmt.Errorf("invalid kind '%s': must be of the form Kind, version/Kind or group/version/Kind", kind)
+	}
+
+	for i, part := range parts {
+		if part == "" || (part == "*" && (len(parts) != 3 || i != 1)) {
+			return fmt.Errorf("invalid kind '%s': must be of the form Kind, version/Kind or group/version/Kind", kind)
+		}
+	}
 	return nil
 }
 
 // checkClusterResourceInMatchAndExclude returns false if namespaced ClusterPolicy contains cluster wide resources in
 // Match and Exclude block
 func checkClusterResourceInMatchAndExclude(rule kyverno.Rule, clusterResources []string) error {
-	// Contains Namespaces in Match->ResourceDescription
-	if len(rule.MatchResources.ResourceDescription.Namespaces) > 0 {
-		return fmt.Errorf("namespaced cluster policy : field namespaces not allowed in match.resources")
-	}
-	// Contains Namespaces in Exclude->ResourceDescription
-	if len(rule.ExcludeResources.ResourceDescription.Namespaces) > 0 {
-		return fmt.Errorf("namespaced cluster policy : field namespaces not allowed in exclude.resources")
-	}
-	// Contains "Cluster Wide Resources" in Match->ResourceDescription->Kinds
-	for _, kind := range rule.MatchResources.ResourceDescription.Kinds {
-		for _, k := range clusterResources {
-			if kind == k {
-				return fmt.Errorf("namespaced policy : cluster type value '%s' not allowed in match.resources.kinds", kind)
+	matchDescriptions := append([]kyverno.ResourceDescription{rule.MatchResources.ResourceDescription}, filterDescriptions(rule.MatchResources.Any, rule.MatchResources.All)...)
+	excludeDescriptions := append([]kyverno.ResourceDescription{rule.ExcludeResources.ResourceDescription}, filterDescriptions(rule.ExcludeResources.Any, rule.ExcludeResources.All)...)
+
+	for _, rd := range matchDescriptions {
+		// Contains Namespaces in Match->ResourceDescription
+		if len(rd.Namespaces) > 0 {
+			return fmt.Errorf("namespaced cluster policy : field namespaces not allowed in match.resources")
+		}
+		// Contains "Cluster Wide Resources" in Match->ResourceDescription->Kinds
+		for _, kind := range rd.Kinds {
+			for _, k := range clusterResources {
+				if kind == k {
+					return fmt.Errorf("namespaced policy : cluster type value '%s' not allowed in match.resources.kinds", kind)
+				}
 			}
 		}
 	}
-	// Contains "Cluster Wide Resources" in Exclude->ResourceDescription->Kinds
-	for _, kind := range rule.ExcludeResources.ResourceDescription.Kinds {
-		for _, k := range clusterResources {
-			if kind == k {
-				return fmt.Errorf("namespaced policy : cluster type value '%s' not allowed in exclude.resources.kinds", kind)
+
+	for _, rd := range excludeDescriptions {
+		// Contains Namespaces in Exclude->ResourceDescription
+		if len(rd.Namespaces) > 0 {
+			return fmt.Errorf("namespaced cluster policy : field namespaces not allowed in exclude.resources")
+		}
+		// Contains "Cluster Wide Resources" in Exclude->ResourceDescription->Kinds
+		for _, kind := range rd.Kinds {
+			for _, k := range clusterResources {
+				if kind == k {
+					return fmt.Errorf("namespaced policy : cluster type value '%s' not allowed in exclude.resources.kinds", kind)
+				}
 			}
 		}
-
 	}
 	return nil
 }
 
+// filterDescriptions returns the resource descriptions of the any/all resource filters
+func filterDescriptions(filters ...kyverno.ResourceFilters) []kyverno.ResourceDescription {
+	var descriptions []kyverno.ResourceDescription
+	for _, rfs := range filters {
+		for _, rf := range rfs {
+			descriptions = append(descriptions, rf.ResourceDescription)
+		}
+	}
+	return descriptions
+}
+
 // jsonPatchOnPod checks if a rule applies JSON patches to Pod
 func jsonPatchOnPod(rule kyverno.Rule) bool {
 	if !rule.HasMutate() {
 		return false
 	}
 
-	if utils.ContainsString(rule.MatchResources.Kinds, "Pod") && rule.Mutation.PatchesJSON6902 != "" {
+	if utils.ContainsKind(rule.MatchResources.GetKinds(), "Pod") && rule.Mutation.PatchesJSON6902 != "" {
 		return true
 	}
 
@@ -30,6 +30,10 @@ func (v *Validate) Validate() (string, error) {
 		return "", err
 	}
 
+	if rule.ValidationFailureAction != "" && rule.ValidationFailureAction != "enforce" && rule.ValidationFailureAction != "audit" {
+		return "validationFailureAction", fmt.Errorf("invalid action '%s', must be 'enforce' or 'audit'", rule.ValidationFailureAction)
+	}
+
 	if rule.Pattern != nil {
 		if path, err := common.ValidatePattern(rule.Pattern, "/", []commonAnchors.IsAnchor{commonAnchors.IsConditionAnchor, commonAnchors.IsExistenceAnchor, commonAnchors.IsEqualityAnchor, commonAnchors.IsNegationAnchor}); err != nil {
 			return fmt.Sprintf("pattern.%s", path), err
@@ -379,3 +379,29 @@ func Test_Validate_Validate_Unsupported(t *testing.T) {
 	}
 
 }
+
+func Test_Validate_ValidationFailureAction(t *testing.T) {
+	testCases := []struct {
+		action string
+		valid  bool
+	}{
+		{action: "", valid: true},
+		{action: "enforce", valid: true},
+		{action: "audit", valid: true},
+		{action: "block", valid: false},
+	}
+
+	for _, tc := range testCases {
+		validation := kyverno.Validation{
+			ValidationFailureAction: tc.action,
+			Pattern:                 map[string]interface{}{"metadata": map[string]interface{}{"name": "?*"}},
+		}
+
+		checker := NewValidateFactory(validation)
+		path, err := checker.Validate()
+		assert.Equal(t, err == nil, tc.valid, "action %q: %v", tc.action, err)
+		if !tc.valid {
+			assert.Equal(t, path, "validationFailureAction")
+		}
+	}
+}
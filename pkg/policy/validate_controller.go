@@ -24,6 +24,7 @@ import (
 	policyRuleInfoMetric "github.com/kyverno/kyverno/pkg/metrics/policyruleinfo"
 	pm "github.com/kyverno/kyverno/pkg/policymutation"
 	"github.com/kyverno/kyverno/pkg/policyreport"
+	"github.com/kyverno/kyverno/pkg/policystatus"
 	"github.com/kyverno/kyverno/pkg/resourcecache"
 	utils "github.com/kyverno/kyverno/pkg/utils"
 	v1 "k8s.io/api/core/v1"
@@ -116,6 +117,9 @@ type PolicyController struct {
 	log logr.Logger
 
 	promConfig *metrics.PromConfig
+
+	// statusListener updates the conditions and autogen details of the policy status
+	statusListener policystatus.Listener
 }
 
 // NewPolicyController create a new PolicyController
@@ -134,7 +138,8 @@ func NewPolicyController(
 	log logr.Logger,
 	resCache resourcecache.ResourceCache,
 	reconcilePeriod time.Duration,
-	promConfig *metrics.PromConfig) (*PolicyController, error) {
+	promConfig *metrics.PromConfig,
+	statusListener policystatus.Listener) (*PolicyController, error) {
 
 	// Event broad caster
 	eventBroadcaster := record.NewBroadcaster()
@@ -159,6 +164,7 @@ func NewPolicyController(
 		resCache:           resCache,
 		reconcilePeriod:    reconcilePeriod,
 		promConfig:         promConfig,
+		statusListener:     statusListener,
 		log:                log,
 	}
 
@@ -275,6 +281,7 @@ func (pc *PolicyController) addPolicy(obj interface{}) {
 		}
 	}
 
+	pc.updatePolicyStatus(p)
 	if !pc.canBackgroundProcess(p) {
 		return
 	}
@@ -302,11 +309,12 @@ func (pc *PolicyController) updatePolicy(old, cur interface{}) {
 		}
 	}
 
-	if !pc.canBackgroundProcess(curP) {
+	if reflect.DeepEqual(oldP.Spec, curP.Spec) {
 		return
 	}
 
-	if reflect.DeepEqual(oldP.Spec, curP.Spec) {
+	pc.updatePolicyStatus(curP)
+	if !pc.canBackgroundProcess(curP) {
 		return
 	}
 
@@ -431,6 +439,8 @@ func (pc *PolicyController) addNsPolicy(obj interface{}) {
 			logger.Error(err, "failed to add namespace policy")
 		}
 	}
+
+	pc.updatePolicyStatus(pol)
 	if !pc.canBackgroundProcess(pol) {
 		return
 	}
@@ -459,11 +469,12 @@ func (pc *PolicyController) updateNsPolicy(old, cur interface{}) {
 		}
 	}
 
-	if !pc.canBackgroundProcess(ncurP) {
+	if reflect.DeepEqual(oldP.Spec, curP.Spec) {
 		return
 	}
 
-	if reflect.DeepEqual(oldP.Spec, curP.Spec) {
+	pc.updatePolicyStatus(ncurP)
+	if !pc.canBackgroundProcess(ncurP) {
 		return
 	}
 
@@ -641,6 +652,7 @@ func (pc *PolicyController) syncPolicy(key string) error {
 
 	updateGR(pc.kyvernoClient, policy.Name, grList, logger)
 	pc.processExistingResources(policy, startTime.Unix())
+	pc.updateBackgroundScanCondition(policy, metav1.ConditionTrue, policystatus.ReasonBackgroundScanCompleted, "policy applied to the existing resources")
 	return nil
 }
 
@@ -1287,6 +1287,136 @@ func Test_Validate_Kind(t *testing.T) {
 	assert.Assert(t, err != nil)
 }
 
+func Test_Validate_Wildcard_Kind(t *testing.T) {
+	testCases := []struct {
+		name       string
+		background string
+		kind       string
+		valid      bool
+	}{
+		{name: "wildcard-in-background", background: "true", kind: "*", valid: true},
+		{name: "wildcard-without-background", background: "false", kind: "*", valid: true},
+		{name: "wildcard-version", background: "true", kind: "networking.k8s.io/*/Ingress", valid: true},
+		{name: "wildcard-group", background: "true", kind: "*/v1/Ingress", valid: false},
+		{name: "empty-group", background: "true", kind: "/v1/Ingress", valid: false},
+		{name: "too-many-parts", background: "true", kind: "a/networking.k8s.io/v1/Ingress", valid: false},
+	}
+
+	for _, tc := range testCases {
+		rawPolicy := []byte(fmt.Sprintf(`{
+			"apiVersion": "kyverno.io/v1",
+			"kind": "ClusterPolicy",
+			"metadata": {
+				"name": "require-labels"
+			},
+			"spec": {
+				"background": %s,
+				"rules": [
+					{
+						"name": "check-labels",
+						"match": {
+							"resources": {
+								"kinds": ["%s"]
+							}
+						},
+						"validate": {
+							"message": "label app is required",
+							"pattern": {
+								"metadata": {
+									"labels": {
+										"app": "?*"
+									}
+								}
+							}
+						}
+					}
+				]
+			}
+		}`, tc.background, tc.kind))
+
+		var policy *kyverno.ClusterPolicy
+		err := json.Unmarshal(rawPolicy, &policy)
+		assert.NilError(t, err)
+
+		openAPIController, _ := openapi.NewOpenAPIController()
+		err = Validate(policy, nil, true, openAPIController)
+		assert.Equal(t, err == nil, tc.valid, tc.name)
+	}
+}
+
+func Test_Validate_AnyAll_ResourceFilters(t *testing.T) {
+	testCases := []struct {
+		name  string
+		match string
+		valid bool
+	}{
+		{name: "any", match: `{"any":[{"resources":{"kinds":["Pod"],"namespaces":["a"]}},{"resources":{"kinds":["Deployment"],"selector":{"matchLabels":{"team":"x"}}}}]}`, valid: true},
+		{name: "all", match: `{"all":[{"resources":{"kinds":["Pod"]}},{"resources":{"kinds":["Pod"],"namespaces":["a"]}}]}`, valid: true},
+		{name: "any-and-all", match: `{"any":[{"resources":{"kinds":["Pod"]}}],"all":[{"resources":{"kinds":["Pod"]}}]}`, valid: false},
+		{name: "any-and-resources", match: `{"any":[{"resources":{"kinds":["Pod"]}}],"resources":{"kinds":["Pod"]}}`, valid: false},
+		{name: "any-without-kinds", match: `{"any":[{"resources":{"namespaces":["a"]}}]}`, valid: false},
+	}
+
+	for _, tc := range testCases {
+		rawPolicy := []byte(fmt.Sprintf(`{
+			"apiVersion": "kyverno.io/v1",
+			"kind": "ClusterPolicy",
+			"metadata": {
+				"name": "require-labels"
+			},
+			"spec": {
+				"rules": [
+					{
+						"name": "check-labels",
+						"match": %s,
+						"validate": {
+							"message": "label app is required",
+							"pattern": {
+								"metadata": {
+									"labels": {
+										"app": "?*"
+									}
+								}
+							}
+						}
+					}
+				]
+			}
+		}`, tc.match))
+
+		var policy *kyverno.ClusterPolicy
+		err := json.Unmarshal(rawPolicy, &policy)
+		assert.NilError(t, err)
+
+		openAPIController, _ := openapi.NewOpenAPIController()
+		err = Validate(policy, nil, true, openAPIController)
+		assert.Equal(t, err == nil, tc.valid, "%s: %v", tc.name, err)
+	}
+}
+
+func Test_Validate_ValidationFailureActionOverrides(t *testing.T) {
+	testCases := []struct {
+		name      string
+		overrides string
+		valid     bool
+	}{
+		{name: "namespaces", overrides: `[{"action":"enforce","namespaces":["prod-*"]}]`, valid: true},
+		{name: "selector", overrides: `[{"action":"audit","namespaceSelector":{"matchLabels":{"env":"dev"}}}]`, valid: true},
+		{name: "invalid-action", overrides: `[{"action":"block","namespaces":["prod"]}]`, valid: false},
+		{name: "no-namespaces", overrides: `[{"action":"enforce"}]`, valid: false},
+		{name: "invalid-selector", overrides: `[{"action":"enforce","namespaceSelector":{"matchExpressions":[{"key":"env","operator":"Equals"}]}}]`, valid: false},
+	}
+
+	for _, tc := range testCases {
+		var overrides []kyverno.ValidationFailureActionOverride
+		err := json.Unmarshal([]byte(tc.overrides), &overrides)
+		assert.NilError(t, err)
+
+		_, err = validateValidationFailureActionOverrides(overrides)
+		assert.Equal(t, err == nil, tc.valid, "%s: %v", tc.name, err)
+	}
+}
+
 func Test_checkAutoGenRules(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -7,7 +7,10 @@ import (
 	kyverno "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
 	kyvernolister "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/common"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/kyverno/kyverno/pkg/metrics/policycachelookup"
 	policy2 "github.com/kyverno/kyverno/pkg/policy"
+	"github.com/kyverno/kyverno/pkg/utils"
 )
 
 type pMap struct {
@@ -33,6 +36,9 @@ type policyCache struct {
 
 	// npLister can list/get namespace policy from the shared informer's store
 	npLister kyvernolister.PolicyLister
+
+	// promConfig records the number of policies returned by the lookups, it is nil if metrics are disabled
+	promConfig *metrics.PromConfig
 }
 
 // Interface ...
@@ -53,7 +59,7 @@ type Interface interface {
 }
 
 // newPolicyCache ...
-func newPolicyCache(log logr.Logger, pLister kyvernolister.ClusterPolicyLister, npLister kyvernolister.PolicyLister) Interface {
+func newPolicyCache(log logr.Logger, pLister kyvernolister.ClusterPolicyLister, npLister kyvernolister.PolicyLister) *policyCache {
 	namesCache := map[PolicyType]map[string]bool{
 		Mutate:          make(map[string]bool),
 		ValidateEnforce: make(map[string]bool),
@@ -70,6 +76,7 @@ func newPolicyCache(log logr.Logger, pLister kyvernolister.ClusterPolicyLister,
 		log,
 		pLister,
 		npLister,
+		nil,
 	}
 }
 
@@ -85,12 +92,22 @@ func (pc *policyCache) get(pkey PolicyType, kind, nspace string) []string {
 }
 func (pc *policyCache) GetPolicies(pkey PolicyType, kind, nspace string) []*kyverno.ClusterPolicy {
 	policies := pc.getPolicyObject(pkey, kind, "")
-	if nspace == "" {
-		return policies
+	if nspace != "" {
+		policies = append(policies, pc.getPolicyObject(pkey, kind, nspace)...)
+	}
+
+	pc.registerLookup(pkey, len(policies))
+	return policies
+}
+
+func (pc *policyCache) registerLookup(pkey PolicyType, policies int) {
+	if pc.promConfig == nil {
+		return
 	}
 
-	nsPolicies := pc.getPolicyObject(pkey, kind, nspace)
-	return append(policies, nsPolicies...)
+	if err := policycachelookup.ParsePromMetrics(*pc.promConfig.Metrics).RegisterLookup(pkey.String(), policies); err != nil {
+		pc.Logger.Error(err, "error occurred while registering kyverno_policy_cache_lookup_policies metrics")
+	}
 }
 
 // Remove a policy from cache
@@ -103,7 +120,17 @@ func (m *pMap) add(policy *kyverno.ClusterPolicy) {
 	m.Lock()
 	defer m.Unlock()
 
+	// policies with validationFailureActionOverrides can be both enforced and audited,
+	// depending on the namespace of the resource
 	enforcePolicy := policy.Spec.ValidationFailureAction == "enforce"
+	auditPolicy := !enforcePolicy
+	for _, override := range policy.Spec.ValidationFailureActionOverrides {
+		if override.Action == "enforce" {
+			enforcePolicy = true
+		} else {
+			auditPolicy = true
+		}
+	}
 	mutateMap := m.nameCacheMap[Mutate]
 	validateEnforceMap := m.nameCacheMap[ValidateEnforce]
 	validateAuditMap := m.nameCacheMap[ValidateAudit]
@@ -118,8 +145,8 @@ func (m *pMap) add(policy *kyverno.ClusterPolicy) {
 
 	for _, rule := range policy.Spec.Rules {
 
-		for _, gvk := range rule.MatchResources.Kinds {
-			_, kind := common.GetKindFromGVK(gvk)
+		for _, gvk := range rule.MatchResources.GetKinds() {
+			kind := getKind(gvk)
 			_, ok := m.kindDataMap[kind]
 			if !ok {
 				m.kindDataMap[kind] = make(map[PolicyType][]string)
@@ -135,17 +162,22 @@ func (m *pMap) add(policy *kyverno.ClusterPolicy) {
 			}
 
 			if rule.HasValidate() {
-				if enforcePolicy {
+				// a rule level validationFailureAction takes precedence over the policy actions
+				enforceRule, auditRule := enforcePolicy, auditPolicy
+				if action := rule.Validation.ValidationFailureAction; action != "" {
+					enforceRule, auditRule = action == "enforce", action != "enforce"
+				}
+
+				if enforceRule {
 					if !validateEnforceMap[kind+"/"+pName] {
 						validateEnforceMap[kind+"/"+pName] = true
 						validatePolicy := m.kindDataMap[kind][ValidateEnforce]
 						m.kindDataMap[kind][ValidateEnforce] = append(validatePolicy, pName)
 					}
-					continue
 				}
 
 				// ValidateAudit
-				if !validateAuditMap[kind+"/"+pName] {
+				if auditRule && !validateAuditMap[kind+"/"+pName] {
 					validateAuditMap[kind+"/"+pName] = true
 					validatePolicy := m.kindDataMap[kind][ValidateAudit]
 					m.kindDataMap[kind][ValidateAudit] = append(validatePolicy, pName)
@@ -183,8 +215,21 @@ func (m *pMap) add(policy *kyverno.ClusterPolicy) {
 func (pc *pMap) get(key PolicyType, gvk, namespace string) (names []string) {
 	pc.RLock()
 	defer pc.RUnlock()
-	_, kind := common.GetKindFromGVK(gvk)
-	for _, policyName := range pc.kindDataMap[kind][key] {
+	kind := getKind(gvk)
+	policyNames := append([]string{}, pc.kindDataMap[kind][key]...)
+	if parent, subresource := utils.SplitSubresource(kind); subresource != "" && subresource != "*" {
+		policyNames = append(policyNames, pc.kindDataMap[parent+"/*"][key]...)
+	}
+	if kind != "*" {
+		// policies matching the wildcard kind apply to every kind
+		for _, policyName := range pc.kindDataMap["*"][key] {
+			if !utils.ContainsString(policyNames, policyName) {
+				policyNames = append(policyNames, policyName)
+			}
+		}
+	}
+
+	for _, policyName := range policyNames {
 		ns, key, isNamespacedPolicy := policy2.ParseNamespacedPolicy(policyName)
 		if !isNamespacedPolicy && namespace == "" {
 			names = append(names, key)
@@ -207,8 +252,8 @@ func (m *pMap) remove(policy *kyverno.ClusterPolicy) {
 	}
 
 	for _, rule := range policy.Spec.Rules {
-		for _, gvk := range rule.MatchResources.Kinds {
-			_, kind := common.GetKindFromGVK(gvk)
+		for _, gvk := range rule.MatchResources.GetKinds() {
+			kind := getKind(gvk)
 			dataMap := m.kindDataMap[kind]
 			for policyType, policies := range dataMap {
 				var newPolicies []string
@@ -230,8 +275,7 @@ func (m *pMap) remove(policy *kyverno.ClusterPolicy) {
 	}
 }
 func (m *policyCache) getPolicyObject(key PolicyType, gvk string, nspace string) (policyObject []*kyverno.ClusterPolicy) {
-	_, kind := common.GetKindFromGVK(gvk)
-	policyNames := m.pMap.get(key, kind, nspace)
+	policyNames := m.pMap.get(key, gvk, nspace)
 	for _, policyName := range policyNames {
 		var policy *kyverno.ClusterPolicy
 		ns, key, isNamespacedPolicy := policy2.ParseNamespacedPolicy(policyName)
@@ -247,3 +291,15 @@ func (m *policyCache) getPolicyObject(key PolicyType, gvk string, nspace string)
 	}
 	return policyObject
 }
+
+// getKind returns the key used to index policies by kind, the subresource
+// is preserved for kinds of the form Kind/subresource (e.g. Pod/exec)
+func getKind(gvk string) string {
+	gvk, subresource := utils.SplitSubresource(gvk)
+	_, kind := common.GetKindFromGVK(gvk)
+	if subresource != "" {
+		return kind + "/" + subresource
+	}
+
+	return kind
+}
@@ -8,6 +8,7 @@ import (
 	kyverno "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
 
 	lv1 "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	"gotest.tools/assert"
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -834,3 +835,276 @@ func Test_NsMutate_Policy(t *testing.T) {
 	}
 
 }
+
+func Test_Subresource_Policy(t *testing.T) {
+	pCache := newPolicyCache(log.Log, dummyLister{}, dummyNsLister{})
+	rawPolicy := []byte(`{
+		"metadata": {
+			"name": "block-exec"
+		},
+		"spec": {
+			"validationFailureAction": "enforce",
+			"rules": [
+				{
+					"name": "deny-exec",
+					"match": {
+						"resources": {
+							"kinds": [
+								"Pod/exec"
+							]
+						}
+					},
+					"validate": {
+						"deny": {}
+					}
+				},
+				{
+					"name": "deny-scale",
+					"match": {
+						"resources": {
+							"kinds": [
+								"apps/v1/Deployment/*"
+							]
+						}
+					},
+					"validate": {
+						"deny": {}
+					}
+				}
+			]
+		}
+	}`)
+
+	var policy *kyverno.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+
+	pCache.Add(policy)
+	assert.Equal(t, len(pCache.get(ValidateEnforce, "Pod/exec", "")), 1)
+	assert.Equal(t, len(pCache.get(ValidateEnforce, "Pod", "")), 0)
+	assert.Equal(t, len(pCache.get(ValidateEnforce, "Deployment/scale", "")), 1)
+	assert.Equal(t, len(pCache.get(ValidateEnforce, "Deployment", "")), 0)
+
+	pCache.Remove(policy)
+	assert.Equal(t, len(pCache.get(ValidateEnforce, "Pod/exec", "")), 0)
+	assert.Equal(t, len(pCache.get(ValidateEnforce, "Deployment/scale", "")), 0)
+}
+
+func Test_Wildcard_Kind_Policy(t *testing.T) {
+	pCache := newPolicyCache(log.Log, dummyLister{}, dummyNsLister{})
+	rawPolicy := []byte(`{
+		"metadata": {
+			"name": "require-labels"
+		},
+		"spec": {
+			"validationFailureAction": "enforce",
+			"background": false,
+			"rules": [
+				{
+					"name": "check-all",
+					"match": {
+						"resources": {
+							"kinds": [
+								"*"
+							]
+						}
+					},
+					"validate": {
+						"deny": {}
+					}
+				},
+				{
+					"name": "check-ingress",
+					"match": {
+						"resources": {
+							"kinds": [
+								"networking.k8s.io/*/Ingress"
+							]
+						}
+					},
+					"validate": {
+						"deny": {}
+					}
+				}
+			]
+		}
+	}`)
+
+	var policy *kyverno.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+
+	pCache.Add(policy)
+	assert.Equal(t, len(pCache.get(ValidateEnforce, "Pod", "")), 1)
+	assert.Equal(t, len(pCache.get(ValidateEnforce, "Ingress", "")), 1)
+	assert.Equal(t, len(pCache.get(ValidateEnforce, "networking.k8s.io/v1/Ingress", "")), 1)
+	assert.Equal(t, len(pCache.get(ValidateAudit, "Pod", "")), 0)
+
+	pCache.Remove(policy)
+	assert.Equal(t, len(pCache.get(ValidateEnforce, "Pod", "")), 0)
+	assert.Equal(t, len(pCache.get(ValidateEnforce, "Ingress", "")), 0)
+}
+
+func Test_AnyAll_Kinds_Policy(t *testing.T) {
+	pCache := newPolicyCache(log.Log, dummyLister{}, dummyNsLister{})
+	rawPolicy := []byte(`{
+		"metadata": {
+			"name": "require-labels"
+		},
+		"spec": {
+			"validationFailureAction": "enforce",
+			"rules": [
+				{
+					"name": "check-labels",
+					"match": {
+						"any": [
+							{
+								"resources": {
+									"kinds": ["Pod"],
+									"namespaces": ["a"]
+								}
+							},
+							{
+								"resources": {
+									"kinds": ["Deployment"]
+								}
+							}
+						]
+					},
+					"validate": {
+						"deny": {}
+					}
+				}
+			]
+		}
+	}`)
+
+	var policy *kyverno.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+
+	pCache.Add(policy)
+	assert.Equal(t, len(pCache.get(ValidateEnforce, "Pod", "")), 1)
+	assert.Equal(t, len(pCache.get(ValidateEnforce, "Deployment", "")), 1)
+	assert.Equal(t, len(pCache.get(ValidateEnforce, "Service", "")), 0)
+
+	pCache.Remove(policy)
+	assert.Equal(t, len(pCache.get(ValidateEnforce, "Pod", "")), 0)
+	assert.Equal(t, len(pCache.get(ValidateEnforce, "Deployment", "")), 0)
+}
+
+func Test_ValidationFailureActionOverrides_Policy(t *testing.T) {
+	pCache := newPolicyCache(log.Log, dummyLister{}, dummyNsLister{})
+	rawPolicy := []byte(`{
+		"metadata": {
+			"name": "require-labels"
+		},
+		"spec": {
+			"validationFailureAction": "audit",
+			"validationFailureActionOverrides": [
+				{
+					"action": "enforce",
+					"namespaces": ["prod-*"]
+				}
+			],
+			"rules": [
+				{
+					"name": "check-labels",
+					"match": {
+						"resources": {
+							"kinds": ["Pod"]
+						}
+					},
+					"validate": {
+						"deny": {}
+					}
+				}
+			]
+		}
+	}`)
+
+	var policy *kyverno.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+
+	pCache.Add(policy)
+	assert.Equal(t, len(pCache.get(ValidateEnforce, "Pod", "")), 1)
+	assert.Equal(t, len(pCache.get(ValidateAudit, "Pod", "")), 1)
+
+	pCache.Remove(policy)
+	assert.Equal(t, len(pCache.get(ValidateEnforce, "Pod", "")), 0)
+	assert.Equal(t, len(pCache.get(ValidateAudit, "Pod", "")), 0)
+}
+
+func Test_Rule_ValidationFailureAction_Policy(t *testing.T) {
+	pCache := newPolicyCache(log.Log, dummyLister{}, dummyNsLister{})
+	rawPolicy := []byte(`{
+		"metadata": {
+			"name": "require-labels"
+		},
+		"spec": {
+			"validationFailureAction": "audit",
+			"rules": [
+				{
+					"name": "check-app",
+					"match": {
+						"resources": {
+							"kinds": ["Pod"]
+						}
+					},
+					"validate": {
+						"validationFailureAction": "enforce",
+						"deny": {}
+					}
+				},
+				{
+					"name": "check-team",
+					"match": {
+						"resources": {
+							"kinds": ["Deployment"]
+						}
+					},
+					"validate": {
+						"deny": {}
+					}
+				}
+			]
+		}
+	}`)
+
+	var policy *kyverno.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+
+	pCache.Add(policy)
+	assert.Equal(t, len(pCache.get(ValidateEnforce, "Pod", "")), 1)
+	assert.Equal(t, len(pCache.get(ValidateAudit, "Pod", "")), 0)
+	assert.Equal(t, len(pCache.get(ValidateEnforce, "Deployment", "")), 0)
+	assert.Equal(t, len(pCache.get(ValidateAudit, "Deployment", "")), 1)
+}
+
+func Test_GetPolicies_registersLookup(t *testing.T) {
+	promConfig := metrics.NewPromConfig(metrics.MetricsConfig{})
+	pCache := newPolicyCache(log.Log, dummyLister{}, dummyNsLister{})
+	pCache.promConfig = promConfig
+
+	pCache.GetPolicies(Mutate, "Pod", "default")
+	pCache.GetPolicies(Mutate, "Pod", "")
+
+	families, err := promConfig.MetricsRegistry.Gather()
+	assert.NilError(t, err)
+
+	var sampleCount uint64
+	for _, family := range families {
+		if family.GetName() != "kyverno_policy_cache_lookup_policies" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			assert.Equal(t, metric.GetLabel()[0].GetValue(), "mutate")
+			sampleCount += metric.GetHistogram().GetSampleCount()
+		}
+	}
+
+	assert.Equal(t, sampleCount, uint64(2))
+}
@@ -6,6 +6,10 @@ import (
 	"github.com/go-logr/logr"
 	kyverno "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/kyverno/kyverno/pkg/policystatus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -19,17 +23,26 @@ type Controller struct {
 	nspSynched cache.InformerSynced
 	Cache      Interface
 	log        logr.Logger
+
+	// statusListener sets the PolicyCached condition of the cached policies
+	statusListener policystatus.Listener
 }
 
 // NewPolicyCacheController create a new PolicyController
 func NewPolicyCacheController(
 	pInformer kyvernoinformer.ClusterPolicyInformer,
 	nspInformer kyvernoinformer.PolicyInformer,
+	promConfig *metrics.PromConfig,
+	statusListener policystatus.Listener,
 	log logr.Logger) *Controller {
 
+	policyCache := newPolicyCache(log, pInformer.Lister(), nspInformer.Lister())
+	policyCache.promConfig = promConfig
+
 	pc := Controller{
-		Cache: newPolicyCache(log, pInformer.Lister(), nspInformer.Lister()),
-		log:   log,
+		Cache:          policyCache,
+		log:            log,
+		statusListener: statusListener,
 	}
 
 	// ClusterPolicy Informer
@@ -62,6 +75,7 @@ func convertPolicyToClusterPolicy(nsPolicies *kyverno.Policy) *kyverno.ClusterPo
 func (c *Controller) addPolicy(obj interface{}) {
 	p := obj.(*kyverno.ClusterPolicy)
 	c.Cache.Add(p)
+	c.updatePolicyCachedCondition(p.Name, p)
 }
 
 func (c *Controller) updatePolicy(old, cur interface{}) {
@@ -73,6 +87,7 @@ func (c *Controller) updatePolicy(old, cur interface{}) {
 	}
 	c.Cache.Remove(pOld)
 	c.Cache.Add(pNew)
+	c.updatePolicyCachedCondition(pNew.Name, pNew)
 }
 
 func (c *Controller) deletePolicy(obj interface{}) {
@@ -82,8 +97,9 @@ func (c *Controller) deletePolicy(obj interface{}) {
 
 // addNsPolicy - Add Policy to cache
 func (c *Controller) addNsPolicy(obj interface{}) {
-	p := obj.(*kyverno.Policy)
-	c.Cache.Add(convertPolicyToClusterPolicy(p))
+	p := convertPolicyToClusterPolicy(obj.(*kyverno.Policy))
+	c.Cache.Add(p)
+	c.updatePolicyCachedCondition(p.Namespace+"/"+p.Name, p)
 }
 
 // updateNsPolicy - Update Policy of cache
@@ -94,7 +110,9 @@ func (c *Controller) updateNsPolicy(old, cur interface{}) {
 		return
 	}
 	c.Cache.Remove(convertPolicyToClusterPolicy(npOld))
-	c.Cache.Add(convertPolicyToClusterPolicy(npNew))
+	pNew := convertPolicyToClusterPolicy(npNew)
+	c.Cache.Add(pNew)
+	c.updatePolicyCachedCondition(npNew.Namespace+"/"+npNew.Name, pNew)
 }
 
 // deleteNsPolicy - Delete Policy from cache
@@ -103,6 +121,25 @@ func (c *Controller) deleteNsPolicy(obj interface{}) {
 	c.Cache.Remove(convertPolicyToClusterPolicy(p))
 }
 
+// updatePolicyCachedCondition marks the policy as served by the policy cache,
+// key is namespace/name for namespaced policies
+func (c *Controller) updatePolicyCachedCondition(key string, policy *kyverno.ClusterPolicy) {
+	condition := metav1.Condition{
+		Type:               kyverno.PolicyConditionPolicyCached,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: policy.Generation,
+		Reason:             policystatus.ReasonPolicyCached,
+		Message:            "policy is served by the policy cache of the admission webhooks",
+	}
+
+	if current := meta.FindStatusCondition(policy.Status.Conditions, condition.Type); current != nil &&
+		current.Status == condition.Status && current.ObservedGeneration == condition.ObservedGeneration {
+		return
+	}
+
+	c.statusListener.Update(policystatus.ConditionUpdate{Policy: key, Condition: condition})
+}
+
 // Run waits until policy informer to be synced
 func (c *Controller) Run(workers int, stopCh <-chan struct{}) {
 	logger := c.log
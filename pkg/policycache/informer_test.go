@@ -0,0 +1,32 @@
+package policycache
+
+import (
+	"testing"
+
+	kyverno "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/policystatus"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_Controller_setsPolicyCachedCondition(t *testing.T) {
+	pCache := newPolicyCache(log.Log, dummyLister{}, dummyNsLister{})
+	listener := make(policystatus.Listener, 2)
+	c := &Controller{Cache: pCache, log: log.Log, statusListener: listener}
+
+	policy := &kyverno.Policy{ObjectMeta: metav1.ObjectMeta{Name: "require-labels", Namespace: "default", Generation: 2}}
+	c.addNsPolicy(policy)
+	assert.Equal(t, len(listener), 1)
+
+	update := (<-listener).(policystatus.ConditionUpdate)
+	assert.Equal(t, update.Policy, "default/require-labels")
+	assert.Equal(t, update.Condition.Type, kyverno.PolicyConditionPolicyCached)
+	assert.Equal(t, update.Condition.Status, metav1.ConditionTrue)
+	assert.Equal(t, update.Condition.ObservedGeneration, int64(2))
+
+	// the condition is not updated again for the same generation
+	policy.Status.Conditions = []metav1.Condition{update.Condition}
+	c.addNsPolicy(policy)
+	assert.Equal(t, len(listener), 0)
+}
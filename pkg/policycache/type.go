@@ -11,3 +11,20 @@ const (
 	Generate
 	VerifyImages
 )
+
+func (t PolicyType) String() string {
+	switch t {
+	case Mutate:
+		return "mutate"
+	case ValidateEnforce:
+		return "validate_enforce"
+	case ValidateAudit:
+		return "validate_audit"
+	case Generate:
+		return "generate"
+	case VerifyImages:
+		return "verify_images"
+	default:
+		return "unknown"
+	}
+}
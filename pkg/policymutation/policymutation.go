@@ -150,6 +150,18 @@ func convertOverlayToStrategicMerge(policy *kyverno.ClusterPolicy, log logr.Logg
 	return patches, errs
 }
 
+// ConvertDeprecatedMutations returns the JSON patches that replace the deprecated patches and overlay
+// of the mutate rules with patchesJson6902 and patchStrategicMerge
+func ConvertDeprecatedMutations(policy *kyverno.ClusterPolicy, log logr.Logger) (patches [][]byte, errs []error) {
+	patches, errs = convertPatchToJSON6902(policy, log)
+	if len(policy.Spec.Rules) == 0 {
+		return patches, errs
+	}
+
+	overlayPatches, overlayErrs := convertOverlayToStrategicMerge(policy, log)
+	return append(patches, overlayPatches...), append(errs, overlayErrs...)
+}
+
 func defaultBackgroundFlag(policy *kyverno.ClusterPolicy, log logr.Logger) ([]byte, string) {
 	// set 'Background' flag to 'true' if not specified
 	defaultVal := true
@@ -258,6 +270,8 @@ func GeneratePodControllerRule(policy kyverno.ClusterPolicy, log logr.Logger) (p
 // - "none" if:
 //          - name or selector is defined
 //          - mixed kinds (Pod + pod controller) is defined
+//          - the wildcard kind "*" is defined
+//          - any/all resource filters are defined
 //          - mutate.Patches/mutate.PatchesJSON6902/validate.deny/generate rule is defined
 // - otherwise it returns all pod controllers
 func CanAutoGen(policy *kyverno.ClusterPolicy, log logr.Logger) (applyAutoGen bool, controllers string) {
@@ -271,8 +285,18 @@ func CanAutoGen(policy *kyverno.ClusterPolicy, log logr.Logger) (applyAutoGen bo
 			return false, "none"
 		}
 
-		if (len(match.Kinds) > 1 && utils.ContainsString(match.Kinds, "Pod")) ||
-			(len(exclude.Kinds) > 1 && utils.ContainsString(exclude.Kinds, "Pod")) {
+		if (len(match.Kinds) > 1 && utils.ContainsKind(match.Kinds, "Pod")) ||
+			(len(exclude.Kinds) > 1 && utils.ContainsKind(exclude.Kinds, "Pod")) {
+			return false, "none"
+		}
+
+		if match.HasAnyAll() || exclude.HasAnyAll() {
+			log.V(3).Info("skip generating rule on pod controllers: any/all resource filters may not be applicable.", "rule", rule.Name)
+			return false, "none"
+		}
+
+		if utils.ContainsString(match.Kinds, "*") || utils.ContainsString(exclude.Kinds, "*") {
+			log.V(3).Info("skip generating rule on pod controllers: wildcard kind already matches pod controllers.", "rule", rule.Name)
 			return false, "none"
 		}
 
@@ -434,8 +458,8 @@ func generateRuleForControllers(rule kyverno.Rule, controllers string, log logr.
 
 	match := rule.MatchResources
 	exclude := rule.ExcludeResources
-	if !utils.ContainsString(match.ResourceDescription.Kinds, "Pod") ||
-		(len(exclude.ResourceDescription.Kinds) != 0 && !utils.ContainsString(exclude.ResourceDescription.Kinds, "Pod")) {
+	if !utils.ContainsKind(match.ResourceDescription.Kinds, "Pod") ||
+		(len(exclude.ResourceDescription.Kinds) != 0 && !utils.ContainsKind(exclude.ResourceDescription.Kinds, "Pod")) {
 		return kyvernoRule{}
 	}
 
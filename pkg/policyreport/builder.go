@@ -3,11 +3,13 @@ package policyreport
 import (
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/go-logr/logr"
 	kyverno "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
 	request "github.com/kyverno/kyverno/pkg/api/kyverno/v1alpha1"
-	report "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha1"
+	reportv1alpha1 "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha1"
+	report "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha2"
 	kyvernolister "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine/response"
@@ -80,23 +82,14 @@ func NewBuilder(cpolLister kyvernolister.ClusterPolicyLister, polLister kyvernol
 	return &requestBuilder{cpolLister: cpolLister, polLister: polLister}
 }
 
+// build builds the report change request of info, the v1alpha1 requests keep the v1alpha2 fields
+// of the results in their data so that the report controller can restore them
 func (builder *requestBuilder) build(info Info) (req *unstructured.Unstructured, err error) {
-	results := []*report.PolicyReportResult{}
-	for _, infoResult := range info.Results {
-		for _, rule := range infoResult.Rules {
-			if rule.Type != utils.Validation.String() {
-				continue
-			}
-
-			result := builder.buildRCRResult(info.PolicyName, infoResult.Resource, rule)
-			results = append(results, result)
-		}
-	}
-
+	results := builder.buildResults(info)
 	if info.Namespace != "" {
 		rr := &request.ReportChangeRequest{
-			Summary: calculateSummary(results),
-			Results: results,
+			Summary: reportv1alpha1.PolicyReportSummary(calculateSummary(results)),
+			Results: reportv1alpha1.ConvertResultsFrom(results),
 		}
 
 		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(rr)
@@ -108,8 +101,8 @@ func (builder *requestBuilder) build(info Info) (req *unstructured.Unstructured,
 		set(req, info)
 	} else {
 		rr := &request.ClusterReportChangeRequest{
-			Summary: calculateSummary(results),
-			Results: results,
+			Summary: reportv1alpha1.PolicyReportSummary(calculateSummary(results)),
+			Results: reportv1alpha1.ConvertResultsFrom(results),
 		}
 
 		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(rr)
@@ -131,10 +124,32 @@ func (builder *requestBuilder) build(info Info) (req *unstructured.Unstructured,
 	return req, nil
 }
 
-func (builder *requestBuilder) buildRCRResult(policy string, resource response.ResourceSpec, rule kyverno.ViolatedRule) *report.PolicyReportResult {
+// buildResults builds the policy report results of the rules in info
+func (builder *requestBuilder) buildResults(info Info) []*report.PolicyReportResult {
+	results := []*report.PolicyReportResult{}
+	for _, infoResult := range info.Results {
+		for _, rule := range infoResult.Rules {
+			var action string
+			if rule.Type == utils.Validation.String() {
+				action = rule.ValidationFailureAction
+				if action == "" {
+					action = infoResult.ValidationFailureAction
+				}
+			}
+
+			result := builder.buildRCRResult(info.PolicyName, infoResult.Resource, rule, action)
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
+
+func (builder *requestBuilder) buildRCRResult(policy string, resource response.ResourceSpec, rule kyverno.ViolatedRule, validationFailureAction string) *report.PolicyReportResult {
 	av := builder.fetchAnnotationValues(policy, resource.Namespace)
 
 	result := &report.PolicyReportResult{
+		Source: SourceKyverno,
 		Policy: policy,
 		Resources: []*v1.ObjectReference{
 			{
@@ -151,10 +166,15 @@ func (builder *requestBuilder) buildRCRResult(policy string, resource response.R
 	}
 
 	result.Rule = rule.Name
+	result.Type = RuleType(rule.Type)
 	result.Message = rule.Message
-	result.Status = report.PolicyStatus(rule.Check)
-	if result.Status == "fail" && !av.scored {
-		result.Status = "warn"
+	result.Timestamp = metav1.Timestamp{Seconds: time.Now().Unix()}
+	result.Result = report.PolicyResult(rule.Check)
+	if result.Result == report.StatusFail && !av.scored {
+		result.Result = report.StatusWarn
+	}
+	if validationFailureAction != "" {
+		result.Properties = map[string]string{ValidationFailureActionKey: validationFailureAction}
 	}
 	return result
 }
@@ -212,7 +232,7 @@ func setRequestLabels(req *unstructured.Unstructured, info Info) bool {
 
 func calculateSummary(results []*report.PolicyReportResult) (summary report.PolicyReportSummary) {
 	for _, res := range results {
-		switch string(res.Status) {
+		switch string(res.Result) {
 		case report.StatusPass:
 			summary.Pass++
 		case report.StatusFail:
@@ -234,8 +254,9 @@ func buildPVInfo(er *response.EngineResponse) Info {
 		Namespace:  er.PatchedResource.GetNamespace(),
 		Results: []EngineResponseResult{
 			{
-				Resource: er.GetResourceSpec(),
-				Rules:    buildViolatedRules(er),
+				Resource:                er.GetResourceSpec(),
+				Rules:                   buildViolatedRules(er),
+				ValidationFailureAction: er.PolicyResponse.ValidationFailureAction,
 			},
 		},
 	}
@@ -246,19 +267,50 @@ func buildViolatedRules(er *response.EngineResponse) []kyverno.ViolatedRule {
 	var violatedRules []kyverno.ViolatedRule
 	for _, rule := range er.PolicyResponse.Rules {
 		vrule := kyverno.ViolatedRule{
-			Name:    rule.Name,
-			Type:    rule.Type,
-			Message: rule.Message,
-		}
-		vrule.Check = report.StatusFail
-		if rule.Success {
-			vrule.Check = report.StatusPass
+			Name:                    rule.Name,
+			Type:                    rule.Type,
+			Message:                 rule.Message,
+			ValidationFailureAction: rule.ValidationFailureAction,
 		}
+		vrule.Check = string(rule.GetStatus())
 		violatedRules = append(violatedRules, vrule)
 	}
 	return violatedRules
 }
 
+// ValidationFailureActionKey is the key of the result properties which stores the
+// validation failure action applied to the resource
+const ValidationFailureActionKey string = "validationFailureAction"
+
+// SourceKyverno is the source of the policy report results generated by Kyverno
+const SourceKyverno string = "Kyverno"
+
+// RuleType converts the rule type of an engine response to the policy report rule type
+func RuleType(engineRuleType string) report.PolicyRuleType {
+	switch engineRuleType {
+	case utils.Mutation.String():
+		return report.RuleTypeMutate
+	case utils.Generation.String():
+		return report.RuleTypeGenerate
+	default:
+		return report.RuleTypeValidate
+	}
+}
+
+// GetRuleType returns the policy report rule type of a policy rule
+func GetRuleType(rule kyverno.Rule) report.PolicyRuleType {
+	switch {
+	case rule.HasMutate():
+		return report.RuleTypeMutate
+	case rule.HasGenerate():
+		return report.RuleTypeGenerate
+	case rule.HasVerifyImages():
+		return report.RuleTypeVerifyImages
+	default:
+		return report.RuleTypeValidate
+	}
+}
+
 const categoryLabel string = "policies.kyverno.io/category"
 const severityLabel string = "policies.kyverno.io/severity"
 const scoredLabel string = "policies.kyverno.io/scored"
@@ -271,12 +323,16 @@ type annotationValues struct {
 
 func (av *annotationValues) setSeverityFromString(severity string) {
 	switch severity {
+	case report.SeverityCritical:
+		av.severity = report.SeverityCritical
 	case report.SeverityHigh:
 		av.severity = report.SeverityHigh
 	case report.SeverityMedium:
 		av.severity = report.SeverityMedium
 	case report.SeverityLow:
 		av.severity = report.SeverityLow
+	case report.SeverityInfo:
+		av.severity = report.SeverityInfo
 	}
 }
 
@@ -0,0 +1,72 @@
+package policyreport
+
+import (
+	"testing"
+
+	reportv1alpha1 "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha1"
+	report "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_buildViolatedRules(t *testing.T) {
+	er := &response.EngineResponse{
+		PolicyResponse: response.PolicyResponse{
+			Rules: []response.RuleResponse{
+				{Name: "validate-pass", Type: "Validation", Success: true},
+				{Name: "validate-fail", Type: "Validation", Success: false},
+				{Name: "mutate-error", Type: "Mutation", Success: false, Status: response.RuleStatusError},
+				{Name: "mutate-skip", Type: "Mutation", Success: true, Status: response.RuleStatusSkip},
+				{Name: "generate-pass", Type: "Generation", Success: true, Status: response.RuleStatusPass},
+			},
+		},
+	}
+
+	expected := map[string]string{
+		"validate-pass": report.StatusPass,
+		"validate-fail": report.StatusFail,
+		"mutate-error":  report.StatusError,
+		"mutate-skip":   report.StatusSkip,
+		"generate-pass": report.StatusPass,
+	}
+
+	rules := buildViolatedRules(er)
+	assert.Equal(t, len(rules), len(expected))
+	for _, rule := range rules {
+		assert.Equal(t, rule.Check, expected[rule.Name], rule.Name)
+	}
+
+	assert.Equal(t, RuleType("Mutation"), report.PolicyRuleType(report.RuleTypeMutate))
+	assert.Equal(t, RuleType("Generation"), report.PolicyRuleType(report.RuleTypeGenerate))
+}
+
+func Test_ConvertResults_RoundTrip(t *testing.T) {
+	results := []*report.PolicyReportResult{
+		{
+			Source:     SourceKyverno,
+			Policy:     "require-labels",
+			Rule:       "check-team",
+			Type:       report.RuleTypeValidate,
+			Severity:   report.SeverityCritical,
+			Timestamp:  metav1.Timestamp{Seconds: 1634567890, Nanos: 42},
+			Result:     report.StatusFail,
+			Message:    "label team is required",
+			Properties: map[string]string{ValidationFailureActionKey: "audit"},
+		},
+		{
+			Policy: "add-labels",
+			Rule:   "add-team",
+			Type:   report.RuleTypeMutate,
+			Result: report.StatusPass,
+		},
+	}
+
+	// the report change requests are v1alpha1, the results they carry are restored by the report controller
+	requestResults := reportv1alpha1.ConvertResultsFrom(results)
+	assert.Equal(t, string(requestResults[0].Severity), reportv1alpha1.SeverityHigh)
+	assert.Equal(t, string(requestResults[0].Status), reportv1alpha1.StatusFail)
+	assert.Equal(t, requestResults[0].Data[ValidationFailureActionKey], "audit")
+
+	assert.DeepEqual(t, reportv1alpha1.ConvertResultsTo(requestResults), results)
+}
@@ -0,0 +1,30 @@
+package policyreport
+
+import (
+	"net/http"
+
+	reportv1alpha1 "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha1"
+	reportv1alpha2 "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+)
+
+// NewConversionHandler returns the handler of the conversion webhook
+// that converts (Cluster)PolicyReports between the served versions
+func NewConversionHandler() (http.Handler, error) {
+	scheme := runtime.NewScheme()
+	if err := reportv1alpha1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	if err := reportv1alpha2.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	webhook := &conversion.Webhook{}
+	if err := webhook.InjectScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
@@ -23,12 +23,13 @@ import (
 	"k8s.io/client-go/util/workqueue"
 
 	changerequest "github.com/kyverno/kyverno/pkg/api/kyverno/v1alpha1"
-	report "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha1"
+	reportv1alpha1 "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha1"
+	report "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha2"
 	kyvernoclient "github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	requestinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1alpha1"
-	policyreportinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions/policyreport/v1alpha1"
+	policyreportinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions/policyreport/v1alpha2"
 	requestlister "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1alpha1"
-	policyreport "github.com/kyverno/kyverno/pkg/client/listers/policyreport/v1alpha1"
+	policyreport "github.com/kyverno/kyverno/pkg/client/listers/policyreport/v1alpha2"
 	"github.com/kyverno/kyverno/pkg/config"
 	dclient "github.com/kyverno/kyverno/pkg/dclient"
 )
@@ -502,7 +503,7 @@ func mergeRequests(ns *v1.Namespace, requestsGeneral interface{}) (*unstructured
 				continue
 			}
 			if len(request.Results) != 0 {
-				results = append(results, request.Results...)
+				results = append(results, reportv1alpha1.ConvertResultsTo(request.Results)...)
 			}
 			aggregatedRequests = append(aggregatedRequests, request)
 		}
@@ -529,7 +530,7 @@ func mergeRequests(ns *v1.Namespace, requestsGeneral interface{}) (*unstructured
 				continue
 			}
 			if len(request.Results) != 0 {
-				results = append(results, request.Results...)
+				results = append(results, reportv1alpha1.ConvertResultsTo(request.Results)...)
 			}
 			aggregatedRequests = append(aggregatedRequests, request)
 		}
@@ -130,8 +130,9 @@ type Info struct {
 }
 
 type EngineResponseResult struct {
-	Resource response.ResourceSpec
-	Rules    []kyverno.ViolatedRule
+	Resource                response.ResourceSpec
+	Rules                   []kyverno.ViolatedRule
+	ValidationFailureAction string
 }
 
 func (i Info) ToKey() string {
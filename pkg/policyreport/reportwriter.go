@@ -0,0 +1,540 @@
+package policyreport
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	report "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha2"
+	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	policyreportinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions/policyreport/v1alpha2"
+	policyreport "github.com/kyverno/kyverno/pkg/client/listers/policyreport/v1alpha2"
+	dclient "github.com/kyverno/kyverno/pkg/dclient"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	informers "k8s.io/client-go/informers/core/v1"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// timestampRefreshInterval is the age after which the timestamp of a result observed again is refreshed,
+// the reports are not written for a result that only differs by a more recent timestamp
+const timestampRefreshInterval = 10 * time.Minute
+
+// ReportWriter aggregates the policy report results in memory and periodically writes
+// the changed (Cluster)PolicyReports, instead of creating report change requests.
+// The pending changes are applied on top of the reports in the informer cache, so a
+// new instance rebuilds its state from the existing reports.
+// Only the leader writes the reports, the other replicas pass the results to the fallback
+// generator which creates report change requests merged into the reports by the leader
+type ReportWriter struct {
+	dclient *dclient.Client
+
+	reportLister policyreport.PolicyReportLister
+	reportSynced cache.InformerSynced
+
+	clusterReportLister policyreport.ClusterPolicyReportLister
+	clusterReportSynced cache.InformerSynced
+
+	nsLister       listerv1.NamespaceLister
+	nsListerSynced cache.InformerSynced
+
+	cpolListerSynced cache.InformerSynced
+	polListerSynced  cache.InformerSynced
+
+	builder *requestBuilder
+
+	fallback GeneratorInterface
+
+	// leading is set while the writer runs on the leader
+	leading int32
+
+	flushInterval time.Duration
+
+	mutex sync.Mutex
+
+	// changes holds the pending changes keyed by the report namespace, "" for the cluster report
+	changes map[string]*reportChanges
+
+	// deletedPolicies holds the pending policy and rule deletions which apply to all reports
+	deletedPolicies []deletedPolicy
+
+	log logr.Logger
+}
+
+type deletedPolicy struct {
+	policy, rule string
+}
+
+// replacedResource is a resource evaluated again by a policy, the new results of the
+// policy replace all of its previous results for the resource
+type replacedResource struct {
+	policy   string
+	resource deletedResource
+}
+
+// reportChanges are the changes of a single report, deletions are applied before the results
+type reportChanges struct {
+	// results to add or replace, keyed by policy, rule and resource
+	results map[string]*report.PolicyReportResult
+
+	// replacedResources are the policy and resource pairs whose results which are not
+	// in results are removed, e.g. the rules which no longer match the resource
+	replacedResources []replacedResource
+
+	deletedResources []deletedResource
+	deletedPolicies  []deletedPolicy
+}
+
+func newReportChanges() *reportChanges {
+	return &reportChanges{
+		results: make(map[string]*report.PolicyReportResult),
+	}
+}
+
+// NewReportWriter returns a new instance of the batched policy report writer
+func NewReportWriter(dclient *dclient.Client,
+	clusterReportInformer policyreportinformer.ClusterPolicyReportInformer,
+	reportInformer policyreportinformer.PolicyReportInformer,
+	namespace informers.NamespaceInformer,
+	cpolInformer kyvernoinformer.ClusterPolicyInformer,
+	polInformer kyvernoinformer.PolicyInformer,
+	fallback GeneratorInterface,
+	flushInterval time.Duration,
+	log logr.Logger) *ReportWriter {
+	return &ReportWriter{
+		dclient:             dclient,
+		reportLister:        reportInformer.Lister(),
+		reportSynced:        reportInformer.Informer().HasSynced,
+		clusterReportLister: clusterReportInformer.Lister(),
+		clusterReportSynced: clusterReportInformer.Informer().HasSynced,
+		nsLister:            namespace.Lister(),
+		nsListerSynced:      namespace.Informer().HasSynced,
+		cpolListerSynced:    cpolInformer.Informer().HasSynced,
+		polListerSynced:     polInformer.Informer().HasSynced,
+		builder:             &requestBuilder{cpolLister: cpolInformer.Lister(), polLister: polInformer.Lister()},
+		fallback:            fallback,
+		flushInterval:       flushInterval,
+		changes:             make(map[string]*reportChanges),
+		log:                 log,
+	}
+}
+
+// Add records the policy application results to be written to the reports,
+// or passes them to the fallback generator if the writer does not run on the leader
+func (w *ReportWriter) Add(infos ...Info) {
+	if atomic.LoadInt32(&w.leading) == 0 && w.fallback != nil {
+		w.fallback.Add(infos...)
+		return
+	}
+
+	for _, info := range infos {
+		switch {
+		case isResourceDeletion(info):
+			resource := info.Results[0].Resource
+			w.deleteResource(resource.Namespace, deletedResource{kind: resource.Kind, ns: resource.Namespace, name: resource.Name})
+		case isPolicyDeletion(info):
+			w.deletePolicy(deletedPolicy{policy: info.PolicyName})
+		case isRuleDeletion(info):
+			w.deletePolicy(deletedPolicy{policy: info.PolicyName, rule: info.Results[0].Rules[0].Name})
+		default:
+			w.addResults(info.Namespace, replacedResources(info), w.builder.buildResults(info))
+		}
+	}
+}
+
+// replacedResources returns the policy and resource pairs evaluated in info
+func replacedResources(info Info) []replacedResource {
+	replaced := make([]replacedResource, 0, len(info.Results))
+	for _, result := range info.Results {
+		resource := result.Resource
+		replaced = append(replaced, replacedResource{
+			policy:   info.PolicyName,
+			resource: deletedResource{kind: resource.Kind, ns: resource.Namespace, name: resource.Name},
+		})
+	}
+
+	return replaced
+}
+
+func (w *ReportWriter) addResults(namespace string, replaced []replacedResource, results []*report.PolicyReportResult) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	changes := w.changesFor(namespace)
+	for key, result := range changes.results {
+		if matchesAnyReplaced(result, replaced) {
+			delete(changes.results, key)
+		}
+	}
+
+	changes.replacedResources = append(changes.replacedResources, replaced...)
+	for _, result := range results {
+		changes.results[resultKey(result)] = result
+	}
+}
+
+func (w *ReportWriter) deleteResource(namespace string, resource deletedResource) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	changes := w.changesFor(namespace)
+	for key, result := range changes.results {
+		if matchesResource(result, resource) {
+			delete(changes.results, key)
+		}
+	}
+
+	changes.deletedResources = append(changes.deletedResources, resource)
+}
+
+func (w *ReportWriter) deletePolicy(policy deletedPolicy) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for _, changes := range w.changes {
+		for key, result := range changes.results {
+			if matchesPolicy(result, policy) {
+				delete(changes.results, key)
+			}
+		}
+	}
+
+	w.deletedPolicies = append(w.deletedPolicies, policy)
+}
+
+func (w *ReportWriter) changesFor(namespace string) *reportChanges {
+	changes, ok := w.changes[namespace]
+	if !ok {
+		changes = newReportChanges()
+		w.changes[namespace] = changes
+	}
+
+	return changes
+}
+
+// restore re-queues the changes of a report that failed to be written,
+// the changes recorded in the meantime take precedence
+func (w *ReportWriter) restore(namespace string, old *reportChanges) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	changes := w.changesFor(namespace)
+	for key, result := range old.results {
+		if _, ok := changes.results[key]; ok {
+			continue
+		}
+
+		if changes.deletes(result) || changes.replaces(result) || matchesAnyPolicy(result, w.deletedPolicies) {
+			continue
+		}
+
+		changes.results[key] = result
+	}
+
+	changes.replacedResources = append(old.replacedResources, changes.replacedResources...)
+	changes.deletedResources = append(old.deletedResources, changes.deletedResources...)
+	changes.deletedPolicies = append(old.deletedPolicies, changes.deletedPolicies...)
+}
+
+// Run waits for the informer caches to be synced and writes the pending changes every flush interval,
+// it is started by the leader
+func (w *ReportWriter) Run(stopCh <-chan struct{}) {
+	logger := w.log
+	defer utilruntime.HandleCrash()
+
+	logger.Info("start")
+	defer logger.Info("shutting down")
+
+	atomic.StoreInt32(&w.leading, 1)
+	defer atomic.StoreInt32(&w.leading, 0)
+
+	if !cache.WaitForCacheSync(stopCh, w.reportSynced, w.clusterReportSynced, w.nsListerSynced, w.cpolListerSynced, w.polListerSynced) {
+		logger.Info("failed to sync informer cache")
+	}
+
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.flush()
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// flush writes the pending changes to the reports
+func (w *ReportWriter) flush() {
+	w.mutex.Lock()
+	changes, deletedPolicies := w.changes, w.deletedPolicies
+	w.changes = make(map[string]*reportChanges)
+	w.deletedPolicies = nil
+	w.mutex.Unlock()
+
+	if len(deletedPolicies) != 0 {
+		namespaces, err := w.reportNamespaces()
+		if err != nil {
+			w.log.Error(err, "failed to list policy reports")
+		}
+
+		for _, namespace := range namespaces {
+			if _, ok := changes[namespace]; !ok {
+				changes[namespace] = newReportChanges()
+			}
+		}
+
+		for _, c := range changes {
+			c.deletedPolicies = append(c.deletedPolicies, deletedPolicies...)
+		}
+	}
+
+	for namespace, c := range changes {
+		if err := w.writeReport(namespace, c); err != nil {
+			w.log.V(3).Info("failed to write policy report, retrying", "namespace", namespace, "error", err.Error())
+			w.restore(namespace, c)
+		}
+	}
+}
+
+// reportNamespaces returns the namespaces of the existing reports, "" for the cluster report
+func (w *ReportWriter) reportNamespaces() ([]string, error) {
+	var namespaces []string
+	if _, err := w.clusterReportLister.Get(generatePolicyReportName("")); err == nil {
+		namespaces = append(namespaces, "")
+	}
+
+	polrs, err := w.reportLister.List(labels.Everything())
+	if err != nil {
+		return namespaces, err
+	}
+
+	for _, polr := range polrs {
+		if polr.GetName() == generatePolicyReportName(polr.GetNamespace()) {
+			namespaces = append(namespaces, polr.GetNamespace())
+		}
+	}
+
+	return namespaces, nil
+}
+
+// writeReport applies the changes to the report of the namespace, and creates or patches the
+// report if its results changed. The patch fails if the report was modified in the meantime
+func (w *ReportWriter) writeReport(namespace string, changes *reportChanges) error {
+	var ns *v1.Namespace
+	var kind, resourceVersion string
+	var old []*report.PolicyReportResult
+	var exists bool
+	var err error
+
+	name := generatePolicyReportName(namespace)
+	if namespace != "" {
+		kind = "PolicyReport"
+		ns, err = w.nsLister.Get(namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to fetch namespace: %v", err)
+		}
+
+		if ns.GetDeletionTimestamp() != nil {
+			return nil
+		}
+
+		polr, err := w.reportLister.PolicyReports(namespace).Get(name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("unable to get policyReport: %v", err)
+		}
+
+		if err == nil {
+			exists, old, resourceVersion = true, polr.Results, polr.GetResourceVersion()
+		}
+	} else {
+		kind = "ClusterPolicyReport"
+		cpolr, err := w.clusterReportLister.Get(name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("unable to get clusterPolicyReport: %v", err)
+		}
+
+		if err == nil {
+			exists, old, resourceVersion = true, cpolr.Results, cpolr.GetResourceVersion()
+		}
+	}
+
+	results, changed := applyChanges(old, changes)
+	if !changed {
+		w.log.V(4).Info("unchanged policy report", "kind", kind, "namespace", namespace, "name", name)
+		return nil
+	}
+
+	if !exists {
+		return w.createReport(ns, results)
+	}
+
+	patch, err := json.Marshal([]map[string]interface{}{
+		{"op": "test", "path": "/metadata/resourceVersion", "value": resourceVersion},
+		{"op": "add", "path": "/results", "value": results},
+		{"op": "add", "path": "/summary", "value": calculateSummary(results)},
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.dclient.PatchResource(report.SchemeGroupVersion.String(), kind, namespace, name, patch); err != nil {
+		return fmt.Errorf("failed to patch %s: %v", kind, err)
+	}
+
+	w.log.V(3).Info("successfully updated policy report", "kind", kind, "namespace", namespace, "name", name)
+	return nil
+}
+
+func (w *ReportWriter) createReport(ns *v1.Namespace, results []*report.PolicyReportResult) error {
+	var obj map[string]interface{}
+	var err error
+	if ns == nil {
+		obj, err = runtime.DefaultUnstructuredConverter.ToUnstructured(&report.ClusterPolicyReport{Results: results, Summary: calculateSummary(results)})
+	} else {
+		obj, err = runtime.DefaultUnstructuredConverter.ToUnstructured(&report.PolicyReport{Results: results, Summary: calculateSummary(results)})
+	}
+	if err != nil {
+		return err
+	}
+
+	new := &unstructured.Unstructured{Object: obj}
+	setReport(new, ns)
+	if _, err := w.dclient.CreateResource(new.GetAPIVersion(), new.GetKind(), new.GetNamespace(), new, false); err != nil {
+		return fmt.Errorf("failed to create %s: %v", new.GetKind(), err)
+	}
+
+	w.log.V(2).Info("successfully created policy report", "kind", new.GetKind(), "namespace", new.GetNamespace(), "name", new.GetName())
+	return nil
+}
+
+// applyChanges returns the results with the changes applied and whether they differ from the
+// given results, a result which only differs by its timestamp is replaced once the timestamp is stale.
+// The results of a replaced policy and resource pair which are not in the changes are removed
+func applyChanges(results []*report.PolicyReportResult, changes *reportChanges) ([]*report.PolicyReportResult, bool) {
+	changed := false
+	merged := make([]*report.PolicyReportResult, 0, len(results)+len(changes.results))
+	index := make(map[string]int, len(results))
+	for _, result := range results {
+		if changes.deletes(result) {
+			changed = true
+			continue
+		}
+
+		if _, ok := changes.results[resultKey(result)]; !ok && changes.replaces(result) {
+			changed = true
+			continue
+		}
+
+		index[resultKey(result)] = len(merged)
+		merged = append(merged, result)
+	}
+
+	keys := make([]string, 0, len(changes.results))
+	for key := range changes.results {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		result := changes.results[key]
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(merged)
+			merged = append(merged, result)
+			changed = true
+			continue
+		}
+
+		if equalResults(merged[i], result) && !staleTimestamp(merged[i], result) {
+			continue
+		}
+
+		merged[i] = result
+		changed = true
+	}
+
+	return merged, changed
+}
+
+func (c *reportChanges) deletes(result *report.PolicyReportResult) bool {
+	for _, resource := range c.deletedResources {
+		if matchesResource(result, resource) {
+			return true
+		}
+	}
+
+	return matchesAnyPolicy(result, c.deletedPolicies)
+}
+
+func (c *reportChanges) replaces(result *report.PolicyReportResult) bool {
+	return matchesAnyReplaced(result, c.replacedResources)
+}
+
+func resultKey(result *report.PolicyReportResult) string {
+	if len(result.Resources) == 0 || result.Resources[0] == nil {
+		return fmt.Sprintf("%s/%s", result.Policy, result.Rule)
+	}
+
+	resource := result.Resources[0]
+	return fmt.Sprintf("%s/%s/%s/%s/%s", result.Policy, result.Rule, resource.Kind, resource.Namespace, resource.Name)
+}
+
+func matchesResource(result *report.PolicyReportResult, resource deletedResource) bool {
+	if len(result.Resources) == 0 || result.Resources[0] == nil {
+		return false
+	}
+
+	r := result.Resources[0]
+	return r.Kind == resource.kind && r.Namespace == resource.ns && r.Name == resource.name
+}
+
+func matchesAnyPolicy(result *report.PolicyReportResult, policies []deletedPolicy) bool {
+	for _, policy := range policies {
+		if matchesPolicy(result, policy) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesAnyReplaced(result *report.PolicyReportResult, replaced []replacedResource) bool {
+	for _, r := range replaced {
+		if result.Policy == r.policy && matchesResource(result, r.resource) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesPolicy(result *report.PolicyReportResult, policy deletedPolicy) bool {
+	return result.Policy == policy.policy && (policy.rule == "" || result.Rule == policy.rule)
+}
+
+// equalResults compares two results ignoring their timestamps
+func equalResults(old, new *report.PolicyReportResult) bool {
+	compared := *new
+	compared.Timestamp = old.Timestamp
+	return reflect.DeepEqual(*old, compared)
+}
+
+// staleTimestamp checks if the result was observed again at least timestampRefreshInterval after its timestamp
+func staleTimestamp(old, new *report.PolicyReportResult) bool {
+	return new.Timestamp.Seconds-old.Timestamp.Seconds >= int64(timestampRefreshInterval/time.Second)
+}
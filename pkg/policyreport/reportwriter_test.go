@@ -0,0 +1,206 @@
+package policyreport
+
+import (
+	"testing"
+	"time"
+
+	report "github.com/kyverno/kyverno/pkg/api/policyreport/v1alpha2"
+	"gotest.tools/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newResult(policy, rule, name string, result report.PolicyResult, seconds int64) *report.PolicyReportResult {
+	return &report.PolicyReportResult{
+		Policy:    policy,
+		Rule:      rule,
+		Result:    result,
+		Timestamp: metav1.Timestamp{Seconds: seconds},
+		Resources: []*v1.ObjectReference{
+			{Kind: "Pod", Namespace: "test", Name: name},
+		},
+	}
+}
+
+func Test_applyChanges(t *testing.T) {
+	existing := []*report.PolicyReportResult{
+		newResult("pol1", "rule1", "pod1", report.StatusPass, 1),
+		newResult("pol1", "rule1", "pod2", report.StatusFail, 1),
+		newResult("pol2", "rule1", "pod1", report.StatusPass, 1),
+		newResult("pol2", "rule2", "pod1", report.StatusFail, 1),
+	}
+
+	testcases := []struct {
+		name     string
+		changes  func(c *reportChanges)
+		expected []string
+		changed  bool
+	}{
+		{
+			name:     "no changes",
+			changes:  func(c *reportChanges) {},
+			expected: []string{"pol1/rule1/Pod/test/pod1", "pol1/rule1/Pod/test/pod2", "pol2/rule1/Pod/test/pod1", "pol2/rule2/Pod/test/pod1"},
+			changed:  false,
+		},
+		{
+			name: "only timestamp changed",
+			changes: func(c *reportChanges) {
+				r := newResult("pol1", "rule1", "pod1", report.StatusPass, 2)
+				c.results[resultKey(r)] = r
+			},
+			expected: []string{"pol1/rule1/Pod/test/pod1", "pol1/rule1/Pod/test/pod2", "pol2/rule1/Pod/test/pod1", "pol2/rule2/Pod/test/pod1"},
+			changed:  false,
+		},
+		{
+			name: "stale timestamp refreshed",
+			changes: func(c *reportChanges) {
+				r := newResult("pol1", "rule1", "pod1", report.StatusPass, 1+int64(timestampRefreshInterval/time.Second))
+				c.results[resultKey(r)] = r
+			},
+			expected: []string{"pol1/rule1/Pod/test/pod1", "pol1/rule1/Pod/test/pod2", "pol2/rule1/Pod/test/pod1", "pol2/rule2/Pod/test/pod1"},
+			changed:  true,
+		},
+		{
+			name: "result changed and added",
+			changes: func(c *reportChanges) {
+				r := newResult("pol1", "rule1", "pod2", report.StatusPass, 2)
+				c.results[resultKey(r)] = r
+				r = newResult("pol1", "rule1", "pod3", report.StatusPass, 2)
+				c.results[resultKey(r)] = r
+			},
+			expected: []string{"pol1/rule1/Pod/test/pod1", "pol1/rule1/Pod/test/pod2", "pol2/rule1/Pod/test/pod1", "pol2/rule2/Pod/test/pod1", "pol1/rule1/Pod/test/pod3"},
+			changed:  true,
+		},
+		{
+			name: "resource deleted",
+			changes: func(c *reportChanges) {
+				c.deletedResources = append(c.deletedResources, deletedResource{kind: "Pod", ns: "test", name: "pod1"})
+			},
+			expected: []string{"pol1/rule1/Pod/test/pod2"},
+			changed:  true,
+		},
+		{
+			name: "policy deleted and re-added",
+			changes: func(c *reportChanges) {
+				c.deletedPolicies = append(c.deletedPolicies, deletedPolicy{policy: "pol1"})
+				r := newResult("pol1", "rule1", "pod1", report.StatusPass, 2)
+				c.results[resultKey(r)] = r
+			},
+			expected: []string{"pol2/rule1/Pod/test/pod1", "pol2/rule2/Pod/test/pod1", "pol1/rule1/Pod/test/pod1"},
+			changed:  true,
+		},
+		{
+			name: "rule no longer applies to the resource",
+			changes: func(c *reportChanges) {
+				c.replacedResources = append(c.replacedResources, replacedResource{policy: "pol2", resource: deletedResource{kind: "Pod", ns: "test", name: "pod1"}})
+				r := newResult("pol2", "rule1", "pod1", report.StatusPass, 2)
+				c.results[resultKey(r)] = r
+			},
+			expected: []string{"pol1/rule1/Pod/test/pod1", "pol1/rule1/Pod/test/pod2", "pol2/rule1/Pod/test/pod1"},
+			changed:  true,
+		},
+		{
+			name: "replaced with the same results",
+			changes: func(c *reportChanges) {
+				c.replacedResources = append(c.replacedResources, replacedResource{policy: "pol2", resource: deletedResource{kind: "Pod", ns: "test", name: "pod1"}})
+				for _, r := range []*report.PolicyReportResult{
+					newResult("pol2", "rule1", "pod1", report.StatusPass, 2),
+					newResult("pol2", "rule2", "pod1", report.StatusFail, 2),
+				} {
+					c.results[resultKey(r)] = r
+				}
+			},
+			expected: []string{"pol1/rule1/Pod/test/pod1", "pol1/rule1/Pod/test/pod2", "pol2/rule1/Pod/test/pod1", "pol2/rule2/Pod/test/pod1"},
+			changed:  false,
+		},
+	}
+
+	for _, tc := range testcases {
+		changes := newReportChanges()
+		tc.changes(changes)
+
+		results, changed := applyChanges(existing, changes)
+		assert.Equal(t, changed, tc.changed, tc.name)
+
+		var keys []string
+		for _, result := range results {
+			keys = append(keys, resultKey(result))
+		}
+		assert.DeepEqual(t, keys, tc.expected)
+	}
+}
+
+func Test_ReportWriter_restore(t *testing.T) {
+	w := &ReportWriter{changes: make(map[string]*reportChanges)}
+
+	failed := newReportChanges()
+	for _, r := range []*report.PolicyReportResult{
+		newResult("pol1", "rule1", "pod1", report.StatusFail, 1),
+		newResult("pol1", "rule1", "pod2", report.StatusFail, 1),
+		newResult("pol2", "rule1", "pod1", report.StatusFail, 1),
+	} {
+		failed.results[resultKey(r)] = r
+	}
+
+	// changes recorded while the failed changes were written
+	w.addResults("test", nil, []*report.PolicyReportResult{newResult("pol1", "rule1", "pod1", report.StatusPass, 2)})
+	w.deleteResource("test", deletedResource{kind: "Pod", ns: "test", name: "pod2"})
+	w.deletePolicy(deletedPolicy{policy: "pol2"})
+
+	w.restore("test", failed)
+
+	changes := w.changes["test"]
+	assert.Equal(t, len(changes.results), 1)
+	assert.Equal(t, changes.results["pol1/rule1/Pod/test/pod1"].Result, report.PolicyResult(report.StatusPass))
+	assert.Equal(t, len(changes.deletedResources), 1)
+}
+
+func Test_ReportWriter_addResults_replaced(t *testing.T) {
+	w := &ReportWriter{changes: make(map[string]*reportChanges)}
+	replaced := []replacedResource{{policy: "pol1", resource: deletedResource{kind: "Pod", ns: "test", name: "pod1"}}}
+
+	w.addResults("test", replaced, []*report.PolicyReportResult{
+		newResult("pol1", "rule1", "pod1", report.StatusFail, 1),
+		newResult("pol1", "rule2", "pod1", report.StatusFail, 1),
+	})
+	w.addResults("test", nil, []*report.PolicyReportResult{newResult("pol2", "rule1", "pod1", report.StatusPass, 1)})
+
+	// the resource was updated and only matches rule1 of pol1
+	w.addResults("test", replaced, []*report.PolicyReportResult{newResult("pol1", "rule1", "pod1", report.StatusPass, 2)})
+
+	changes := w.changes["test"]
+	assert.Equal(t, len(changes.results), 2)
+	assert.Equal(t, changes.results["pol1/rule1/Pod/test/pod1"].Result, report.PolicyResult(report.StatusPass))
+	assert.Assert(t, changes.results["pol2/rule1/Pod/test/pod1"] != nil)
+	assert.Equal(t, len(changes.replacedResources), 2)
+
+	results, changed := applyChanges([]*report.PolicyReportResult{
+		newResult("pol1", "rule1", "pod1", report.StatusFail, 1),
+		newResult("pol1", "rule2", "pod1", report.StatusFail, 1),
+	}, changes)
+	assert.Assert(t, changed)
+
+	var keys []string
+	for _, result := range results {
+		keys = append(keys, resultKey(result))
+	}
+	assert.DeepEqual(t, keys, []string{"pol1/rule1/Pod/test/pod1", "pol2/rule1/Pod/test/pod1"})
+}
+
+type fakeGenerator struct {
+	infos []Info
+}
+
+func (g *fakeGenerator) Add(infos ...Info) {
+	g.infos = append(g.infos, infos...)
+}
+
+func Test_ReportWriter_Add_NotLeading(t *testing.T) {
+	fallback := &fakeGenerator{}
+	w := &ReportWriter{changes: make(map[string]*reportChanges), fallback: fallback}
+
+	// the replicas which are not the leader create report change requests
+	w.Add(Info{PolicyName: "pol1", Namespace: "test"})
+	assert.Equal(t, len(fallback.infos), 1)
+	assert.Equal(t, len(w.changes), 0)
+}
@@ -0,0 +1,102 @@
+package policystatus
+
+import (
+	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Reasons of the policy status conditions
+const (
+	ReasonPolicyReady             = "PolicyReady"
+	ReasonWebhookPending          = "WebhookPending"
+	ReasonWebhookConfigured       = "WebhookConfigured"
+	ReasonWebhookNotConfigured    = "WebhookNotConfigured"
+	ReasonPolicyCachePending      = "PolicyCachePending"
+	ReasonPolicyCached            = "PolicyCached"
+	ReasonBackgroundScanPending   = "BackgroundScanPending"
+	ReasonBackgroundScanCompleted = "BackgroundScanCompleted"
+	ReasonBackgroundScanDisabled  = "BackgroundScanDisabled"
+)
+
+// ConditionUpdate sets a condition of the policy status,
+// the Ready condition is derived from the other conditions
+type ConditionUpdate struct {
+	// Policy is the key of the policy, namespace/name for namespaced policies
+	Policy    string
+	Condition metav1.Condition
+}
+
+// PolicyName returns the key of the policy
+func (u ConditionUpdate) PolicyName() string {
+	return u.Policy
+}
+
+// UpdateStatus sets the condition and the Ready condition of the status
+func (u ConditionUpdate) UpdateStatus(status v1.PolicyStatus) v1.PolicyStatus {
+	// the status may be shared with the informer cache
+	status = *status.DeepCopy()
+	meta.SetStatusCondition(&status.Conditions, u.Condition)
+	setReadyCondition(&status, u.Condition.ObservedGeneration)
+	return status
+}
+
+// setReadyCondition marks the policy as ready once the webhook configurations cover its rules
+// and the policy cache of the admission webhooks serves it. The background scan does not count
+// towards Ready, it only applies the policy to the existing resources.
+func setReadyCondition(status *v1.PolicyStatus, generation int64) {
+	ready := metav1.Condition{
+		Type:               v1.PolicyConditionReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             ReasonWebhookPending,
+		Message:            "waiting for the webhook configurations",
+	}
+
+	webhook := meta.FindStatusCondition(status.Conditions, v1.PolicyConditionWebhookConfigured)
+	cached := meta.FindStatusCondition(status.Conditions, v1.PolicyConditionPolicyCached)
+	switch {
+	case webhook == nil:
+	case webhook.Status != metav1.ConditionTrue:
+		ready.ObservedGeneration = webhook.ObservedGeneration
+		ready.Reason = webhook.Reason
+		ready.Message = webhook.Message
+	case cached == nil:
+		ready.ObservedGeneration = webhook.ObservedGeneration
+		ready.Reason = ReasonPolicyCachePending
+		ready.Message = "waiting for the policy cache"
+	case cached.Status != metav1.ConditionTrue:
+		ready.ObservedGeneration = cached.ObservedGeneration
+		ready.Reason = cached.Reason
+		ready.Message = cached.Message
+	default:
+		ready.ObservedGeneration = webhook.ObservedGeneration
+		if cached.ObservedGeneration < ready.ObservedGeneration {
+			ready.ObservedGeneration = cached.ObservedGeneration
+		}
+		ready.Status = metav1.ConditionTrue
+		ready.Reason = ReasonPolicyReady
+		ready.Message = "policy is enforced by the admission webhooks"
+	}
+
+	meta.SetStatusCondition(&status.Conditions, ready)
+}
+
+// AutogenUpdate sets the details of the auto-generated rules of the policy status
+type AutogenUpdate struct {
+	// Policy is the key of the policy, namespace/name for namespaced policies
+	Policy  string
+	Autogen *v1.AutogenStatus
+}
+
+// PolicyName returns the key of the policy
+func (u AutogenUpdate) PolicyName() string {
+	return u.Policy
+}
+
+// UpdateStatus sets the autogen details of the status
+func (u AutogenUpdate) UpdateStatus(status v1.PolicyStatus) v1.PolicyStatus {
+	status = *status.DeepCopy()
+	status.Autogen = u.Autogen
+	return status
+}
@@ -0,0 +1,101 @@
+package policystatus
+
+import (
+	"testing"
+
+	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_ConditionUpdate_setsReady(t *testing.T) {
+	status := v1.PolicyStatus{
+		RulesAppliedCount: 3,
+		Conditions: []metav1.Condition{{
+			Type:               v1.PolicyConditionPolicyCached,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: 2,
+			Reason:             ReasonPolicyCached,
+		}},
+	}
+
+	status = ConditionUpdate{
+		Policy: "require-labels",
+		Condition: metav1.Condition{
+			Type:               v1.PolicyConditionBackgroundScanCompleted,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: 2,
+			Reason:             ReasonBackgroundScanCompleted,
+		},
+	}.UpdateStatus(status)
+
+	ready := meta.FindStatusCondition(status.Conditions, v1.PolicyConditionReady)
+	assert.Assert(t, ready != nil)
+	assert.Equal(t, ready.Status, metav1.ConditionFalse)
+	assert.Equal(t, ready.Reason, ReasonWebhookPending)
+
+	status = ConditionUpdate{
+		Policy: "require-labels",
+		Condition: metav1.Condition{
+			Type:               v1.PolicyConditionWebhookConfigured,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: 2,
+			Reason:             ReasonWebhookConfigured,
+		},
+	}.UpdateStatus(status)
+
+	ready = meta.FindStatusCondition(status.Conditions, v1.PolicyConditionReady)
+	assert.Equal(t, ready.Status, metav1.ConditionTrue)
+	assert.Equal(t, ready.Reason, ReasonPolicyReady)
+	assert.Equal(t, ready.ObservedGeneration, int64(2))
+	assert.Equal(t, len(status.Conditions), 4)
+	assert.Equal(t, status.RulesAppliedCount, 3)
+}
+
+func Test_ConditionUpdate_webhookNotConfigured(t *testing.T) {
+	status := ConditionUpdate{
+		Policy: "ns/require-labels",
+		Condition: metav1.Condition{
+			Type:    v1.PolicyConditionWebhookConfigured,
+			Status:  metav1.ConditionFalse,
+			Reason:  ReasonWebhookNotConfigured,
+			Message: "ValidatingWebhookConfiguration kyverno-resource-validating-webhook-cfg does not cover the kind Pod",
+		},
+	}.UpdateStatus(v1.PolicyStatus{})
+
+	ready := meta.FindStatusCondition(status.Conditions, v1.PolicyConditionReady)
+	assert.Equal(t, ready.Status, metav1.ConditionFalse)
+	assert.Equal(t, ready.Reason, ReasonWebhookNotConfigured)
+	assert.Equal(t, ready.Message, "ValidatingWebhookConfiguration kyverno-resource-validating-webhook-cfg does not cover the kind Pod")
+}
+
+func Test_ConditionUpdate_policyCachePending(t *testing.T) {
+	status := ConditionUpdate{
+		Policy: "require-labels",
+		Condition: metav1.Condition{
+			Type:               v1.PolicyConditionWebhookConfigured,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: 1,
+			Reason:             ReasonWebhookConfigured,
+		},
+	}.UpdateStatus(v1.PolicyStatus{})
+
+	ready := meta.FindStatusCondition(status.Conditions, v1.PolicyConditionReady)
+	assert.Equal(t, ready.Status, metav1.ConditionFalse)
+	assert.Equal(t, ready.Reason, ReasonPolicyCachePending)
+
+	status = ConditionUpdate{
+		Policy: "require-labels",
+		Condition: metav1.Condition{
+			Type:               v1.PolicyConditionPolicyCached,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: 1,
+			Reason:             ReasonPolicyCached,
+		},
+	}.UpdateStatus(status)
+
+	ready = meta.FindStatusCondition(status.Conditions, v1.PolicyConditionReady)
+	assert.Equal(t, ready.Status, metav1.ConditionTrue)
+	assert.Equal(t, ready.Reason, ReasonPolicyReady)
+}
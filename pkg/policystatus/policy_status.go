@@ -107,10 +107,7 @@ func (s *Sync) updateStatusCache(stopCh <-chan struct{}) {
 			status, exist := s.cache.data[statusUpdater.PolicyName()]
 			s.cache.dataMu.RUnlock()
 			if !exist {
-				policy, _ := s.lister.Get(statusUpdater.PolicyName())
-				if policy != nil {
-					status = policy.Status
-				}
+				status = s.getPolicyStatus(name)
 			}
 
 			updatedStatus := statusUpdater.UpdateStatus(status)
@@ -146,6 +143,22 @@ func (s *Sync) writePolicyStatus() {
 	}
 }
 
+// getPolicyStatus returns the current status of the policy from the informer cache
+func (s *Sync) getPolicyStatus(key string) v1.PolicyStatus {
+	namespace, policyName := s.parseStatusKey(key)
+	if namespace == "" {
+		if policy, _ := s.lister.Get(policyName); policy != nil {
+			return policy.Status
+		}
+		return v1.PolicyStatus{}
+	}
+
+	if policy, _ := s.nsLister.Policies(namespace).Get(policyName); policy != nil {
+		return policy.Status
+	}
+	return v1.PolicyStatus{}
+}
+
 func (s *Sync) parseStatusKey(key string) (string, string) {
 	namespace := ""
 	policyName := key
@@ -0,0 +1,40 @@
+package resultsink
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kyverno/kyverno/pkg/config"
+)
+
+// fileSink appends the records as newline-delimited JSON to a file
+type fileSink struct {
+	file *os.File
+}
+
+func newFileSink(cfg config.ResultSinkConfig) (Sink, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("path is required for the %s result sink", TypeFile)
+	}
+
+	file, err := os.OpenFile(cfg.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %v", cfg.Path, err)
+	}
+
+	return &fileSink{file: file}, nil
+}
+
+func (s *fileSink) Write(records []Record) error {
+	data, err := encode(records)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.file.Write(data)
+	return err
+}
+
+func (s *fileSink) Close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,274 @@
+package resultsink
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	backoff "github.com/cenkalti/backoff"
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	resultSinkRecords "github.com/kyverno/kyverno/pkg/metrics/resultsinkrecords"
+)
+
+const (
+	defaultBufferSize    = 1000
+	defaultBatchSize     = 100
+	defaultFlushInterval = 5 * time.Second
+	defaultMaxRetries    = 3
+)
+
+// Interface publishes policy results to the configured result sinks
+type Interface interface {
+	// Publish queues the records for delivery, it never blocks
+	Publish(records ...Record)
+}
+
+// Manager delivers the published records asynchronously to the result sinks
+// configured in the Kyverno ConfigMap
+type Manager struct {
+	// UpdateSinksChan receives a signal when the result sinks configuration changes,
+	// it is buffered so that the configuration handler does not wait for the sinks to restart
+	UpdateSinksChan chan bool
+
+	mu      sync.RWMutex
+	workers []*worker
+
+	promConfig *metrics.PromConfig
+	log        logr.Logger
+}
+
+// NewManager returns a new instance of the result sink manager
+func NewManager(promConfig *metrics.PromConfig, log logr.Logger) *Manager {
+	return &Manager{
+		UpdateSinksChan: make(chan bool, 1),
+		promConfig:      promConfig,
+		log:             log,
+	}
+}
+
+// Publish queues the records for delivery to every sink, records are dropped if a sink buffer is full
+func (m *Manager) Publish(records ...Record) {
+	if len(records) == 0 {
+		return
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, w := range m.workers {
+		w.enqueue(records)
+	}
+}
+
+// Run starts the configured sinks and restarts them when the configuration changes
+func (m *Manager) Run(configHandler config.Interface, stopCh <-chan struct{}) {
+	m.update(configHandler.GetResultSinks())
+	for {
+		select {
+		case <-m.UpdateSinksChan:
+			m.update(configHandler.GetResultSinks())
+		case <-stopCh:
+			for _, w := range m.setWorkers(nil) {
+				w.stop()
+			}
+			return
+		}
+	}
+}
+
+// update starts the new sinks, the running sinks are stopped in the background
+// after they delivered the buffered records
+func (m *Manager) update(configs []config.ResultSinkConfig) {
+	var workers []*worker
+	for _, cfg := range configs {
+		w, err := m.newWorker(cfg)
+		if err != nil {
+			m.log.Error(err, "failed to create result sink", "name", cfg.Name, "type", cfg.Type)
+			continue
+		}
+
+		workers = append(workers, w)
+	}
+
+	for _, w := range workers {
+		go w.run()
+	}
+
+	for _, w := range m.setWorkers(workers) {
+		go w.stop()
+	}
+
+	m.log.V(2).Info("updated result sinks", "count", len(workers))
+}
+
+// setWorkers replaces the workers records are published to and returns the previous ones
+func (m *Manager) setWorkers(workers []*worker) []*worker {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	old := m.workers
+	m.workers = workers
+	return old
+}
+
+func (m *Manager) newWorker(cfg config.ResultSinkConfig) (*worker, error) {
+	flushInterval := defaultFlushInterval
+	if cfg.FlushInterval != "" {
+		interval, err := time.ParseDuration(cfg.FlushInterval)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("invalid flushInterval %q", cfg.FlushInterval)
+		}
+		flushInterval = interval
+	}
+
+	sink, err := newSink(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return newWorker(cfg, sink, flushInterval, m.promConfig, m.log.WithValues("name", cfg.Name, "type", cfg.Type)), nil
+}
+
+// worker buffers the records of a sink and delivers them in batches
+type worker struct {
+	name     string
+	sinkType string
+	sink     Sink
+
+	queue         chan Record
+	batchSize     int
+	flushInterval time.Duration
+	maxRetries    int
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+
+	promConfig *metrics.PromConfig
+	log        logr.Logger
+}
+
+func newWorker(cfg config.ResultSinkConfig, sink Sink, flushInterval time.Duration, promConfig *metrics.PromConfig, log logr.Logger) *worker {
+	bufferSize := cfg.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	maxRetries := cfg.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultMaxRetries
+	}
+
+	return &worker{
+		name:          cfg.Name,
+		sinkType:      cfg.Type,
+		sink:          sink,
+		queue:         make(chan Record, bufferSize),
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		maxRetries:    maxRetries,
+		stopCh:        make(chan struct{}),
+		doneCh:        make(chan struct{}),
+		promConfig:    promConfig,
+		log:           log,
+	}
+}
+
+func (w *worker) enqueue(records []Record) {
+	dropped := 0
+	for _, record := range records {
+		select {
+		case w.queue <- record:
+		default:
+			dropped++
+		}
+	}
+
+	if dropped > 0 {
+		w.log.V(3).Info("result sink buffer is full, dropping records", "dropped", dropped)
+		w.registerDropped(resultSinkRecords.BufferFull, dropped)
+	}
+}
+
+func (w *worker) run() {
+	defer close(w.doneCh)
+
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Record, 0, w.batchSize)
+	for {
+		select {
+		case record := <-w.queue:
+			batch = append(batch, record)
+			if len(batch) >= w.batchSize {
+				w.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			w.flush(batch)
+			batch = batch[:0]
+		case <-w.stopCh:
+			for {
+				select {
+				case record := <-w.queue:
+					batch = append(batch, record)
+				default:
+					w.flush(batch)
+					if err := w.sink.Close(); err != nil {
+						w.log.Error(err, "failed to close result sink")
+					}
+					return
+				}
+			}
+		}
+	}
+}
+
+// stop delivers the buffered records and closes the sink
+func (w *worker) stop() {
+	close(w.stopCh)
+	<-w.doneCh
+}
+
+func (w *worker) flush(batch []Record) {
+	for len(batch) > 0 {
+		n := len(batch)
+		if n > w.batchSize {
+			n = w.batchSize
+		}
+
+		w.deliver(batch[:n])
+		batch = batch[n:]
+	}
+}
+
+func (w *worker) deliver(records []Record) {
+	retry := backoff.NewExponentialBackOff()
+	retry.InitialInterval = 500 * time.Millisecond
+	retry.MaxElapsedTime = 0
+
+	err := backoff.Retry(func() error {
+		return w.sink.Write(records)
+	}, backoff.WithMaxRetries(retry, uint64(w.maxRetries)))
+
+	if err != nil {
+		w.log.Error(err, "failed to deliver records to result sink", "dropped", len(records))
+		w.registerDropped(resultSinkRecords.DeliveryFailed, len(records))
+	}
+}
+
+func (w *worker) registerDropped(reason resultSinkRecords.DropReason, count int) {
+	if w.promConfig == nil {
+		return
+	}
+
+	pm := resultSinkRecords.ParsePromMetrics(*w.promConfig.Metrics)
+	if err := pm.RegisterDropped(w.name, w.sinkType, reason, count); err != nil {
+		w.log.Error(err, "error occurred while registering kyverno_result_sink_records_dropped_total metrics")
+	}
+}
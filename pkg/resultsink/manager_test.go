@@ -0,0 +1,167 @@
+package resultsink
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type fakeSink struct {
+	mu       sync.Mutex
+	batches  [][]Record
+	failures int
+	closed   bool
+}
+
+func (s *fakeSink) Write(records []Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("unavailable")
+	}
+
+	s.batches = append(s.batches, append([]Record{}, records...))
+	return nil
+}
+
+func (s *fakeSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func newRecords(n int) []Record {
+	var records []Record
+	for i := 0; i < n; i++ {
+		records = append(records, Record{Policy: "pol", Rule: "rule", Result: "pass"})
+	}
+	return records
+}
+
+func Test_worker_batches(t *testing.T) {
+	sink := &fakeSink{failures: 1}
+	w := newWorker(config.ResultSinkConfig{Name: "test", BatchSize: 2, BufferSize: 3, MaxRetries: 1}, sink, time.Hour, nil, log.Log)
+
+	// records exceeding the buffer are dropped until the worker runs
+	w.enqueue(newRecords(5))
+	assert.Equal(t, len(w.queue), 3)
+
+	go w.run()
+	w.stop()
+
+	assert.Assert(t, sink.closed)
+	assert.Equal(t, len(sink.batches), 2)
+	assert.Equal(t, len(sink.batches[0]), 2)
+	assert.Equal(t, len(sink.batches[1]), 1)
+}
+
+type blockingSink struct {
+	release chan struct{}
+}
+
+func (s *blockingSink) Write(records []Record) error {
+	<-s.release
+	return nil
+}
+
+func (s *blockingSink) Close() error {
+	return nil
+}
+
+func Test_Manager_update_stopsOldSinksAsync(t *testing.T) {
+	sink := &blockingSink{release: make(chan struct{})}
+	w := newWorker(config.ResultSinkConfig{Name: "slow"}, sink, time.Hour, nil, log.Log)
+	go w.run()
+
+	m := NewManager(nil, log.Log)
+	m.workers = []*worker{w}
+	m.Publish(newRecords(1)...)
+
+	updated := make(chan struct{})
+	go func() {
+		m.update(nil)
+		close(updated)
+	}()
+
+	select {
+	case <-updated:
+	case <-time.After(time.Second):
+		t.Fatal("update waited for the old sink to deliver its records")
+	}
+	assert.Equal(t, len(m.workers), 0)
+
+	close(sink.release)
+	<-w.doneCh
+}
+
+func Test_fileSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "resultsink")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "results.json")
+	sink, err := newSink(config.ResultSinkConfig{Type: TypeFile, Path: path})
+	assert.NilError(t, err)
+
+	assert.NilError(t, sink.Write(newRecords(2)))
+	assert.NilError(t, sink.Write(newRecords(1)))
+	assert.NilError(t, sink.Close())
+
+	file, err := os.Open(path)
+	assert.NilError(t, err)
+	defer file.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record Record
+		assert.NilError(t, json.Unmarshal(scanner.Bytes(), &record))
+		assert.Equal(t, record.Policy, "pol")
+		lines++
+	}
+	assert.Equal(t, lines, 3)
+}
+
+func Test_httpSink(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		received = append(received, string(body))
+	}))
+	defer server.Close()
+
+	sink, err := newSink(config.ResultSinkConfig{Type: TypeHTTP, URL: server.URL})
+	assert.NilError(t, err)
+	assert.ErrorContains(t, sink.Write(newRecords(1)), "401")
+
+	sink, err = newSink(config.ResultSinkConfig{Type: TypeHTTP, URL: server.URL, Headers: map[string]string{"Authorization": "Bearer token"}})
+	assert.NilError(t, err)
+	assert.NilError(t, sink.Write(newRecords(2)))
+	assert.Equal(t, len(received), 1)
+	assert.Equal(t, received[0], "{\"timestamp\":\"0001-01-01T00:00:00Z\",\"source\":\"\",\"policy\":\"pol\",\"rule\":\"rule\",\"ruleType\":\"\",\"resource\":{\"kind\":\"\",\"name\":\"\"},\"action\":\"\",\"result\":\"pass\"}\n{\"timestamp\":\"0001-01-01T00:00:00Z\",\"source\":\"\",\"policy\":\"pol\",\"rule\":\"rule\",\"ruleType\":\"\",\"resource\":{\"kind\":\"\",\"name\":\"\"},\"action\":\"\",\"result\":\"pass\"}\n")
+}
+
+func Test_newSink_invalid(t *testing.T) {
+	_, err := newSink(config.ResultSinkConfig{Type: "kafka"})
+	assert.ErrorContains(t, err, "unsupported result sink type")
+
+	_, err = newSink(config.ResultSinkConfig{Type: TypeFile})
+	assert.ErrorContains(t, err, "path is required")
+}
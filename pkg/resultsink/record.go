@@ -0,0 +1,124 @@
+package resultsink
+
+import (
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"github.com/kyverno/kyverno/pkg/policyreport"
+	v1beta1 "k8s.io/api/admission/v1beta1"
+)
+
+const (
+	// SourceAdmission is set for the results of admission requests
+	SourceAdmission = "admission"
+	// SourceBackground is set for the results of background scans and generate requests
+	SourceBackground = "background"
+)
+
+const (
+	// ActionAllowed the admission request was allowed
+	ActionAllowed = "allowed"
+	// ActionBlocked the admission request was rejected
+	ActionBlocked = "blocked"
+	// ActionReported the result was recorded in a policy report
+	ActionReported = "reported"
+)
+
+// Record is a policy rule result published to the result sinks
+type Record struct {
+	Timestamp time.Time `json:"timestamp"`
+	Source    string    `json:"source"`
+	Operation string    `json:"operation,omitempty"`
+	Policy    string    `json:"policy"`
+	Rule      string    `json:"rule"`
+	RuleType  string    `json:"ruleType"`
+	Resource  Resource  `json:"resource"`
+	User      *User     `json:"user,omitempty"`
+	Action    string    `json:"action"`
+	Result    string    `json:"result"`
+	Message   string    `json:"message,omitempty"`
+}
+
+// Resource identifies the resource a policy was applied to
+type Resource struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	UID        string `json:"uid,omitempty"`
+}
+
+// User is the requester of an admission request
+type User struct {
+	Username string   `json:"username"`
+	UID      string   `json:"uid,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+}
+
+// FromEngineResponses builds the records of the rules applied in an admission request
+func FromEngineResponses(engineResponses []*response.EngineResponse, request *v1beta1.AdmissionRequest, action string) []Record {
+	var records []Record
+	now := time.Now()
+	for _, er := range engineResponses {
+		resource := Resource{
+			APIVersion: er.PolicyResponse.Resource.APIVersion,
+			Kind:       er.PolicyResponse.Resource.Kind,
+			Namespace:  er.PolicyResponse.Resource.Namespace,
+			Name:       er.PolicyResponse.Resource.Name,
+			UID:        er.PolicyResponse.Resource.UID,
+		}
+
+		for _, rule := range er.PolicyResponse.Rules {
+			records = append(records, Record{
+				Timestamp: now,
+				Source:    SourceAdmission,
+				Operation: string(request.Operation),
+				Policy:    er.PolicyResponse.Policy.Name,
+				Rule:      rule.Name,
+				RuleType:  string(policyreport.RuleType(rule.Type)),
+				Resource:  resource,
+				User: &User{
+					Username: request.UserInfo.Username,
+					UID:      request.UserInfo.UID,
+					Groups:   request.UserInfo.Groups,
+				},
+				Action:  action,
+				Result:  string(rule.GetStatus()),
+				Message: rule.Message,
+			})
+		}
+	}
+
+	return records
+}
+
+// FromReportInfo builds the records of the results added to the policy reports
+func FromReportInfo(info policyreport.Info) []Record {
+	var records []Record
+	now := time.Now()
+	for _, result := range info.Results {
+		resource := Resource{
+			APIVersion: result.Resource.APIVersion,
+			Kind:       result.Resource.Kind,
+			Namespace:  result.Resource.Namespace,
+			Name:       result.Resource.Name,
+			UID:        result.Resource.UID,
+		}
+
+		for _, rule := range result.Rules {
+			records = append(records, Record{
+				Timestamp: now,
+				Source:    SourceBackground,
+				Policy:    info.PolicyName,
+				Rule:      rule.Name,
+				RuleType:  string(policyreport.RuleType(rule.Type)),
+				Resource:  resource,
+				Action:    ActionReported,
+				Result:    rule.Check,
+				Message:   rule.Message,
+			})
+		}
+	}
+
+	return records
+}
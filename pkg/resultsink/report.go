@@ -0,0 +1,25 @@
+package resultsink
+
+import (
+	"github.com/kyverno/kyverno/pkg/policyreport"
+)
+
+// reportGenerator publishes the results added to the policy reports
+type reportGenerator struct {
+	policyreport.GeneratorInterface
+	sink Interface
+}
+
+// NewReportGenerator returns a policy report generator that also publishes the results
+// to the result sinks; it is used for results which are not produced by admission requests
+func NewReportGenerator(gen policyreport.GeneratorInterface, sink Interface) policyreport.GeneratorInterface {
+	return &reportGenerator{GeneratorInterface: gen, sink: sink}
+}
+
+func (g *reportGenerator) Add(infos ...policyreport.Info) {
+	for _, info := range infos {
+		g.sink.Publish(FromReportInfo(info)...)
+	}
+
+	g.GeneratorInterface.Add(infos...)
+}
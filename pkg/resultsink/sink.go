@@ -0,0 +1,52 @@
+package resultsink
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kyverno/kyverno/pkg/config"
+)
+
+const (
+	// TypeFile appends the results to a file
+	TypeFile = "file"
+	// TypeHTTP posts the results to a webhook
+	TypeHTTP = "http"
+	// TypeSyslog sends the results to a syslog server
+	TypeSyslog = "syslog"
+)
+
+// Sink delivers batches of records to an external system
+type Sink interface {
+	// Write delivers the records, a failed delivery is retried with the same records
+	Write(records []Record) error
+	// Close releases the resources held by the sink
+	Close() error
+}
+
+func newSink(cfg config.ResultSinkConfig) (Sink, error) {
+	switch cfg.Type {
+	case TypeFile:
+		return newFileSink(cfg)
+	case TypeHTTP:
+		return newHTTPSink(cfg)
+	case TypeSyslog:
+		return newSyslogSink(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported result sink type %q, supported types: %s, %s, %s", cfg.Type, TypeFile, TypeHTTP, TypeSyslog)
+	}
+}
+
+// encode returns the records as newline-delimited JSON
+func encode(records []Record) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
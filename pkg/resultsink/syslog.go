@@ -0,0 +1,49 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package resultsink
+
+import (
+	"encoding/json"
+	"log/syslog"
+
+	"github.com/kyverno/kyverno/pkg/config"
+)
+
+// syslogSink sends each record as a JSON message to a syslog server
+type syslogSink struct {
+	writer *syslog.Writer
+}
+
+func newSyslogSink(cfg config.ResultSinkConfig) (Sink, error) {
+	tag := cfg.Tag
+	if tag == "" {
+		tag = "kyverno"
+	}
+
+	writer, err := syslog.Dial(cfg.Network, cfg.Address, syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &syslogSink{writer: writer}, nil
+}
+
+func (s *syslogSink) Write(records []Record) error {
+	for _, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+
+		if err := s.writer.Info(string(data)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *syslogSink) Close() error {
+	return s.writer.Close()
+}
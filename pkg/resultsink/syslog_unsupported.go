@@ -0,0 +1,15 @@
+//go:build windows || plan9
+// +build windows plan9
+
+package resultsink
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/kyverno/kyverno/pkg/config"
+)
+
+func newSyslogSink(cfg config.ResultSinkConfig) (Sink, error) {
+	return nil, fmt.Errorf("the %s result sink is not supported on %s", TypeSyslog, runtime.GOOS)
+}
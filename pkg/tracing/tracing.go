@@ -0,0 +1,93 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/kyverno/kyverno/pkg/version"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/kyverno/kyverno"
+
+// attribute keys of the kyverno spans
+const (
+	PolicyNameKey        = attribute.Key("kyverno.policy.name")
+	PolicyNamespaceKey   = attribute.Key("kyverno.policy.namespace")
+	RuleNameKey          = attribute.Key("kyverno.rule.name")
+	RuleTypeKey          = attribute.Key("kyverno.rule.type")
+	RuleResultKey        = attribute.Key("kyverno.rule.result")
+	ResourceKindKey      = attribute.Key("kyverno.resource.kind")
+	ResourceNamespaceKey = attribute.Key("kyverno.resource.namespace")
+	ResourceNameKey      = attribute.Key("kyverno.resource.name")
+	RequestUIDKey        = attribute.Key("kyverno.request.uid")
+	RequestOperationKey  = attribute.Key("kyverno.request.operation")
+	ContextEntryKey      = attribute.Key("kyverno.context.entry")
+	ImageKey             = attribute.Key("kyverno.image")
+)
+
+// NewOTLPProvider registers a tracer provider exporting the sampled spans with OTLP over gRPC
+// to the collector at endpoint, along with the W3C trace context propagator
+func NewOTLPProvider(ctx context.Context, endpoint string, insecure bool, sampleRatio float64) (*sdktrace.TracerProvider, error) {
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(endpoint)}
+	if insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptrace.New(ctx, otlptracegrpc.NewClient(opts...))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String("kyverno"),
+			semconv.ServiceVersionKey.String(version.BuildVersion),
+		)),
+	)
+
+	register(provider)
+	return provider, nil
+}
+
+func register(provider trace.TracerProvider) {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// StartSpan starts a span as a child of the span in ctx, a new trace is started if ctx is nil
+// or has no span; the spans are not recorded until a tracer provider is registered
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// ExtractHTTP returns the request context with the span context propagated in the request headers
+func ExtractHTTP(r *http.Request) context.Context {
+	return otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+}
+
+// SetError records the error on the span
+func SetError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
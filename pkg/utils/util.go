@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
+	"unicode"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -201,6 +203,64 @@ func SliceContains(slice []string, values ...string) bool {
 	return false
 }
 
+// SplitSubresource splits a policy kind of the form Kind/subresource (e.g. Pod/exec, apps/v1/Deployment/scale)
+// into the kind and the subresource name. Kinds without a subresource are returned unchanged.
+func SplitSubresource(kind string) (string, string) {
+	idx := strings.LastIndex(kind, "/")
+	if idx <= 0 || idx == len(kind)-1 {
+		return kind, ""
+	}
+
+	// kinds are camel case (e.g. v1/Pod) while subresources are lower case (e.g. Pod/exec)
+	parent, subresource := kind[:idx], kind[idx+1:]
+	if subresource != "*" && !unicode.IsLower(rune(subresource[0])) {
+		return kind, ""
+	}
+
+	parentKind := parent[strings.LastIndex(parent, "/")+1:]
+	if parentKind == "" || !unicode.IsUpper(rune(parentKind[0])) {
+		return kind, ""
+	}
+
+	return parent, subresource
+}
+
+// MatchesGVK checks if a policy kind matches the given GroupVersionKind. The policy kind can be
+// specified as "*", Kind, version/Kind, group/version/Kind or group/*/Kind.
+func MatchesGVK(kind string, gvk schema.GroupVersionKind) bool {
+	if kind == "*" {
+		return true
+	}
+
+	splitGVK := strings.Split(kind, "/")
+	switch len(splitGVK) {
+	case 1:
+		return gvk.Kind == kind
+	case 2:
+		return gvk.Kind == splitGVK[1] && gvk.Version == splitGVK[0]
+	case 3:
+		return gvk.Group == splitGVK[0] && gvk.Kind == splitGVK[2] && (splitGVK[1] == "*" || gvk.Version == splitGVK[1])
+	}
+
+	return false
+}
+
+// ContainsKind checks if the list of policy kinds contains the given kind, ignoring the group and version
+// of group/version qualified kinds. Subresource kinds and the wildcard kind are not considered.
+func ContainsKind(kinds []string, kind string) bool {
+	for _, k := range kinds {
+		if _, subresource := SplitSubresource(k); subresource != "" {
+			continue
+		}
+
+		if k[strings.LastIndex(k, "/")+1:] == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ApiextensionsJsonTOKyvernoConditions takes in user-provided conditions in abstract apiextensions.JSON form
 // and converts it into []kyverno.Condition or kyverno.AnyAllConditions according to its content.
 // it also helps in validating the condtions as it returns an error when the conditions are provided wrongfully by the user.
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func Test_allEmpty(t *testing.T) {
@@ -165,3 +166,58 @@ func Test_ConvertResource(t *testing.T) {
 	}
 
 }
+
+func Test_SplitSubresource(t *testing.T) {
+	testCases := []struct {
+		kind        string
+		parent      string
+		subresource string
+	}{
+		{kind: "Pod", parent: "Pod"},
+		{kind: "v1/Pod", parent: "v1/Pod"},
+		{kind: "apps/v1/Deployment", parent: "apps/v1/Deployment"},
+		{kind: "Pod/exec", parent: "Pod", subresource: "exec"},
+		{kind: "Pod/*", parent: "Pod", subresource: "*"},
+		{kind: "v1/Pod/ephemeralcontainers", parent: "v1/Pod", subresource: "ephemeralcontainers"},
+		{kind: "apps/v1/Deployment/scale", parent: "apps/v1/Deployment", subresource: "scale"},
+	}
+
+	for _, tc := range testCases {
+		parent, subresource := SplitSubresource(tc.kind)
+		assert.Equal(t, parent, tc.parent)
+		assert.Equal(t, subresource, tc.subresource)
+	}
+}
+
+func Test_MatchesGVK(t *testing.T) {
+	ingress := schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"}
+	testCases := []struct {
+		kind  string
+		gvk   schema.GroupVersionKind
+		match bool
+	}{
+		{kind: "*", gvk: ingress, match: true},
+		{kind: "Ingress", gvk: ingress, match: true},
+		{kind: "v1/Ingress", gvk: ingress, match: true},
+		{kind: "v1beta1/Ingress", gvk: ingress, match: false},
+		{kind: "networking.k8s.io/v1/Ingress", gvk: ingress, match: true},
+		{kind: "networking.k8s.io/v1beta1/Ingress", gvk: ingress, match: false},
+		{kind: "networking.k8s.io/*/Ingress", gvk: ingress, match: true},
+		{kind: "extensions/*/Ingress", gvk: ingress, match: false},
+		{kind: "networking.k8s.io/*/NetworkPolicy", gvk: ingress, match: false},
+		{kind: "Pod", gvk: ingress, match: false},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, MatchesGVK(tc.kind, tc.gvk), tc.match, tc.kind)
+	}
+}
+
+func Test_ContainsKind(t *testing.T) {
+	assert.Assert(t, ContainsKind([]string{"Pod"}, "Pod"))
+	assert.Assert(t, ContainsKind([]string{"Deployment", "v1/Pod"}, "Pod"))
+	assert.Assert(t, ContainsKind([]string{"apps/v1/Deployment"}, "Deployment"))
+	assert.Assert(t, !ContainsKind([]string{"Pod/exec"}, "Pod"))
+	assert.Assert(t, !ContainsKind([]string{"*"}, "Pod"))
+	assert.Assert(t, !ContainsKind([]string{"PodTemplate"}, "Pod"))
+}
@@ -0,0 +1,261 @@
+package webhookconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	kyverno "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/policystatus"
+	"github.com/kyverno/kyverno/pkg/utils"
+	"github.com/pkg/errors"
+	admregapi "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+// policyConditionsResync is the interval the WebhookConfigured condition of the policies is refreshed
+const policyConditionsResync = time.Minute
+
+// UpdatePolicyConditions maintains the WebhookConfigured condition of the policies,
+// the conditions are refreshed when the policies or the webhook configurations change
+func (wrc *Register) UpdatePolicyConditions(stopCh <-chan struct{}) {
+	logger := wrc.log.WithName("UpdatePolicyConditions")
+	if !cache.WaitForCacheSync(stopCh, wrc.pListerSynced, wrc.npListerSynced) {
+		logger.Info("failed to sync informer cache")
+		return
+	}
+
+	ticker := time.NewTicker(policyConditionsResync)
+	defer ticker.Stop()
+
+	for {
+		wrc.updateAllPolicyConditions()
+
+		select {
+		case <-wrc.policyConditionsChan:
+		case <-ticker.C:
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// CheckPolicyWebhooks returns an error if the resource webhook configurations do not cover the kinds of the policy rules
+func (wrc *Register) CheckPolicyWebhooks(policy *kyverno.ClusterPolicy) error {
+	var mutateKinds, validateKinds []string
+	for _, rule := range policy.Spec.Rules {
+		kinds := rule.MatchResources.GetKinds()
+		if rule.HasMutate() || rule.HasVerifyImages() {
+			mutateKinds = append(mutateKinds, kinds...)
+		}
+
+		if rule.HasValidate() || rule.HasGenerate() {
+			validateKinds = append(validateKinds, kinds...)
+		}
+	}
+
+	if len(mutateKinds) > 0 {
+		if err := wrc.checkWebhookKinds(kindMutating, wrc.getResourceMutatingWebhookConfigName(), mutateKinds); err != nil {
+			return err
+		}
+	}
+
+	if len(validateKinds) > 0 {
+		if err := wrc.checkWebhookKinds(kindValidating, wrc.getResourceValidatingWebhookConfigName(), validateKinds); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (wrc *Register) enqueuePolicyConditions() {
+	select {
+	case wrc.policyConditionsChan <- struct{}{}:
+	default:
+	}
+}
+
+func (wrc *Register) addPolicy(obj interface{}) {
+	wrc.enqueuePolicyConditions()
+}
+
+func (wrc *Register) updateClusterPolicy(old, cur interface{}) {
+	if !reflect.DeepEqual(old.(*kyverno.ClusterPolicy).Spec, cur.(*kyverno.ClusterPolicy).Spec) {
+		wrc.enqueuePolicyConditions()
+	}
+}
+
+func (wrc *Register) updateNsPolicy(old, cur interface{}) {
+	if !reflect.DeepEqual(old.(*kyverno.Policy).Spec, cur.(*kyverno.Policy).Spec) {
+		wrc.enqueuePolicyConditions()
+	}
+}
+
+func (wrc *Register) updateAllPolicyConditions() {
+	logger := wrc.log.WithName("updateAllPolicyConditions")
+	cpols, err := wrc.pLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "unable to list ClusterPolicies")
+	}
+
+	for _, cpol := range cpols {
+		wrc.updatePolicyCondition(cpol.Name, cpol)
+	}
+
+	pols, err := wrc.npLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "unable to list Policies")
+	}
+
+	for _, pol := range pols {
+		cpol := kyverno.ClusterPolicy(*pol)
+		wrc.updatePolicyCondition(pol.Namespace+"/"+pol.Name, &cpol)
+	}
+}
+
+func (wrc *Register) updatePolicyCondition(key string, policy *kyverno.ClusterPolicy) {
+	condition := v1.Condition{
+		Type:               kyverno.PolicyConditionWebhookConfigured,
+		Status:             v1.ConditionTrue,
+		ObservedGeneration: policy.Generation,
+		Reason:             policystatus.ReasonWebhookConfigured,
+		Message:            "webhook configurations cover the kinds of the policy rules",
+	}
+
+	if err := wrc.CheckPolicyWebhooks(policy); err != nil {
+		condition.Status = v1.ConditionFalse
+		condition.Reason = policystatus.ReasonWebhookNotConfigured
+		condition.Message = err.Error()
+	}
+
+	if current := meta.FindStatusCondition(policy.Status.Conditions, condition.Type); current != nil &&
+		current.Status == condition.Status && current.Message == condition.Message && current.ObservedGeneration == condition.ObservedGeneration {
+		return
+	}
+
+	wrc.statusListener.Update(policystatus.ConditionUpdate{Policy: key, Condition: condition})
+}
+
+func (wrc *Register) checkWebhookKinds(kind, name string, kinds []string) error {
+	genericCache, ok := wrc.resCache.GetGVRCache(kind)
+	if !ok {
+		return fmt.Errorf("%s informer not found", kind)
+	}
+
+	webhookCfg, err := genericCache.Lister().Get(name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get %s %s", kind, name)
+	}
+
+	rules, err := webhookRules(webhookCfg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse %s %s", kind, name)
+	}
+
+	for _, k := range kinds {
+		group, resource, subresource, err := wrc.resourceForKind(k)
+		if err != nil {
+			return err
+		}
+
+		if !rulesCover(rules, group, resource, subresource) {
+			return fmt.Errorf("%s %s does not cover the kind %s", kind, name, k)
+		}
+	}
+
+	return nil
+}
+
+// resourceForKind returns the group, resource and subresource of a policy kind
+func (wrc *Register) resourceForKind(kind string) (group, resource, subresource string, err error) {
+	if kind == "*" {
+		return "*", "*", "", nil
+	}
+
+	parent, subresource := utils.SplitSubresource(kind)
+	parentKind := parent[strings.LastIndex(parent, "/")+1:]
+	gvr, err := wrc.client.DiscoveryClient.GetGVRFromKind(parentKind)
+	if err != nil {
+		return "", "", "", errors.Wrapf(err, "unable to find the resource of the kind %s", kind)
+	}
+
+	return gvr.Group, gvr.Resource, subresource, nil
+}
+
+// webhookRules returns the rules of the webhooks of a webhook configuration
+func webhookRules(webhookCfg *unstructured.Unstructured) ([]admregapi.RuleWithOperations, error) {
+	webhooks, _, err := unstructured.NestedSlice(webhookCfg.Object, "webhooks")
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []admregapi.RuleWithOperations
+	for _, webhook := range webhooks {
+		webhookMap, ok := webhook.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		webhookRules, _, err := unstructured.NestedSlice(webhookMap, "rules")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range webhookRules {
+			ruleMap, ok := r.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			var rule admregapi.RuleWithOperations
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(ruleMap, &rule); err != nil {
+				return nil, err
+			}
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules, nil
+}
+
+// rulesCover checks if any of the webhook rules matches the resource
+func rulesCover(rules []admregapi.RuleWithOperations, group, resource, subresource string) bool {
+	for _, rule := range rules {
+		if !utils.ContainsString(rule.APIGroups, "*") && !utils.ContainsString(rule.APIGroups, group) {
+			continue
+		}
+
+		for _, r := range rule.Resources {
+			if resourceMatches(r, resource, subresource) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// resourceMatches checks if the resource of a webhook rule, e.g. "*", "pods" or "pods/*", matches the resource
+func resourceMatches(ruleResource, resource, subresource string) bool {
+	if ruleResource == "*/*" {
+		return true
+	}
+
+	parts := strings.SplitN(ruleResource, "/", 2)
+	if parts[0] != "*" && parts[0] != resource {
+		return false
+	}
+
+	if subresource == "" {
+		return len(parts) == 1
+	}
+
+	return len(parts) == 2 && (parts[1] == "*" || parts[1] == subresource)
+}
@@ -0,0 +1,47 @@
+package webhookconfig
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_webhookRules_cover(t *testing.T) {
+	webhookCfg := &unstructured.Unstructured{Object: map[string]interface{}{
+		"webhooks": []interface{}{
+			map[string]interface{}{
+				"name": "validate.kyverno.svc",
+				"rules": []interface{}{
+					map[string]interface{}{
+						"apiGroups":   []interface{}{"", "apps"},
+						"apiVersions": []interface{}{"*"},
+						"operations":  []interface{}{"CREATE", "UPDATE"},
+						"resources":   []interface{}{"pods", "pods/exec", "deployments/*"},
+					},
+				},
+			},
+		},
+	}}
+
+	rules, err := webhookRules(webhookCfg)
+	assert.NilError(t, err)
+	assert.Equal(t, len(rules), 1)
+
+	assert.Assert(t, rulesCover(rules, "", "pods", ""))
+	assert.Assert(t, rulesCover(rules, "", "pods", "exec"))
+	assert.Assert(t, !rulesCover(rules, "", "pods", "log"))
+	assert.Assert(t, rulesCover(rules, "apps", "deployments", "scale"))
+	assert.Assert(t, !rulesCover(rules, "apps", "deployments", ""))
+	assert.Assert(t, !rulesCover(rules, "batch", "jobs", ""))
+	assert.Assert(t, !rulesCover(rules, "*", "*", ""))
+}
+
+func Test_resourceMatches_wildcards(t *testing.T) {
+	assert.Assert(t, resourceMatches("*/*", "pods", "exec"))
+	assert.Assert(t, resourceMatches("*/*", "*", ""))
+	assert.Assert(t, resourceMatches("*", "pods", ""))
+	assert.Assert(t, resourceMatches("*", "*", ""))
+	assert.Assert(t, !resourceMatches("*", "pods", "exec"))
+	assert.Assert(t, resourceMatches("*/exec", "pods", "exec"))
+}
@@ -0,0 +1,64 @@
+package webhookconfig
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/kyverno/kyverno/pkg/config"
+)
+
+// policyReportCRDs are the CRDs served in multiple versions and converted by Kyverno
+var policyReportCRDs = []string{
+	"policyreports.wgpolicyk8s.io",
+	"clusterpolicyreports.wgpolicyk8s.io",
+}
+
+// updatePolicyReportConversion points the conversion of the policy report CRDs to the Kyverno conversion webhook
+func (wrc *Register) updatePolicyReportConversion(caData []byte) error {
+	patch, err := wrc.constructConversionPatch(caData)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range policyReportCRDs {
+		if _, err := wrc.client.PatchResource("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", name, patch); err != nil {
+			return fmt.Errorf("failed to update conversion of CRD %s: %v", name, err)
+		}
+
+		wrc.log.V(4).Info("updated CRD conversion", "name", name)
+	}
+
+	return nil
+}
+
+func (wrc *Register) constructConversionPatch(caData []byte) ([]byte, error) {
+	clientConfig := map[string]interface{}{
+		"caBundle": caData,
+	}
+
+	if wrc.serverIP != "" {
+		clientConfig["url"] = fmt.Sprintf("https://%s%s", wrc.serverIP, config.ConversionWebhookServicePath)
+	} else {
+		clientConfig["service"] = map[string]interface{}{
+			"namespace": config.KyvernoNamespace,
+			"name":      config.KyvernoServiceName,
+			"path":      config.ConversionWebhookServicePath,
+		}
+	}
+
+	patch := []map[string]interface{}{
+		{
+			"op":   "add",
+			"path": "/spec/conversion",
+			"value": map[string]interface{}{
+				"strategy": "Webhook",
+				"webhook": map[string]interface{}{
+					"clientConfig":             clientConfig,
+					"conversionReviewVersions": []string{"v1beta1"},
+				},
+			},
+		},
+	}
+
+	return json.Marshal(patch)
+}
@@ -8,8 +8,11 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernolister "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/config"
 	client "github.com/kyverno/kyverno/pkg/dclient"
+	"github.com/kyverno/kyverno/pkg/policystatus"
 	"github.com/kyverno/kyverno/pkg/resourcecache"
 	"github.com/kyverno/kyverno/pkg/tls"
 	"github.com/pkg/errors"
@@ -20,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	rest "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 )
 
 const (
@@ -42,6 +46,16 @@ type Register struct {
 	log            logr.Logger
 	debug          bool
 
+	// policies the WebhookConfigured condition is maintained for
+	pLister        kyvernolister.ClusterPolicyLister
+	npLister       kyvernolister.PolicyLister
+	pListerSynced  cache.InformerSynced
+	npListerSynced cache.InformerSynced
+	statusListener policystatus.Listener
+
+	// signals the policy conditions need to be updated
+	policyConditionsChan chan struct{}
+
 	UpdateWebhookChan chan bool
 }
 
@@ -50,20 +64,41 @@ func NewRegister(
 	clientConfig *rest.Config,
 	client *client.Client,
 	resCache resourcecache.ResourceCache,
+	pInformer kyvernoinformer.ClusterPolicyInformer,
+	npInformer kyvernoinformer.PolicyInformer,
+	statusListener policystatus.Listener,
 	serverIP string,
 	webhookTimeout int32,
 	debug bool,
 	log logr.Logger) *Register {
-	return &Register{
-		clientConfig:      clientConfig,
-		client:            client,
-		resCache:          resCache,
-		serverIP:          serverIP,
-		timeoutSeconds:    webhookTimeout,
-		log:               log.WithName("Register"),
-		debug:             debug,
-		UpdateWebhookChan: make(chan bool),
-	}
+	wrc := &Register{
+		clientConfig:         clientConfig,
+		client:               client,
+		resCache:             resCache,
+		pLister:              pInformer.Lister(),
+		npLister:             npInformer.Lister(),
+		pListerSynced:        pInformer.Informer().HasSynced,
+		npListerSynced:       npInformer.Informer().HasSynced,
+		statusListener:       statusListener,
+		serverIP:             serverIP,
+		timeoutSeconds:       webhookTimeout,
+		log:                  log.WithName("Register"),
+		debug:                debug,
+		policyConditionsChan: make(chan struct{}, 1),
+		UpdateWebhookChan:    make(chan bool),
+	}
+
+	pInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    wrc.addPolicy,
+		UpdateFunc: wrc.updateClusterPolicy,
+	})
+
+	npInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    wrc.addPolicy,
+		UpdateFunc: wrc.updateNsPolicy,
+	})
+
+	return wrc
 }
 
 // Register clean up the old webhooks and re-creates admission webhooks configs on cluster
@@ -105,6 +140,13 @@ func (wrc *Register) Register() error {
 		errors = append(errors, err.Error())
 	}
 
+	if err := wrc.updatePolicyReportConversion(caData); err != nil {
+		errors = append(errors, err.Error())
+	}
+
+	// the resource webhook configurations may have changed
+	wrc.enqueuePolicyConditions()
+
 	if len(errors) > 0 {
 		return fmt.Errorf("%s", strings.Join(errors, ","))
 	}
@@ -190,6 +232,8 @@ func (wrc *Register) UpdateWebhookConfigurations(configHandler config.Interface)
 		} else {
 			logger.Info("successfully updated validatingWebhookConfigurations", "name", wrc.getResourceValidatingWebhookConfigName())
 		}
+
+		wrc.enqueuePolicyConditions()
 	}
 }
 
@@ -94,6 +94,7 @@ func (wrc *Register) removeResourceMutatingWebhookConfiguration(wg *sync.WaitGro
 	logger.Info("webhook configuration deleted")
 }
 
+// validating webhooks include CONNECT so that requests to subresources like pods/exec are sent to Kyverno
 func (wrc *Register) constructDefaultDebugValidatingWebhookConfig(caData []byte) *admregapi.ValidatingWebhookConfiguration {
 	url := fmt.Sprintf("https://%s%s", wrc.serverIP, config.ValidatingWebhookServicePath)
 
@@ -111,7 +112,7 @@ func (wrc *Register) constructDefaultDebugValidatingWebhookConfig(caData []byte)
 				[]string{"*/*"},
 				"*",
 				"*",
-				[]admregapi.OperationType{admregapi.Create, admregapi.Update, admregapi.Delete},
+				[]admregapi.OperationType{admregapi.Create, admregapi.Update, admregapi.Delete, admregapi.Connect},
 			),
 		},
 	}
@@ -135,7 +136,7 @@ func (wrc *Register) constructDefaultValidatingWebhookConfig(caData []byte) *adm
 				[]string{"*/*"},
 				"*",
 				"*",
-				[]admregapi.OperationType{admregapi.Create, admregapi.Update, admregapi.Delete},
+				[]admregapi.OperationType{admregapi.Create, admregapi.Update, admregapi.Delete, admregapi.Connect},
 			),
 		},
 	}
@@ -1,18 +1,29 @@
 package webhooks
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	kyverno "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/common"
+	"github.com/kyverno/kyverno/pkg/config"
+	client "github.com/kyverno/kyverno/pkg/dclient"
+	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/kyverno/kyverno/pkg/metrics/rolereflatency"
+	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/tracing"
+	"github.com/kyverno/kyverno/pkg/userinfo"
 	yamlv2 "gopkg.in/yaml.v2"
 	"k8s.io/api/admission/v1beta1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	rbaclister "k8s.io/client-go/listers/rbac/v1"
 )
 
 // isResponseSuccessful return true if all responses are successful
@@ -29,9 +40,11 @@ func isResponseSuccessful(engineReponses []*response.EngineResponse) bool {
 // returns false -> if all the policies are meant to report only, we dont block resource request
 func toBlockResource(engineReponses []*response.EngineResponse, log logr.Logger) bool {
 	for _, er := range engineReponses {
-		if !er.IsSuccessful() && er.PolicyResponse.ValidationFailureAction == common.Enforce {
-			log.Info("spec.ValidationFailureAction set to enforce blocking resource request", "policy", er.PolicyResponse.Policy.Name)
-			return true
+		for _, rule := range er.PolicyResponse.Rules {
+			if !rule.Success && ruleValidationFailureAction(er, rule) == common.Enforce {
+				log.Info("ValidationFailureAction set to enforce blocking resource request", "policy", er.PolicyResponse.Policy.Name, "rule", rule.Name)
+				return true
+			}
 		}
 	}
 
@@ -44,14 +57,14 @@ func getEnforceFailureErrorMsg(engineResponses []*response.EngineResponse) strin
 	policyToRule := make(map[string]interface{})
 	var resourceName string
 	for _, er := range engineResponses {
-		if !er.IsSuccessful() && er.PolicyResponse.ValidationFailureAction == common.Enforce {
-			ruleToReason := make(map[string]string)
-			for _, rule := range er.PolicyResponse.Rules {
-				if !rule.Success {
-					ruleToReason[rule.Name] = rule.Message
-				}
+		ruleToReason := make(map[string]string)
+		for _, rule := range er.PolicyResponse.Rules {
+			if !rule.Success && ruleValidationFailureAction(er, rule) == common.Enforce {
+				ruleToReason[rule.Name] = rule.Message
 			}
+		}
 
+		if len(ruleToReason) > 0 {
 			resourceName = fmt.Sprintf("%s/%s/%s", er.PolicyResponse.Resource.Kind, er.PolicyResponse.Resource.Namespace, er.PolicyResponse.Resource.Name)
 			policyToRule[er.PolicyResponse.Policy.Name] = ruleToReason
 		}
@@ -120,6 +133,13 @@ func containsRBACInfo(policies ...[]*kyverno.ClusterPolicy) bool {
 				if len(rule.MatchResources.Roles) > 0 || len(rule.MatchResources.ClusterRoles) > 0 || len(rule.ExcludeResources.Roles) > 0 || len(rule.ExcludeResources.ClusterRoles) > 0 {
 					return true
 				}
+				for _, filters := range []kyverno.ResourceFilters{rule.MatchResources.Any, rule.MatchResources.All, rule.ExcludeResources.Any, rule.ExcludeResources.All} {
+					for _, rf := range filters {
+						if len(rf.Roles) > 0 || len(rf.ClusterRoles) > 0 {
+							return true
+						}
+					}
+				}
 			}
 		}
 	}
@@ -188,3 +208,131 @@ func excludeKyvernoResources(kind string) bool {
 		return false
 	}
 }
+
+// getSubresource returns the subresource targeted by the admission request,
+// along with the kind of the resource owning it (e.g. Pod for pods/exec)
+func getSubresource(client *client.Client, request *v1beta1.AdmissionRequest, logger logr.Logger) engine.Subresource {
+	if request.SubResource == "" {
+		return engine.Subresource{}
+	}
+
+	subresource := engine.Subresource{Name: request.SubResource}
+	gv := schema.GroupVersion{Group: request.Resource.Group, Version: request.Resource.Version}
+	apiResource, _, err := client.DiscoveryClient.FindResource(gv.String(), request.Resource.Resource)
+	if err != nil {
+		logger.Error(err, "failed to find the kind of the parent resource", "resource", request.Resource.String(), "subresource", request.SubResource)
+		return subresource
+	}
+
+	subresource.ParentKind = gv.WithKind(apiResource.Kind)
+	return subresource
+}
+
+// getPolicies returns the policies for the kind of the admission request, for
+// requests on a subresource the policies matching Kind/subresource (e.g. Pod/exec) are included
+func getPolicies(pCache policycache.Interface, pkey policycache.PolicyType, kind string, subresource engine.Subresource, namespace string) []*kyverno.ClusterPolicy {
+	policies := pCache.GetPolicies(pkey, kind, namespace)
+	if subresource.Name == "" || subresource.ParentKind.Kind == "" {
+		return policies
+	}
+
+	names := make(map[string]bool, len(policies))
+	for _, policy := range policies {
+		if policy != nil {
+			names[policy.GetNamespace()+"/"+policy.GetName()] = true
+		}
+	}
+
+	for _, policy := range pCache.GetPolicies(pkey, subresource.ParentKind.Kind+"/"+subresource.Name, namespace) {
+		if policy == nil || names[policy.GetNamespace()+"/"+policy.GetName()] {
+			continue
+		}
+
+		policies = append(policies, policy)
+	}
+
+	return policies
+}
+
+// setSubresourceMetadata sets the name and namespace of the parent resource on subresource objects
+// like PodExecOptions, which do not carry them, so that match and exclude on names and namespaces can be applied
+func setSubresourceMetadata(resource *unstructured.Unstructured, request *v1beta1.AdmissionRequest) {
+	if request.SubResource == "" || resource.Object == nil {
+		return
+	}
+
+	if resource.GetName() == "" {
+		resource.SetName(request.Name)
+	}
+
+	if resource.GetNamespace() == "" {
+		resource.SetNamespace(request.Namespace)
+	}
+}
+
+// filterPoliciesByValidationFailureAction returns the enforce (or audit) policies, after applying the
+// validationFailureActionOverrides for the requested namespace. Policies which have rules of another
+// action are copied with only the validate rules of the requested action.
+func filterPoliciesByValidationFailureAction(policies []*kyverno.ClusterPolicy, enforce bool, request *v1beta1.AdmissionRequest, resource unstructured.Unstructured, namespaceLabels map[string]string) []*kyverno.ClusterPolicy {
+	namespace := request.Namespace
+	if request.Kind.Kind == "Namespace" {
+		namespace = request.Name
+		namespaceLabels = resource.GetLabels()
+	}
+
+	var filtered []*kyverno.ClusterPolicy
+	for _, policy := range policies {
+		policyAction := engine.GetValidationFailureAction(*policy, namespace, namespaceLabels)
+
+		var rules []kyverno.Rule
+		for _, rule := range policy.Spec.Rules {
+			if rule.HasValidate() && (rule.GetValidationFailureAction(policyAction) == common.Enforce) == enforce {
+				rules = append(rules, rule)
+			}
+		}
+
+		if len(rules) == 0 {
+			continue
+		}
+
+		if len(rules) == len(policy.Spec.Rules) {
+			filtered = append(filtered, policy)
+			continue
+		}
+
+		policyCopy := policy.DeepCopy()
+		policyCopy.Spec.Rules = rules
+		filtered = append(filtered, policyCopy)
+	}
+
+	return filtered
+}
+
+// ruleValidationFailureAction returns the validation failure action of a rule response,
+// defaults to the action of the policy response
+func ruleValidationFailureAction(er *response.EngineResponse, rule response.RuleResponse) string {
+	if rule.ValidationFailureAction != "" {
+		return rule.ValidationFailureAction
+	}
+
+	return er.PolicyResponse.ValidationFailureAction
+}
+
+// getRoleRef returns the roles and cluster roles bound to the user of the request,
+// the resolution is traced and its latency recorded
+func getRoleRef(traceCtx context.Context, rbLister rbaclister.RoleBindingLister, crbLister rbaclister.ClusterRoleBindingLister, request *v1beta1.AdmissionRequest, configHandler config.Interface, promConfig *metrics.PromConfig, logger logr.Logger) (roles []string, clusterRoles []string, err error) {
+	_, span := tracing.StartSpan(traceCtx, "get-role-ref")
+	startTime := time.Now()
+	defer func() {
+		tracing.SetError(span, err)
+		span.End()
+
+		if promConfig != nil {
+			if err := rolereflatency.ParsePromMetrics(*promConfig.Metrics).RegisterRoleRef(metrics.ParseOutcome(err), time.Since(startTime)); err != nil {
+				logger.Error(err, "error occurred while registering kyverno_role_ref_duration_seconds metrics")
+			}
+		}
+	}()
+
+	return userinfo.GetRoleRef(rbLister, crbLister, request, configHandler)
+}
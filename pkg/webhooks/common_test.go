@@ -0,0 +1,84 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	kyverno "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"gotest.tools/assert"
+	"k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_filterPoliciesByValidationFailureAction(t *testing.T) {
+	rawPolicy := []byte(`{
+		"metadata": {
+			"name": "require-labels"
+		},
+		"spec": {
+			"validationFailureAction": "audit",
+			"rules": [
+				{
+					"name": "check-app",
+					"match": {"resources": {"kinds": ["Pod"]}},
+					"validate": {
+						"validationFailureAction": "enforce",
+						"pattern": {"metadata": {"labels": {"app": "?*"}}}
+					}
+				},
+				{
+					"name": "check-team",
+					"match": {"resources": {"kinds": ["Pod"]}},
+					"validate": {
+						"pattern": {"metadata": {"labels": {"team": "?*"}}}
+					}
+				}
+			]
+		}
+	}`)
+
+	var policy *kyverno.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+
+	request := &v1beta1.AdmissionRequest{Namespace: "default", Kind: metav1.GroupVersionKind{Version: "v1", Kind: "Pod"}}
+
+	enforce := filterPoliciesByValidationFailureAction([]*kyverno.ClusterPolicy{policy}, true, request, unstructured.Unstructured{}, nil)
+	assert.Equal(t, len(enforce), 1)
+	assert.Equal(t, len(enforce[0].Spec.Rules), 1)
+	assert.Equal(t, enforce[0].Spec.Rules[0].Name, "check-app")
+
+	audit := filterPoliciesByValidationFailureAction([]*kyverno.ClusterPolicy{policy}, false, request, unstructured.Unstructured{}, nil)
+	assert.Equal(t, len(audit), 1)
+	assert.Equal(t, len(audit[0].Spec.Rules), 1)
+	assert.Equal(t, audit[0].Spec.Rules[0].Name, "check-team")
+
+	// the cached policy must not be modified
+	assert.Equal(t, len(policy.Spec.Rules), 2)
+}
+
+func Test_toBlockResource_RuleValidationFailureAction(t *testing.T) {
+	engineResponse := &response.EngineResponse{
+		PolicyResponse: response.PolicyResponse{
+			Policy:                  response.PolicySpec{Name: "require-labels"},
+			ValidationFailureAction: "audit",
+			Rules: []response.RuleResponse{
+				{Name: "check-app", Success: true, ValidationFailureAction: "enforce"},
+				{Name: "check-team", Success: false, ValidationFailureAction: "audit"},
+			},
+		},
+	}
+
+	assert.Assert(t, !toBlockResource([]*response.EngineResponse{engineResponse}, log.Log))
+
+	engineResponse.PolicyResponse.Rules[0].Success = false
+	assert.Assert(t, toBlockResource([]*response.EngineResponse{engineResponse}, log.Log))
+
+	msg := getEnforceFailureErrorMsg([]*response.EngineResponse{engineResponse})
+	assert.Assert(t, strings.Contains(msg, "check-app"))
+	assert.Assert(t, !strings.Contains(msg, "check-team"))
+}
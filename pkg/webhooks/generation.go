@@ -13,6 +13,7 @@ import (
 
 	kyverno "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
 	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/auditlog"
 	"github.com/kyverno/kyverno/pkg/common"
 	"github.com/kyverno/kyverno/pkg/config"
 	client "github.com/kyverno/kyverno/pkg/dclient"
@@ -58,6 +59,13 @@ func (ws *WebhookServer) handleGenerate(
 	logger := ws.log.WithValues("action", "generation", "uid", request.UID, "kind", request.Kind, "namespace", request.Namespace, "name", request.Name, "operation", request.Operation, "gvk", request.Kind.String())
 	logger.V(6).Info("generate request")
 
+	// the request is already allowed, the results of the generate policies are recorded in a separate entry
+	auditEntry := ws.auditLog.NewEntry(auditlog.WebhookGenerate, request)
+	defer func() {
+		auditEntry.SetResponse(successResponse(nil))
+		ws.auditLog.Add(auditEntry)
+	}()
+
 	var engineResponses []*response.EngineResponse
 	var triggeredGeneratePolicies []kyverno.ClusterPolicy
 	if (request.Operation == v1beta1.Create || request.Operation == v1beta1.Update) && len(policies) != 0 {
@@ -76,6 +84,7 @@ func (ws *WebhookServer) handleGenerate(
 			ResourceCache:       ws.resCache,
 			JSONContext:         ctx,
 			Client:              ws.client,
+			PromConfig:          ws.promConfig,
 		}
 
 		for _, policy := range policies {
@@ -85,12 +94,19 @@ func (ws *WebhookServer) handleGenerate(
 				policyContext.NamespaceLabels = common.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, ws.nsLister, logger)
 			}
 			engineResponse := engine.Generate(policyContext)
+			auditEntry.AddEngineResponses(engineResponse)
 			for _, rule := range engineResponse.PolicyResponse.Rules {
-				if !rule.Success {
-					ws.deleteGR(logger, engineResponse)
-					continue
+				switch rule.GetStatus() {
+				case response.RuleStatusPass:
+					rules = append(rules, rule)
+				case response.RuleStatusError:
+					logger.Info("failed to apply generate rule", "policy", policy.Name, "rule", rule.Name, "reason", rule.Message)
+				case response.RuleStatusSkip:
+					// the rule matched the old resource only
+					if !rule.Success {
+						ws.deleteGR(logger, engineResponse)
+					}
 				}
-				rules = append(rules, rule)
 			}
 
 			if len(rules) > 0 {
@@ -541,6 +557,7 @@ func failedEvents(err error, gr kyverno.GenerateRequestSpec, resource unstructur
 	re.Name = resource.GetName()
 	re.Reason = event.PolicyFailed.String()
 	re.Source = event.GeneratePolicyController
+	re.Policy = gr.Policy
 	re.Message = fmt.Sprintf("policy %s failed to apply: %v", gr.Policy, err)
 
 	return []event.Info{re}
@@ -0,0 +1,107 @@
+package webhooks
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/policyreport"
+	"github.com/kyverno/kyverno/pkg/resultsink"
+	v1beta1 "k8s.io/api/admission/v1beta1"
+)
+
+// mutateResultsTTL is the time the mutate results of a request are held for the validating webhook,
+// it is longer than the maximum webhook timeout of 30 seconds
+const mutateResultsTTL = 35 * time.Second
+
+// pendingMutateResults are the mutate results of an admission request which is not yet allowed
+type pendingMutateResults struct {
+	prInfos []policyreport.Info
+	records []resultsink.Record
+	timer   *time.Timer
+}
+
+// mutateResults holds the results of the mutating webhook until the validating webhook of the same request
+// allows or denies it, as the request can still be rejected after it was mutated.
+// The validating webhook is not called for kinds without validate policies and can be served by another
+// replica, the results which are not released by the validating webhook are reported after mutateResultsTTL.
+type mutateResults struct {
+	mutex sync.Mutex
+	// pending holds the results of each call of the mutating webhook for a request,
+	// it can be called again for the same request when policies are reinvoked
+	pending map[string][]*pendingMutateResults
+	ttl     time.Duration
+	report  func(prInfos []policyreport.Info, records []resultsink.Record)
+}
+
+func newMutateResults(ttl time.Duration, report func(prInfos []policyreport.Info, records []resultsink.Record)) *mutateResults {
+	return &mutateResults{
+		pending: make(map[string][]*pendingMutateResults),
+		ttl:     ttl,
+		report:  report,
+	}
+}
+
+// mutateResultsKey identifies an admission request in the mutating and the validating webhook,
+// the name of the resource is not set in the mutating webhook when it is generated
+func mutateResultsKey(request *v1beta1.AdmissionRequest) string {
+	return string(request.UID)
+}
+
+// hold stores the results until the request is released
+func (m *mutateResults) hold(key string, prInfos []policyreport.Info, records []resultsink.Record) {
+	if len(prInfos) == 0 && len(records) == 0 {
+		return
+	}
+
+	pending := &pendingMutateResults{prInfos: prInfos, records: records}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	pending.timer = time.AfterFunc(m.ttl, func() {
+		m.expire(key, pending)
+	})
+	m.pending[key] = append(m.pending[key], pending)
+}
+
+// release reports the results of the request if it is allowed and drops them otherwise
+func (m *mutateResults) release(key string, allowed bool) {
+	m.mutex.Lock()
+	pending := m.pending[key]
+	for _, p := range pending {
+		p.timer.Stop()
+	}
+	delete(m.pending, key)
+	m.mutex.Unlock()
+
+	if !allowed {
+		return
+	}
+
+	for _, p := range pending {
+		m.report(p.prInfos, p.records)
+	}
+}
+
+func (m *mutateResults) expire(key string, pending *pendingMutateResults) {
+	m.mutex.Lock()
+	found := false
+	remaining := m.pending[key][:0]
+	for _, p := range m.pending[key] {
+		if p == pending {
+			found = true
+			continue
+		}
+		remaining = append(remaining, p)
+	}
+
+	if len(remaining) == 0 {
+		delete(m.pending, key)
+	} else {
+		m.pending[key] = remaining
+	}
+	m.mutex.Unlock()
+
+	if found {
+		m.report(pending.prInfos, pending.records)
+	}
+}
@@ -0,0 +1,125 @@
+package webhooks
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/policyreport"
+	"github.com/kyverno/kyverno/pkg/resultsink"
+	"gotest.tools/assert"
+	v1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type reportedResults struct {
+	sync.Mutex
+	policies []string
+}
+
+func (r *reportedResults) report(prInfos []policyreport.Info, records []resultsink.Record) {
+	r.Lock()
+	defer r.Unlock()
+	for _, info := range prInfos {
+		r.policies = append(r.policies, info.PolicyName)
+	}
+}
+
+func (r *reportedResults) get() []string {
+	r.Lock()
+	defer r.Unlock()
+	return append([]string{}, r.policies...)
+}
+
+func Test_mutateResults(t *testing.T) {
+	request := &v1beta1.AdmissionRequest{
+		UID:       types.UID("1"),
+		Operation: v1beta1.Create,
+		Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Pod"},
+		Namespace: "default",
+		Name:      "nginx",
+	}
+	key := mutateResultsKey(request)
+
+	reported := &reportedResults{}
+	results := newMutateResults(time.Hour, reported.report)
+
+	// denied requests are not reported
+	results.hold(key, []policyreport.Info{{PolicyName: "denied"}}, nil)
+	results.release(key, false)
+	assert.Equal(t, len(reported.get()), 0)
+
+	// allowed requests are reported once
+	results.hold(key, []policyreport.Info{{PolicyName: "allowed"}}, nil)
+	results.release(key, true)
+	results.release(key, true)
+	assert.DeepEqual(t, reported.get(), []string{"allowed"})
+
+	// the results of a reinvoked mutating webhook are all reported
+	results.hold(key, []policyreport.Info{{PolicyName: "first"}}, nil)
+	results.hold(key, []policyreport.Info{{PolicyName: "reinvoked"}}, nil)
+	results.release(key, true)
+	assert.DeepEqual(t, reported.get(), []string{"allowed", "first", "reinvoked"})
+}
+
+func Test_mutateResults_GenerateName(t *testing.T) {
+	reported := &reportedResults{}
+	results := newMutateResults(time.Hour, reported.report)
+
+	// the pods of a ReplicaSet are created concurrently with the same generateName,
+	// the mutating webhook does not see the generated names
+	var requests []*v1beta1.AdmissionRequest
+	for i := 0; i < 2; i++ {
+		requests = append(requests, &v1beta1.AdmissionRequest{
+			UID:       types.UID(fmt.Sprintf("uid-%d", i)),
+			Operation: v1beta1.Create,
+			Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Pod"},
+			Namespace: "default",
+		})
+	}
+
+	var wg sync.WaitGroup
+	for i, request := range requests {
+		wg.Add(1)
+		go func(i int, request *v1beta1.AdmissionRequest) {
+			defer wg.Done()
+			results.hold(mutateResultsKey(request), []policyreport.Info{{PolicyName: fmt.Sprintf("pod-%d", i)}}, nil)
+		}(i, request)
+	}
+	wg.Wait()
+
+	// the validating webhook sees the generated names
+	for i, request := range requests {
+		validated := request.DeepCopy()
+		validated.Name = fmt.Sprintf("nginx-%d", i)
+		results.release(mutateResultsKey(validated), i == 0)
+	}
+	assert.DeepEqual(t, reported.get(), []string{"pod-0"})
+	assert.Equal(t, len(results.pending), 0)
+}
+
+func Test_mutateResults_Expire(t *testing.T) {
+	reported := &reportedResults{}
+	results := newMutateResults(10*time.Millisecond, reported.report)
+
+	results.hold("key", []policyreport.Info{{PolicyName: "expired"}}, nil)
+	assert.Assert(t, waitFor(func() bool { return len(reported.get()) == 1 }))
+	assert.DeepEqual(t, reported.get(), []string{"expired"})
+
+	// released results are not reported again when the timer fires
+	results.release("key", true)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, len(reported.get()), 1)
+}
+
+func waitFor(condition func() bool) bool {
+	for i := 0; i < 100; i++ {
+		if condition() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
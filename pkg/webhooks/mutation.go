@@ -11,12 +11,15 @@ import (
 	"github.com/go-logr/logr"
 	kyverno "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
 	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/auditlog"
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	policyRuleExecutionLatency "github.com/kyverno/kyverno/pkg/metrics/policyruleexecutionlatency"
 	policyRuleResults "github.com/kyverno/kyverno/pkg/metrics/policyruleresults"
+	"github.com/kyverno/kyverno/pkg/policyreport"
+	"github.com/kyverno/kyverno/pkg/resultsink"
 	"github.com/kyverno/kyverno/pkg/utils"
 	"github.com/pkg/errors"
 	v1beta1 "k8s.io/api/admission/v1beta1"
@@ -24,11 +27,11 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
-func (ws *WebhookServer) applyMutatePolicies(request *v1beta1.AdmissionRequest, policyContext *engine.PolicyContext, policies []*v1.ClusterPolicy, ts int64, logger logr.Logger) []byte {
+func (ws *WebhookServer) applyMutatePolicies(request *v1beta1.AdmissionRequest, policyContext *engine.PolicyContext, policies []*v1.ClusterPolicy, ts int64, auditEntry *auditlog.Entry, logger logr.Logger) []byte {
 	var triggeredMutatePolicies []v1.ClusterPolicy
 	var mutateEngineResponses []*response.EngineResponse
 
-	mutatePatches, triggeredMutatePolicies, mutateEngineResponses := ws.handleMutation(request, policyContext, policies, ts)
+	mutatePatches, triggeredMutatePolicies, mutateEngineResponses := ws.handleMutation(request, policyContext, policies, ts, auditEntry)
 	logger.V(6).Info("", "generated patches", string(mutatePatches))
 
 	admissionReviewLatencyDuration := int64(time.Since(time.Unix(ts, 0)))
@@ -43,7 +46,8 @@ func (ws *WebhookServer) handleMutation(
 	request *v1beta1.AdmissionRequest,
 	policyContext *engine.PolicyContext,
 	policies []*kyverno.ClusterPolicy,
-	admissionRequestTimestamp int64) ([]byte, []kyverno.ClusterPolicy, []*response.EngineResponse) {
+	admissionRequestTimestamp int64,
+	auditEntry *auditlog.Entry) ([]byte, []kyverno.ClusterPolicy, []*response.EngineResponse) {
 
 	if len(policies) == 0 {
 		return nil, nil, nil
@@ -74,7 +78,7 @@ func (ws *WebhookServer) handleMutation(
 		return nil, nil, nil
 	}
 	var patches [][]byte
-	var engineResponses []*response.EngineResponse
+	var engineResponses, reportResponses []*response.EngineResponse
 	var triggeredPolicies []kyverno.ClusterPolicy
 
 	for _, policy := range policies {
@@ -85,8 +89,12 @@ func (ws *WebhookServer) handleMutation(
 		logger.V(3).Info("applying policy mutate rules", "policy", policy.Name)
 		policyContext.Policy = *policy
 		engineResponse, policyPatches, err := ws.applyMutation(request, policyContext, logger)
+		if engineResponse != nil {
+			reportResponses = append(reportResponses, engineResponse)
+		}
+
 		if err != nil {
-			// TODO report errors in engineResponse and record in metrics
+			// TODO record errors in metrics
 			logger.Error(err, "mutate error")
 			continue
 		}
@@ -109,6 +117,8 @@ func (ws *WebhookServer) handleMutation(
 		triggeredPolicies = append(triggeredPolicies, *policy)
 	}
 
+	auditEntry.AddEngineResponses(reportResponses...)
+
 	// generate annotations
 	if annPatches := generateAnnotationPatches(engineResponses, logger); annPatches != nil {
 		patches = append(patches, annPatches)
@@ -125,6 +135,16 @@ func (ws *WebhookServer) handleMutation(
 	events := generateEvents(engineResponses, false, (request.Operation == v1beta1.Update), logger)
 	ws.eventGen.Add(events...)
 
+	// mutation failures do not block the request, the results are reported once the request is allowed
+	var prInfos []policyreport.Info
+	records := resultsink.FromEngineResponses(reportResponses, request, resultsink.ActionAllowed)
+
+	// objects like PodExecOptions for subresource requests are not persisted, so they are not reported
+	if policyContext.Subresource.Name == "" || policyContext.Subresource.ParentKind.Kind == request.Kind.Kind {
+		prInfos = policyreport.GeneratePRsFromEngineResponse(reportResponses, logger)
+	}
+	ws.mutateResults.hold(mutateResultsKey(request), prInfos, records)
+
 	// debug info
 	func() {
 		if len(patches) != 0 {
@@ -155,7 +175,7 @@ func (ws *WebhookServer) applyMutation(request *v1beta1.AdmissionRequest, policy
 	}
 
 	if !engineResponse.IsSuccessful() && len(engineResponse.GetFailedRules()) > 0 {
-		return nil, nil, fmt.Errorf("failed to apply policy %s rules %v", policyContext.Policy.Name, engineResponse.GetFailedRules())
+		return engineResponse, nil, fmt.Errorf("failed to apply policy %s rules %v", policyContext.Policy.Name, engineResponse.GetFailedRules())
 	}
 
 	err := ws.openAPIController.ValidateResource(*engineResponse.PatchedResource.DeepCopy(), engineResponse.PatchedResource.GetAPIVersion(), engineResponse.PatchedResource.GetKind())
@@ -209,6 +229,10 @@ func (ms mutateStats) UpdateStatus(status kyverno.PolicyStatus) kyverno.PolicySt
 	}
 
 	for _, rule := range ms.resp.PolicyResponse.Rules {
+		if rule.Status == response.RuleStatusSkip {
+			continue
+		}
+
 		ruleStat := nameToRule[rule.Name]
 		ruleStat.Name = rule.Name
 
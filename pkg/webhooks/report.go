@@ -58,6 +58,10 @@ func generateEvents(engineResponses []*response.EngineResponse, blocked, onUpdat
 				failedRulesStr,
 				er.PolicyResponse.Policy.Name,
 			)
+			pe.Policy = event.PolicyKey(er.PolicyResponse.Policy.Namespace, er.PolicyResponse.Policy.Name)
+			pe.Rule = failedRulesStr
+			re.Policy = pe.Policy
+			re.Rule = failedRulesStr
 			events = append(events, pe, re)
 		}
 
@@ -82,6 +86,8 @@ func generateEvents(engineResponses []*response.EngineResponse, blocked, onUpdat
 				successRulesStr,
 				er.PolicyResponse.Resource.GetKey(),
 			)
+			e.Policy = event.PolicyKey(er.PolicyResponse.Policy.Namespace, er.PolicyResponse.Policy.Name)
+			e.Rule = successRulesStr
 			events = append(events, e)
 		}
 	}
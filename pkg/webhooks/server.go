@@ -17,6 +17,7 @@ import (
 	kyvernoclient "github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
 	kyvernolister "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/auditlog"
 	"github.com/kyverno/kyverno/pkg/common"
 	"github.com/kyverno/kyverno/pkg/config"
 	client "github.com/kyverno/kyverno/pkg/dclient"
@@ -31,12 +32,14 @@ import (
 	"github.com/kyverno/kyverno/pkg/policyreport"
 	"github.com/kyverno/kyverno/pkg/policystatus"
 	"github.com/kyverno/kyverno/pkg/resourcecache"
+	"github.com/kyverno/kyverno/pkg/resultsink"
 	tlsutils "github.com/kyverno/kyverno/pkg/tls"
-	userinfo "github.com/kyverno/kyverno/pkg/userinfo"
+	"github.com/kyverno/kyverno/pkg/tracing"
 	"github.com/kyverno/kyverno/pkg/utils"
 	"github.com/kyverno/kyverno/pkg/webhookconfig"
 	webhookgenerate "github.com/kyverno/kyverno/pkg/webhooks/generate"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 	v1beta1 "k8s.io/api/admission/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	informers "k8s.io/client-go/informers/core/v1"
@@ -112,6 +115,15 @@ type WebhookServer struct {
 	// policy report generator
 	prGenerator policyreport.GeneratorInterface
 
+	// publishes the policy results to the external result sinks
+	resultSink resultsink.Interface
+
+	// holds the mutate results of the admission requests until they are allowed
+	mutateResults *mutateResults
+
+	// records the decisions of the resource webhooks, nil if disabled
+	auditLog *auditlog.Logger
+
 	// generate request generator
 	grGenerator *webhookgenerate.Generator
 
@@ -154,6 +166,8 @@ func NewWebhookServer(
 	statusSync policystatus.Listener,
 	configHandler config.Interface,
 	prGenerator policyreport.GeneratorInterface,
+	resultSink resultsink.Interface,
+	auditLog *auditlog.Logger,
 	grGenerator *webhookgenerate.Generator,
 	auditHandler AuditHandler,
 	cleanUp chan<- struct{},
@@ -201,6 +215,8 @@ func NewWebhookServer(
 		cleanUp:           cleanUp,
 		webhookMonitor:    webhookMonitor,
 		prGenerator:       prGenerator,
+		resultSink:        resultSink,
+		auditLog:          auditLog,
 		grGenerator:       grGenerator,
 		grController:      grc,
 		auditHandler:      auditHandler,
@@ -209,14 +225,21 @@ func NewWebhookServer(
 		resCache:          resCache,
 		promConfig:        promConfig,
 	}
+	ws.mutateResults = newMutateResults(mutateResultsTTL, ws.reportMutateResults)
 
 	mux := httprouter.New()
-	mux.HandlerFunc("POST", config.MutatingWebhookServicePath, ws.handlerFunc(ws.resourceMutation, true))
-	mux.HandlerFunc("POST", config.ValidatingWebhookServicePath, ws.handlerFunc(ws.resourceValidation, true))
+	mux.HandlerFunc("POST", config.MutatingWebhookServicePath, ws.handlerFunc(ws.auditedHandler(auditlog.WebhookMutate, ws.resourceMutation), true))
+	mux.HandlerFunc("POST", config.ValidatingWebhookServicePath, ws.handlerFunc(ws.auditedHandler(auditlog.WebhookValidate, ws.resourceValidation), true))
 	mux.HandlerFunc("POST", config.PolicyMutatingWebhookServicePath, ws.handlerFunc(ws.policyMutation, true))
 	mux.HandlerFunc("POST", config.PolicyValidatingWebhookServicePath, ws.handlerFunc(ws.policyValidation, true))
 	mux.HandlerFunc("POST", config.VerifyMutatingWebhookServicePath, ws.handlerFunc(ws.verifyHandler, false))
 
+	conversionHandler, err := policyreport.NewConversionHandler()
+	if err != nil {
+		return nil, err
+	}
+	mux.Handler("POST", config.ConversionWebhookServicePath, conversionHandler)
+
 	// Handle Liveness responds to a Kubernetes Liveness probe
 	// Fail this request if Kubernetes should restart this instance
 	mux.HandlerFunc("GET", config.LivenessServicePath, func(w http.ResponseWriter, r *http.Request) {
@@ -247,7 +270,10 @@ func NewWebhookServer(
 	return ws, nil
 }
 
-func (ws *WebhookServer) handlerFunc(handler func(request *v1beta1.AdmissionRequest) *v1beta1.AdmissionResponse, filter bool) http.HandlerFunc {
+// admissionHandler handles an admission request, ctx carries the span of the request
+type admissionHandler func(ctx context.Context, request *v1beta1.AdmissionRequest) *v1beta1.AdmissionResponse
+
+func (ws *WebhookServer) handlerFunc(handler admissionHandler, filter bool) http.HandlerFunc {
 	return func(rw http.ResponseWriter, r *http.Request) {
 		startTime := time.Now()
 		ws.webhookMonitor.SetTime(startTime)
@@ -273,7 +299,17 @@ func (ws *WebhookServer) handlerFunc(handler func(request *v1beta1.AdmissionRequ
 			return
 		}
 
-		admissionReview.Response = handler(request)
+		ctx, span := tracing.StartSpan(tracing.ExtractHTTP(r), "admission "+r.URL.Path,
+			tracing.RequestUIDKey.String(string(request.UID)),
+			tracing.RequestOperationKey.String(string(request.Operation)),
+			tracing.ResourceKindKey.String(request.Kind.Kind),
+			tracing.ResourceNamespaceKey.String(request.Namespace),
+			tracing.ResourceNameKey.String(request.Name),
+		)
+		admissionReview.Response = handler(ctx, request)
+		span.SetAttributes(attribute.Bool("kyverno.request.allowed", admissionReview.Response.Allowed))
+		span.End()
+
 		writeResponse(rw, admissionReview)
 		logger.V(4).Info("admission review request processed", "time", time.Since(startTime).String())
 
@@ -281,6 +317,17 @@ func (ws *WebhookServer) handlerFunc(handler func(request *v1beta1.AdmissionRequ
 	}
 }
 
+// auditedHandler records the decision of a resource webhook in the audit log
+func (ws *WebhookServer) auditedHandler(webhook string, handler func(ctx context.Context, request *v1beta1.AdmissionRequest, auditEntry *auditlog.Entry) *v1beta1.AdmissionResponse) admissionHandler {
+	return func(ctx context.Context, request *v1beta1.AdmissionRequest) *v1beta1.AdmissionResponse {
+		auditEntry := ws.auditLog.NewEntry(webhook, request)
+		admissionResponse := handler(ctx, request, auditEntry)
+		auditEntry.SetResponse(admissionResponse)
+		ws.auditLog.Add(auditEntry)
+		return admissionResponse
+	}
+}
+
 func writeResponse(rw http.ResponseWriter, admissionReview *v1beta1.AdmissionReview) {
 	responseJSON, err := json.Marshal(admissionReview)
 	if err != nil {
@@ -295,7 +342,7 @@ func writeResponse(rw http.ResponseWriter, admissionReview *v1beta1.AdmissionRev
 }
 
 // resourceMutation mutates resource
-func (ws *WebhookServer) resourceMutation(request *v1beta1.AdmissionRequest) *v1beta1.AdmissionResponse {
+func (ws *WebhookServer) resourceMutation(ctx context.Context, request *v1beta1.AdmissionRequest, auditEntry *auditlog.Entry) *v1beta1.AdmissionResponse {
 	logger := ws.log.WithName("MutateWebhook").WithValues("uid", request.UID, "kind", request.Kind.Kind, "namespace", request.Namespace, "name", request.Name, "operation", request.Operation, "gvk", request.Kind.String())
 
 	if excludeKyvernoResources(request.Kind.Kind) {
@@ -305,9 +352,10 @@ func (ws *WebhookServer) resourceMutation(request *v1beta1.AdmissionRequest) *v1
 	logger.V(4).Info("received an admission request in mutating webhook")
 	requestTime := time.Now().Unix()
 
-	mutatePolicies := ws.pCache.GetPolicies(policycache.Mutate, request.Kind.Kind, request.Namespace)
+	subresource := getSubresource(ws.client, request, logger)
+	mutatePolicies := getPolicies(ws.pCache, policycache.Mutate, request.Kind.Kind, subresource, request.Namespace)
 	generatePolicies := ws.pCache.GetPolicies(policycache.Generate, request.Kind.Kind, request.Namespace)
-	verifyImagesPolicies := ws.pCache.GetPolicies(policycache.VerifyImages, request.Kind.Kind, request.Namespace)
+	verifyImagesPolicies := getPolicies(ws.pCache, policycache.VerifyImages, request.Kind.Kind, subresource, request.Namespace)
 
 	if len(mutatePolicies) == 0 && len(generatePolicies) == 0 && len(verifyImagesPolicies) == 0 {
 		logger.V(4).Info("no policies matched admission request")
@@ -320,18 +368,19 @@ func (ws *WebhookServer) resourceMutation(request *v1beta1.AdmissionRequest) *v1
 	}
 
 	addRoles := containsRBACInfo(mutatePolicies, generatePolicies)
-	policyContext, err := ws.buildPolicyContext(request, addRoles)
+	policyContext, err := ws.buildPolicyContext(ctx, request, subresource, addRoles)
 	if err != nil {
 		logger.Error(err, "failed to build policy context")
 		return failureResponse(err.Error())
 	}
 
-	mutatePatches := ws.applyMutatePolicies(request, policyContext, mutatePolicies, requestTime, logger)
+	mutatePatches := ws.applyMutatePolicies(request, policyContext, mutatePolicies, requestTime, auditEntry, logger)
 
 	newRequest := patchRequest(mutatePatches, request, logger)
-	imagePatches, err := ws.applyImageVerifyPolicies(newRequest, policyContext, verifyImagesPolicies, logger)
+	imagePatches, err := ws.applyImageVerifyPolicies(newRequest, policyContext, verifyImagesPolicies, auditEntry, logger)
 	if err != nil {
 		logger.Error(err, "image verification failed")
+		ws.mutateResults.release(mutateResultsKey(request), false)
 		return failureResponse(err.Error())
 	}
 
@@ -342,6 +391,12 @@ func (ws *WebhookServer) resourceMutation(request *v1beta1.AdmissionRequest) *v1
 	return successResponse(patches)
 }
 
+// reportMutateResults reports the results of the mutate rules of an allowed admission request
+func (ws *WebhookServer) reportMutateResults(prInfos []policyreport.Info, records []resultsink.Record) {
+	ws.resultSink.Publish(records...)
+	ws.prGenerator.Add(prInfos...)
+}
+
 // patchRequest applies patches to the request.Object and returns a new copy of the request
 func patchRequest(patches []byte, request *v1beta1.AdmissionRequest, logger logr.Logger) *v1beta1.AdmissionRequest {
 	patchedResource := processResourceWithPatches(patches, request.Object.Raw, logger)
@@ -350,13 +405,16 @@ func patchRequest(patches []byte, request *v1beta1.AdmissionRequest, logger logr
 	return newRequest
 }
 
-func (ws *WebhookServer) buildPolicyContext(request *v1beta1.AdmissionRequest, addRoles bool) (*engine.PolicyContext, error) {
+func (ws *WebhookServer) buildPolicyContext(traceCtx context.Context, request *v1beta1.AdmissionRequest, subresource engine.Subresource, addRoles bool) (*engine.PolicyContext, error) {
+	_, span := tracing.StartSpan(traceCtx, "build-policy-context")
+	defer span.End()
+
 	userRequestInfo := v1.RequestInfo{
 		AdmissionUserInfo: *request.UserInfo.DeepCopy(),
 	}
 
 	if addRoles {
-		if roles, clusterRoles, err := userinfo.GetRoleRef(ws.rbLister, ws.crbLister, request, ws.configHandler); err != nil {
+		if roles, clusterRoles, err := ws.getRoleRef(traceCtx, request); err != nil {
 			return nil, errors.Wrap(err, "failed to fetch RBAC information for request")
 		} else {
 			userRequestInfo.Roles = roles
@@ -375,6 +433,7 @@ func (ws *WebhookServer) buildPolicyContext(request *v1beta1.AdmissionRequest, a
 		return nil, errors.Wrap(err, "failed to convert raw resource to unstructured format")
 	}
 
+	setSubresourceMetadata(&resource, request)
 	if err := ctx.AddImageInfo(&resource); err != nil {
 		return nil, errors.Wrap(err, "failed to add image information to the policy rule context")
 	}
@@ -387,6 +446,9 @@ func (ws *WebhookServer) buildPolicyContext(request *v1beta1.AdmissionRequest, a
 		ResourceCache:       ws.resCache,
 		JSONContext:         ctx,
 		Client:              ws.client,
+		Subresource:         subresource,
+		PromConfig:          ws.promConfig,
+		TraceContext:        traceCtx,
 	}
 
 	if request.Operation == v1beta1.Update {
@@ -396,6 +458,11 @@ func (ws *WebhookServer) buildPolicyContext(request *v1beta1.AdmissionRequest, a
 	return policyContext, nil
 }
 
+// getRoleRef returns the roles and cluster roles bound to the user of the request
+func (ws *WebhookServer) getRoleRef(traceCtx context.Context, request *v1beta1.AdmissionRequest) ([]string, []string, error) {
+	return getRoleRef(traceCtx, ws.rbLister, ws.crbLister, request, ws.configHandler, ws.promConfig, ws.log)
+}
+
 func successResponse(patch []byte) *v1beta1.AdmissionResponse {
 	r := &v1beta1.AdmissionResponse{
 		Allowed: true,
@@ -463,8 +530,12 @@ func registerAdmissionReviewLatencyMetricGenerate(logger logr.Logger, promMetric
 	}
 }
 
-func (ws *WebhookServer) resourceValidation(request *v1beta1.AdmissionRequest) *v1beta1.AdmissionResponse {
+func (ws *WebhookServer) resourceValidation(traceCtx context.Context, request *v1beta1.AdmissionRequest, auditEntry *auditlog.Entry) (admissionResponse *v1beta1.AdmissionResponse) {
 	logger := ws.log.WithName("ValidateWebhook").WithValues("uid", request.UID, "kind", request.Kind.Kind, "namespace", request.Namespace, "name", request.Name, "operation", request.Operation)
+	defer func() {
+		ws.mutateResults.release(mutateResultsKey(request), admissionResponse.Allowed)
+	}()
+
 	if request.Operation == v1beta1.Delete {
 		ws.handleDelete(request)
 	}
@@ -477,15 +548,20 @@ func (ws *WebhookServer) resourceValidation(request *v1beta1.AdmissionRequest) *
 	// timestamp at which this admission request got triggered
 	admissionRequestTimestamp := time.Now().Unix()
 
-	policies := ws.pCache.GetPolicies(policycache.ValidateEnforce, request.Kind.Kind, "")
+	subresource := getSubresource(ws.client, request, logger)
+	policies := getPolicies(ws.pCache, policycache.ValidateEnforce, request.Kind.Kind, subresource, "")
 	// Get namespace policies from the cache for the requested resource namespace
-	nsPolicies := ws.pCache.GetPolicies(policycache.ValidateEnforce, request.Kind.Kind, request.Namespace)
+	nsPolicies := getPolicies(ws.pCache, policycache.ValidateEnforce, request.Kind.Kind, subresource, request.Namespace)
 	policies = append(policies, nsPolicies...)
 
+	// the span is ended once the policy context is built, ending it again on early returns is a no-op
+	buildCtx, buildSpan := tracing.StartSpan(traceCtx, "build-policy-context")
+	defer buildSpan.End()
+
 	var roles, clusterRoles []string
 	if containsRBACInfo(policies) {
 		var err error
-		roles, clusterRoles, err = userinfo.GetRoleRef(ws.rbLister, ws.crbLister, request, ws.configHandler)
+		roles, clusterRoles, err = ws.getRoleRef(buildCtx, request)
 		if err != nil {
 			return errorResponse(logger, err, "failed to fetch RBAC data")
 		}
@@ -512,6 +588,15 @@ func (ws *WebhookServer) resourceValidation(request *v1beta1.AdmissionRequest) *
 		return errorResponse(logger, err, "failed create parse resource")
 	}
 
+	setSubresourceMetadata(&newResource, request)
+	setSubresourceMetadata(&oldResource, request)
+
+	// apply the validationFailureActionOverrides of the policies for the requested namespace
+	resource := newResource
+	if request.Operation == v1beta1.Delete {
+		resource = oldResource
+	}
+	policies = filterPoliciesByValidationFailureAction(policies, true, request, resource, namespaceLabels)
 	if err := ctx.AddImageInfo(&newResource); err != nil {
 		return errorResponse(logger, err, "failed add image information to policy rule context")
 	}
@@ -525,13 +610,19 @@ func (ws *WebhookServer) resourceValidation(request *v1beta1.AdmissionRequest) *
 		ResourceCache:       ws.resCache,
 		JSONContext:         ctx,
 		Client:              ws.client,
+		Subresource:         subresource,
+		PromConfig:          ws.promConfig,
+		TraceContext:        traceCtx,
 	}
+	buildSpan.End()
 
 	vh := &validationHandler{
 		log:            ws.log,
 		statusListener: ws.statusListener,
 		eventGen:       ws.eventGen,
 		prGenerator:    ws.prGenerator,
+		resultSink:     ws.resultSink,
+		auditEntry:     auditEntry,
 	}
 
 	ok, msg := vh.handleValidation(ws.promConfig, request, policies, policyContext, namespaceLabels, admissionRequestTimestamp)
@@ -1,6 +1,8 @@
 package webhooks
 
 import (
+	"context"
+
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/utils"
 	"strings"
@@ -13,6 +15,7 @@ import (
 
 	"github.com/go-logr/logr"
 	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/auditlog"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/metrics"
@@ -20,7 +23,8 @@ import (
 	"github.com/kyverno/kyverno/pkg/policyreport"
 	"github.com/kyverno/kyverno/pkg/policystatus"
 	"github.com/kyverno/kyverno/pkg/resourcecache"
-	"github.com/kyverno/kyverno/pkg/userinfo"
+	"github.com/kyverno/kyverno/pkg/resultsink"
+	"github.com/kyverno/kyverno/pkg/tracing"
 	"k8s.io/api/admission/v1beta1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -53,6 +57,8 @@ type auditHandler struct {
 	eventGen       event.Interface
 	statusListener policystatus.Listener
 	prGenerator    policyreport.GeneratorInterface
+	resultSink     resultsink.Interface
+	auditLog       *auditlog.Logger
 
 	rbLister       rbaclister.RoleBindingLister
 	rbSynced       cache.InformerSynced
@@ -72,6 +78,8 @@ func NewValidateAuditHandler(pCache policycache.Interface,
 	eventGen event.Interface,
 	statusListener policystatus.Listener,
 	prGenerator policyreport.GeneratorInterface,
+	resultSink resultsink.Interface,
+	auditLog *auditlog.Logger,
 	rbInformer rbacinformer.RoleBindingInformer,
 	crbInformer rbacinformer.ClusterRoleBindingInformer,
 	namespaces informers.NamespaceInformer,
@@ -94,6 +102,8 @@ func NewValidateAuditHandler(pCache policycache.Interface,
 		nsListerSynced: namespaces.Informer().HasSynced,
 		log:            log,
 		prGenerator:    prGenerator,
+		resultSink:     resultSink,
+		auditLog:       auditLog,
 		configHandler:  dynamicConfig,
 		resCache:       resCache,
 		client:         client,
@@ -158,11 +168,21 @@ func (h *auditHandler) process(request *v1beta1.AdmissionRequest) error {
 	admissionRequestTimestamp := time.Now().Unix()
 	logger := h.log.WithName("process")
 
-	policies := h.pCache.GetPolicies(policycache.ValidateAudit, request.Kind.Kind, request.Namespace)
+	traceCtx, span := tracing.StartSpan(context.Background(), "audit",
+		tracing.RequestUIDKey.String(string(request.UID)),
+		tracing.RequestOperationKey.String(string(request.Operation)),
+		tracing.ResourceKindKey.String(request.Kind.Kind),
+		tracing.ResourceNamespaceKey.String(request.Namespace),
+		tracing.ResourceNameKey.String(request.Name),
+	)
+	defer span.End()
+
+	subresource := getSubresource(h.client, request, logger)
+	policies := getPolicies(h.pCache, policycache.ValidateAudit, request.Kind.Kind, subresource, request.Namespace)
 
 	// getRoleRef only if policy has roles/clusterroles defined
 	if containsRBACInfo(policies) {
-		roles, clusterRoles, err = userinfo.GetRoleRef(h.rbLister, h.crbLister, request, h.configHandler)
+		roles, clusterRoles, err = getRoleRef(traceCtx, h.rbLister, h.crbLister, request, h.configHandler, h.promConfig, logger)
 		if err != nil {
 			logger.Error(err, "failed to get RBAC information for request")
 		}
@@ -188,6 +208,15 @@ func (h *auditHandler) process(request *v1beta1.AdmissionRequest) error {
 		return errors.Wrap(err, "failed create parse resource")
 	}
 
+	setSubresourceMetadata(&newResource, request)
+	setSubresourceMetadata(&oldResource, request)
+
+	// apply the validationFailureActionOverrides of the policies for the requested namespace
+	resource := newResource
+	if request.Operation == v1beta1.Delete {
+		resource = oldResource
+	}
+	policies = filterPoliciesByValidationFailureAction(policies, false, request, resource, namespaceLabels)
 	if err := ctx.AddImageInfo(&newResource); err != nil {
 		return errors.Wrap(err, "failed add image information to policy rule context\"")
 	}
@@ -201,16 +230,25 @@ func (h *auditHandler) process(request *v1beta1.AdmissionRequest) error {
 		ResourceCache:       h.resCache,
 		JSONContext:         ctx,
 		Client:              h.client,
+		Subresource:         subresource,
+		PromConfig:          h.promConfig,
+		TraceContext:        traceCtx,
 	}
 
+	// the request is already allowed, the results of the audit policies are recorded in a separate entry
+	auditEntry := h.auditLog.NewEntry(auditlog.WebhookAudit, request)
 	vh := &validationHandler{
 		log:            h.log,
 		statusListener: h.statusListener,
 		eventGen:       h.eventGen,
 		prGenerator:    h.prGenerator,
+		resultSink:     h.resultSink,
+		auditEntry:     auditEntry,
 	}
 
 	vh.handleValidation(h.promConfig, request, policies, policyContext, namespaceLabels, admissionRequestTimestamp)
+	auditEntry.SetResponse(successResponse(nil))
+	h.auditLog.Add(auditEntry)
 	return nil
 }
 
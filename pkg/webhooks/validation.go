@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-logr/logr"
 	kyverno "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/auditlog"
 	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/response"
@@ -17,6 +18,7 @@ import (
 	policyRuleExecutionLatency "github.com/kyverno/kyverno/pkg/metrics/policyruleexecutionlatency"
 	policyRuleResults "github.com/kyverno/kyverno/pkg/metrics/policyruleresults"
 	"github.com/kyverno/kyverno/pkg/policyreport"
+	"github.com/kyverno/kyverno/pkg/resultsink"
 	v1beta1 "k8s.io/api/admission/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -27,6 +29,8 @@ type validationHandler struct {
 	statusListener policystatus.Listener
 	eventGen       event.Interface
 	prGenerator    policyreport.GeneratorInterface
+	resultSink     resultsink.Interface
+	auditEntry     *auditlog.Entry
 }
 
 // handleValidation handles validating webhook admission request
@@ -93,6 +97,8 @@ func (v *validationHandler) handleValidation(
 		}
 	}
 
+	v.auditEntry.AddEngineResponses(engineResponses...)
+
 	// If Validation fails then reject the request
 	// no violations will be created on "enforce"
 	blocked := toBlockResource(engineResponses, logger)
@@ -109,6 +115,13 @@ func (v *validationHandler) handleValidation(
 	//   create an event on the resource
 	events := generateEvents(engineResponses, blocked, (request.Operation == v1beta1.Update), logger)
 	v.eventGen.Add(events...)
+
+	action := resultsink.ActionAllowed
+	if blocked {
+		action = resultsink.ActionBlocked
+	}
+	v.resultSink.Publish(resultsink.FromEngineResponses(engineResponses, request, action)...)
+
 	if blocked {
 		logger.V(4).Info("resource blocked")
 		//registering the kyverno_admission_review_latency_milliseconds metric concurrently
@@ -122,8 +135,11 @@ func (v *validationHandler) handleValidation(
 		return true, ""
 	}
 
-	prInfos := policyreport.GeneratePRsFromEngineResponse(engineResponses, logger)
-	v.prGenerator.Add(prInfos...)
+	// objects like PodExecOptions for subresource requests are not persisted, so they are not reported
+	if policyContext.Subresource.Name == "" || policyContext.Subresource.ParentKind.Kind == request.Kind.Kind {
+		prInfos := policyreport.GeneratePRsFromEngineResponse(engineResponses, logger)
+		v.prGenerator.Add(prInfos...)
+	}
 
 	//registering the kyverno_admission_review_latency_milliseconds metric concurrently
 	admissionReviewLatencyDuration := int64(time.Since(time.Unix(admissionRequestTimestamp, 0)))
@@ -225,7 +241,7 @@ func (vs validateStats) UpdateStatus(status kyverno.PolicyStatus) kyverno.Policy
 		} else {
 			status.RulesFailedCount++
 			ruleStat.FailedCount++
-			if vs.resp.PolicyResponse.ValidationFailureAction == "enforce" {
+			if ruleValidationFailureAction(vs.resp, rule) == "enforce" {
 				status.ResourcesBlockedCount++
 				ruleStat.ResourcesBlockedCount++
 			}
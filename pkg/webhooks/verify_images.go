@@ -4,14 +4,15 @@ import (
 	"errors"
 	"github.com/go-logr/logr"
 	v1 "github.com/kyverno/kyverno/pkg/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/auditlog"
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
 	"k8s.io/api/admission/v1beta1"
 )
 
-func (ws *WebhookServer) applyImageVerifyPolicies(request *v1beta1.AdmissionRequest, policyContext *engine.PolicyContext, policies []*v1.ClusterPolicy, logger logr.Logger) ([]byte, error) {
-	ok, message, imagePatches := ws.handleVerifyImages(request, policyContext, policies)
+func (ws *WebhookServer) applyImageVerifyPolicies(request *v1beta1.AdmissionRequest, policyContext *engine.PolicyContext, policies []*v1.ClusterPolicy, auditEntry *auditlog.Entry, logger logr.Logger) ([]byte, error) {
+	ok, message, imagePatches := ws.handleVerifyImages(request, policyContext, policies, auditEntry)
 	if !ok {
 		return nil, errors.New(message)
 	}
@@ -22,7 +23,8 @@ func (ws *WebhookServer) applyImageVerifyPolicies(request *v1beta1.AdmissionRequ
 
 func (ws *WebhookServer) handleVerifyImages(request *v1beta1.AdmissionRequest,
 	policyContext *engine.PolicyContext,
-	policies []*v1.ClusterPolicy) (bool, string, []byte) {
+	policies []*v1.ClusterPolicy,
+	auditEntry *auditlog.Entry) (bool, string, []byte) {
 
 	if len(policies) == 0 {
 		return true, "", nil
@@ -40,6 +42,8 @@ func (ws *WebhookServer) handleVerifyImages(request *v1beta1.AdmissionRequest,
 		patches = append(patches, resp.GetPatches()...)
 	}
 
+	auditEntry.AddEngineResponses(engineResponses...)
+
 	blocked := toBlockResource(engineResponses, logger)
 	if blocked {
 		logger.V(4).Info("resource blocked")